@@ -0,0 +1,316 @@
+package config
+
+import (
+	"os"
+	"time"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+	u "web_server/utils"
+)
+
+// belirtilen file mevcut mu kontrol edilir. File yoksa hata dönmez false döner.
+func fileExists(pathKey int, pathFields ...string) (bool, error) {
+	var fileEng *FileEngine[struct{}] = &FileEngine[struct{}]{Owner: env.System}
+	fullPath, err := fileEng.IFGetRootFilePath(pathKey, pathFields...)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := os.Stat(fullPath); err != nil {
+		switch {
+		case os.IsNotExist(err):
+			return false, nil
+		case os.IsPermission(err):
+			return false, env.GetFuncError(env.PermissionDenied, nil, fullPath)
+		default:
+			return false, env.GetFuncError(env.UnexpectedError, err)
+		}
+	}
+	return true, nil
+}
+
+// any türündeki datanın canonical cbor formatı üzerinden cid v1 bilgisi üretilir. Map içeren datalarda da aynı cid üretilir.
+func generateDataCID(data any) ([]byte, error) {
+	encodedData, err := u.MarshalCanonicalCBOR(data)
+	if err != nil {
+		return nil, err
+	}
+	return u.DatatoCIDv1Byte(encodedData)
+}
+
+/*
+- sistem ilk açılışında main store üzerinde bootstrap bundle varsa okunur.
+- bundle içerisindeki system pub key ile bundle imzası, whitelist ve main env imzaları doğrulanır.
+- doğrulanan datalar main store üzerine yazılır, trust kaydı oluşturulur ve bundle güvenli şekilde silinir.
+- trust kaydı oluştuktan sonra bundle ile tekrar açılış yapılamaz.
+- store üzerinde system pub key varsa bundle içerisindeki system pub key ile birebir aynı olmak zorundadır.
+*/
+func includeBootstrapBundle() error {
+	bundleExists, err := fileExists(env.MainPathEnvsPathKey, env.BootstrapBundleField)
+	if err != nil {
+		return err
+	}
+
+	//bundle yoksa sistem mevcut store üzerinden açılır.
+	if !bundleExists {
+		return nil
+	}
+
+	trustExists, err := fileExists(env.MainPathEnvsPathKey, env.TrustStateField)
+	if err != nil {
+		return err
+	}
+
+	//trust kurulduktan sonra bundle kabul edilmez. Bundle silinmez, inceleme için yerinde bırakılır.
+	if trustExists {
+		return env.GetFuncError(env.TrustAlreadyEstablished, nil, env.BootstrapBundleField)
+	}
+
+	var bundleEng *FileEngine[e.BootstrapBundleData] = &FileEngine[e.BootstrapBundleData]{Owner: env.System}
+	bundleData := &e.BootstrapBundleData{}
+	if err := bundleEng.IFGet(e.GetInput[e.BootstrapBundleData]{
+		PathKey:    env.MainPathEnvsPathKey,
+		PathFields: []string{env.BootstrapBundleField},
+		Data:       bundleData,
+	}); err != nil {
+		return err
+	}
+
+	if err := checkBootstrapBundle(bundleData); err != nil {
+		return err
+	}
+
+	//store üzerinde system pub key varsa bundle yalnızca aynı key ile imzalanmış ise kabul edilir.
+	storedPubKey, err := getStoredSystemPubKey()
+	if err != nil {
+		return err
+	}
+	if storedPubKey != nil {
+		if err := compareSystemPubKey(storedPubKey, &bundleData.BootstrapInfos.SystemPubKeyInfo, env.BootstrapBundleField); err != nil {
+			return err
+		}
+	}
+
+	if err := persistBootstrapBundle(bundleData); err != nil {
+		return err
+	}
+
+	//trust kaydı yazıldıktan sonra bundle içeriği ezilerek silinir.
+	return bundleEng.IFSecureRemove(env.MainPathEnvsPathKey, env.BootstrapBundleField)
+}
+
+// bundle status, imza ve içerisindeki datalar kontrol edilir.
+func checkBootstrapBundle(bundleData *e.BootstrapBundleData) error {
+	bootstrapInfos := &bundleData.BootstrapInfos
+
+	//bundle status bilgisi kontrol edilir.
+	if err := u.CheckDataStatusInfos(&e.CheckDataStatusInfosInput{
+		Status:      bootstrapInfos.StatusInfos.Status,
+		ActiveAt:    bootstrapInfos.StatusInfos.ActiveAt,
+		ExpiresAt:   bootstrapInfos.StatusInfos.ExpiresAt,
+		Description: bootstrapInfos.StatusInfos.Description,
+	}); err != nil {
+		return err
+	}
+
+	//bundle içerisindeki system pub key status bilgisi kontrol edilir.
+	sysPubKeyData := &bootstrapInfos.SystemPubKeyInfo
+	if err := u.CheckDataStatusInfos(&e.CheckDataStatusInfosInput{
+		Status:      sysPubKeyData.StatusInfo.Status,
+		ActiveAt:    sysPubKeyData.StatusInfo.ActiveAt,
+		ExpiresAt:   sysPubKeyData.StatusInfo.ExpiresAt,
+		Description: sysPubKeyData.StatusInfo.Description,
+	}); err != nil {
+		return err
+	}
+
+	//bundle imzası bundle içerisindeki system pub key ile kontrol edilir.
+	if err := u.VerifySign(e.VerifySignInput[e.BootstrapData]{
+		SignType:  env.SignTypeED25519,
+		PublicKey: sysPubKeyData.PubKey,
+		Signed:    bundleData.SignatureInfos.Signature,
+		Data:      *bootstrapInfos,
+	}); err != nil {
+		return err
+	}
+
+	//whitelist ve main env normal açılıştaki kontrollerden geçirilir.
+	if err := checkSysWhitelist(sysPubKeyData, &bootstrapInfos.WhitelistInfos); err != nil {
+		return err
+	}
+
+	return checkMainEnv(sysPubKeyData, &bootstrapInfos.MainEnvInfos)
+}
+
+// doğrulanan bundle datası main store üzerine yazılır ve en son trust kaydı oluşturulur.
+func persistBootstrapBundle(bundleData *e.BootstrapBundleData) error {
+	bootstrapInfos := &bundleData.BootstrapInfos
+
+	var pubKeyEng *FileEngine[e.PubKeyData] = &FileEngine[e.PubKeyData]{Owner: env.System}
+	if err := pubKeyEng.IFPut(e.PutInput[e.PubKeyData]{
+		PathKey:    env.MainPathEnvsPathKey,
+		PathFields: []string{env.SystemPubKeyField},
+		Data:       &bootstrapInfos.SystemPubKeyInfo,
+	}); err != nil {
+		return err
+	}
+
+	var sysWhitelistEng *FileEngine[e.SystemWhiteListData[string, e.WhitelistOwnerData]] = &FileEngine[e.SystemWhiteListData[string, e.WhitelistOwnerData]]{Owner: env.System}
+	if err := sysWhitelistEng.IFPut(e.PutInput[e.SystemWhiteListData[string, e.WhitelistOwnerData]]{
+		PathKey:    env.MainPathEnvsPathKey,
+		PathFields: []string{env.WhitelistEnvMapField},
+		Data:       &bootstrapInfos.WhitelistInfos,
+	}); err != nil {
+		return err
+	}
+
+	var mainEnvEng *FileEngine[e.EnvFileData[string, e.EnvData[[]byte]]] = &FileEngine[e.EnvFileData[string, e.EnvData[[]byte]]]{Owner: env.System}
+	if err := mainEnvEng.IFPut(e.PutInput[e.EnvFileData[string, e.EnvData[[]byte]]]{
+		PathKey:    env.MainPathEnvsPathKey,
+		PathFields: []string{env.MainEnvMapField},
+		Data:       &bootstrapInfos.MainEnvInfos,
+	}); err != nil {
+		return err
+	}
+
+	sysPubKeyCID, err := systemPubKeyCID(&bootstrapInfos.SystemPubKeyInfo)
+	if err != nil {
+		return err
+	}
+
+	bundleCID, err := generateDataCID(bundleData)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+	var trustStateEng *FileEngine[e.TrustStateData] = &FileEngine[e.TrustStateData]{Owner: env.System}
+	return trustStateEng.IFPut(e.PutInput[e.TrustStateData]{
+		PathKey:    env.MainPathEnvsPathKey,
+		PathFields: []string{env.TrustStateField},
+		Data: &e.TrustStateData{
+			SystemPubKeyCID: sysPubKeyCID,
+			BundleCID:       bundleCID,
+			StatusInfos: e.StatusData{
+				Status:      true,
+				CreatedAt:   now,
+				ActiveAt:    now,
+				UpdatedAt:   now,
+				Description: env.BootstrapBundleField,
+			},
+		},
+	})
+}
+
+// system pub key trust cid bilgisi status alanları olmadan sadece sign type ve pub key üzerinden üretilir.
+func systemPubKeyCID(sysPubKeyData *e.PubKeyData) ([]byte, error) {
+	return generateDataCID(e.TrustPubKeyData{
+		SignType: env.SignTypeED25519,
+		PubKey:   sysPubKeyData.PubKey,
+	})
+}
+
+/*
+- trust kaydı varsa system pub key cid bilgisi trust kaydı ile karşılaştırılır, ardından key status bilgisi ayrıca kontrol edilir.
+- status güncellemeleri trust kaydını bozmaz. Key material cid içermeyen eski trust kayıtları için tüm pub key datası üzerinden üretilen cid kabul edilir.
+*/
+func checkTrustState(sysPubKeyData *e.PubKeyData) error {
+	trustExists, err := fileExists(env.MainPathEnvsPathKey, env.TrustStateField)
+	if err != nil {
+		return err
+	}
+
+	//bootstrap bundle ile kurulmamış eski store yapıları için kontrol atlanır.
+	if !trustExists {
+		return nil
+	}
+
+	var trustStateEng *FileEngine[e.TrustStateData] = &FileEngine[e.TrustStateData]{Owner: env.System}
+	trustState := &e.TrustStateData{}
+	if err := trustStateEng.IFGet(e.GetInput[e.TrustStateData]{
+		PathKey:    env.MainPathEnvsPathKey,
+		PathFields: []string{env.TrustStateField},
+		Data:       trustState,
+	}); err != nil {
+		return err
+	}
+
+	sysPubKeyCID, err := systemPubKeyCID(sysPubKeyData)
+	if err != nil {
+		return err
+	}
+
+	if !u.ComparisonHash(trustState.SystemPubKeyCID, sysPubKeyCID) {
+		legacyPubKeyCID, err := generateDataCID(*sysPubKeyData)
+		if err != nil {
+			return err
+		}
+		if !u.ComparisonHash(trustState.SystemPubKeyCID, legacyPubKeyCID) {
+			return env.GetFuncError(env.TrustStateMismatch, nil, env.SystemPubKeyField)
+		}
+	}
+
+	return u.CheckDataStatusInfos(&e.CheckDataStatusInfosInput{
+		Status:      sysPubKeyData.StatusInfo.Status,
+		ActiveAt:    sysPubKeyData.StatusInfo.ActiveAt,
+		ExpiresAt:   sysPubKeyData.StatusInfo.ExpiresAt,
+		Description: sysPubKeyData.StatusInfo.Description,
+	})
+}
+
+// store üzerindeki system pub key status kontrolü yapılmadan okunur. File yoksa nil döner.
+func getStoredSystemPubKey() (*e.PubKeyData, error) {
+	pubKeyExists, err := fileExists(env.MainPathEnvsPathKey, env.SystemPubKeyField)
+	if err != nil || !pubKeyExists {
+		return nil, err
+	}
+
+	var pubKeyEng *FileEngine[e.PubKeyData] = &FileEngine[e.PubKeyData]{Owner: env.System}
+	pubKeyData := &e.PubKeyData{}
+	if err := pubKeyEng.IFGet(e.GetInput[e.PubKeyData]{
+		PathKey:    env.MainPathEnvsPathKey,
+		PathFields: []string{env.SystemPubKeyField},
+		Data:       pubKeyData,
+	}); err != nil {
+		return nil, err
+	}
+	return pubKeyData, nil
+}
+
+// dışarıdan gelen system pub key güvenilir system pub key ile byte olarak karşılaştırılır.
+func compareSystemPubKey(trustedPubKey *e.PubKeyData, sysPubKeyData *e.PubKeyData, source string) error {
+	if len(trustedPubKey.PubKey) == 0 || !u.ComparisonHash(trustedPubKey.PubKey, sysPubKeyData.PubKey) {
+		return env.GetFuncError(env.SystemPubKeyMismatch, nil, source)
+	}
+	return nil
+}
+
+/*
+- dışarıdan gelen system pub key güvenilir kaynak ile doğrulanır. Kaynak sırası: trustedPubKey, store üzerindeki system pub key, trust kaydı.
+- güvenilir key varsa birebir eşleşmek ve trust kaydı varsa trust kaydı ile eşleşmek zorundadır.
+- güvenilir kaynak yoksa self-signed data kabul edilmez.
+*/
+func checkTrustedSystemPubKey(sysPubKeyData *e.PubKeyData, trustedPubKey *e.PubKeyData) error {
+	if trustedPubKey == nil {
+		storedPubKey, err := getStoredSystemPubKey()
+		if err != nil {
+			return err
+		}
+		trustedPubKey = storedPubKey
+	}
+	if trustedPubKey != nil {
+		if err := compareSystemPubKey(trustedPubKey, sysPubKeyData, env.SystemPubKeyField); err != nil {
+			return err
+		}
+		return checkTrustState(trustedPubKey)
+	}
+
+	trustExists, err := fileExists(env.MainPathEnvsPathKey, env.TrustStateField)
+	if err != nil {
+		return err
+	}
+	if !trustExists {
+		return env.GetFuncError(env.TrustedSystemPubKeyNotFound, nil, env.SystemPubKeyField)
+	}
+	return checkTrustState(sysPubKeyData)
+}
@@ -62,6 +62,102 @@ func getWhitelistOwnerData(ownerWhitelistKey string) (e.WhitelistOwnerData, erro
 	return data, nil
 }
 
+// main env içerisindeki cbor formatında tutulan değer status kontrolünden sonra T türüne çevrilir.
+func getMainEnvValue[T any](key string) (T, error) {
+	return u.GetEnvValue[T](env.MainEnvMapField, key)
+}
+
+// main env üzerinde key tanımlı değilse defaultValue döner.
+func getMainEnvValueOr[T any](key string, defaultValue T) (T, error) {
+	return u.GetEnvValueOr(env.MainEnvMapField, key, defaultValue)
+}
+
+/*
+- owner whitelist datasında belirtilen access data cid ile access data getirilir.
+- access data main env AccessDataPath altında <cid>.cbor olarak barınır.
+- AuthnInfos cid eşleşmesi, owner imzası, PermInfos için system imzası ve status bilgisi kontrol edilir.
+*/
+func getOwnerAccessData(whitelistOwnerData e.WhitelistOwnerData) (*e.AccessData, error) {
+	accessDataPath, err := getMainEnvValue[string](env.AccessDataPath)
+	if err != nil {
+		return nil, err
+	}
+
+	accessDataCID, err := u.CIDv1BytesToString(whitelistOwnerData.AccessDataCID)
+	if err != nil {
+		return nil, err
+	}
+
+	accessDataFilePath := filepath.Join(accessDataPath, accessDataCID+env.Cbor)
+	var accessDataEng *FileEngine[e.AccessData] = &FileEngine[e.AccessData]{Owner: env.System}
+	accessData := &e.AccessData{}
+	if err := accessDataEng.IFGet(e.GetInput[e.AccessData]{
+		PathKey:    env.SpecificPathKey,
+		PathFields: []string{accessDataFilePath},
+		Data:       accessData,
+	}); err != nil {
+		return nil, err
+	}
+
+	//okunan AuthnInfos whitelist üzerinde belirtilen cid ile eşleşmek zorundadır.
+	authnInfosCID, err := generateDataCID(accessData.AuthnInfos)
+	if err != nil {
+		return nil, err
+	}
+	//cid ve imza kontrolünden geçemeyen access data file karantinaya alınır.
+	rejectInput := envQuarantineInput{
+		source:      env.EnvQuarantineSourceAccessData,
+		field:       filepath.Base(accessDataFilePath),
+		signedBy:    accessData.AuthnInfosSignInfos.SignedBy,
+		expectedCID: whitelistOwnerData.AccessDataCID,
+		actualCID:   authnInfosCID,
+	}
+	if !u.ComparisonHash(whitelistOwnerData.AccessDataCID, authnInfosCID) {
+		return nil, rejectAccessDataFile(rejectInput, accessDataFilePath, env.GetFuncError(env.CIDMismatch, nil))
+	}
+
+	//access data içerisindeki owner tarafından oluşturulan AuthnInfos imzası kontrol edilir.
+	ownerPubKeyData, err := getPubKey(env.SpecificPathKey, whitelistOwnerData.PubKeyDataURI)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.VerifySign(e.VerifySignInput[e.AuthnData]{
+		SignType:  env.SignTypeED25519,
+		PublicKey: ownerPubKeyData.PubKey,
+		Signed:    accessData.AuthnInfosSignInfos.Signature,
+		Data:      accessData.AuthnInfos,
+	}); err != nil {
+		return nil, rejectAccessDataFile(rejectInput, accessDataFilePath, err)
+	}
+
+	//owner yetkileri system tarafından onaylanır, PermInfos system imzası kontrol edilir.
+	sysPubKeyData, err := env.GetPubKey(env.SystemKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.VerifySign(e.VerifySignInput[map[string]e.PermissionData]{
+		SignType:  env.SignTypeED25519,
+		PublicKey: sysPubKeyData.PubKey,
+		Signed:    accessData.TaskInfosSignInfos.Signature,
+		Data:      accessData.AuthnInfos.PermInfos,
+	}); err != nil {
+		rejectInput.signedBy = accessData.TaskInfosSignInfos.SignedBy
+		return nil, rejectAccessDataFile(rejectInput, accessDataFilePath, err)
+	}
+
+	//access data status bilgisi kontrol edilir.
+	if err := u.CheckDataStatusInfos(&e.CheckDataStatusInfosInput{
+		Status:      accessData.AuthnInfos.StatusInfos.Status,
+		ActiveAt:    accessData.AuthnInfos.StatusInfos.ActiveAt,
+		ExpiresAt:   accessData.AuthnInfos.StatusInfos.ExpiresAt,
+		Description: accessData.AuthnInfos.StatusInfos.Description,
+	}); err != nil {
+		return nil, err
+	}
+
+	return accessData, nil
+}
+
 // owner reference belirtilen işlemleri yapma yetkisine sahip mi kontrol edilir.
 func (j *FileEngine[T]) IFAccessOperation(input e.WhitelistAccessData) error {
 	/*
@@ -239,6 +335,30 @@ func (j *FileEngine[T]) IFGet(input e.GetInput[T]) error {
 	return nil
 }
 
+func (j *FileEngine[T]) IFPut(input e.PutInput[T]) error {
+	fullPath, err := j.IFGetRootFilePath(input.PathKey, input.PathFields...)
+	if err != nil {
+		return err
+	}
+
+	encodedData, err := cbor.Marshal(input.Data)
+	if err != nil {
+		return env.GetFuncError(env.UnexpectedError, err)
+	}
+
+	//sistem tarafından yazılan files sadece owner tarafından okunabilir.
+	return u.WriteFileAtomic(fullPath, encodedData, 0o600)
+}
+
+func (j *FileEngine[T]) IFSecureRemove(pathKey int, pathFields ...string) error {
+	fullPath, err := j.IFGetRootFilePath(pathKey, pathFields...)
+	if err != nil {
+		return err
+	}
+
+	return u.SecureRemoveFile(fullPath)
+}
+
 func loadYamlConfig[T any](filePath string, config *T) error {
 
 	// os.ReadFile ile dosya oku
@@ -276,7 +396,7 @@ func getPubKey(pathKey int, pathFields ...string) (*e.PubKeyData, error) {
 	return pubKeyData, nil
 }
 
-func incSysWhitelist(sysPubKeyData *e.PubKeyData) error {
+func incSysWhitelist(tx *env.EnvTx, sysPubKeyData *e.PubKeyData) error {
 	// işlem yapacak whitelist kullancılarının yüklendiği kısım
 	var sysWhitelistEng *FileEngine[e.SystemWhiteListData[string, e.WhitelistOwnerData]] = &FileEngine[e.SystemWhiteListData[string, e.WhitelistOwnerData]]{Owner: env.System}
 	// sistemin base path bilgisi alınır.
@@ -287,9 +407,24 @@ func incSysWhitelist(sysPubKeyData *e.PubKeyData) error {
 		PathFields: []string{env.WhitelistEnvMapField},
 		Data:       sysWhiteListData,
 	}); err != nil {
+		//decode edilemeyen file karantinaya alınır, okunamayan file için kanıt bulunmaz.
+		if env.IsFuncError(err, env.UnexpectedError) {
+			return rejectEnvMapFile[e.WhitelistOwnerData](env.EnvQuarantineSourceWhitelist, env.WhitelistEnvMapField, err, nil, nil)
+		}
 		return err
 	}
 
+	if err := checkSysWhitelist(sysPubKeyData, sysWhiteListData); err != nil {
+		return rejectEnvMapFile(env.EnvQuarantineSourceWhitelist, env.WhitelistEnvMapField, err, &sysWhiteListData.SignatureInfos, &sysWhiteListData.WhitelistInfos)
+	}
+
+	// system whitelist bütün süreçler olumlu olursa WhitelistEnvMapField key ile yüklenmek üzere transaction üzerine eklenir.
+	env.TxSetNewEnvMap(tx, env.WhitelistEnvMapField, sysWhiteListData.WhitelistInfos)
+	return nil
+}
+
+// system whitelist datasının status ve imza kontrolü yapılır.
+func checkSysWhitelist(sysPubKeyData *e.PubKeyData, sysWhiteListData *e.SystemWhiteListData[string, e.WhitelistOwnerData]) error {
 	//alınan system whitelist datasının status bilgisi kontrol edilir
 	if err := u.CheckNewDataStatusInfos(&e.CheckDataStatusInfosInput{
 		Status:      sysWhiteListData.WhitelistInfos.StatusInfos.Status,
@@ -309,11 +444,6 @@ func incSysWhitelist(sysPubKeyData *e.PubKeyData) error {
 	}); err != nil {
 		return err
 	}
-
-	// system whitelist bütün süreçler olumlu olursa WhitelistEnvMapField key ile sisteme yüklenir.
-	if err := env.SetNewEnvMap[string, e.WhitelistOwnerData](env.WhitelistEnvMapField, sysWhiteListData.WhitelistInfos); err != nil {
-		return err
-	}
 	return nil
 }
 
@@ -326,9 +456,32 @@ func incMainEnv(sysPubKeyData *e.PubKeyData) error {
 		PathFields: []string{env.MainEnvMapField},
 		Data:       mainEnvfileData,
 	}); err != nil {
+		//decode edilemeyen file karantinaya alınır, okunamayan file için kanıt bulunmaz.
+		if env.IsFuncError(err, env.UnexpectedError) {
+			return rejectEnvMapFile[e.EnvData[[]byte]](env.EnvQuarantineSourceMainEnv, env.MainEnvMapField, err, nil, nil)
+		}
 		return err
 	}
 
+	if err := checkMainEnv(sysPubKeyData, mainEnvfileData); err != nil {
+		return rejectEnvMapFile(env.EnvQuarantineSourceMainEnv, env.MainEnvMapField, err, &mainEnvfileData.SignatureInfos, &mainEnvfileData.EnvMapInfos)
+	}
+
+	//system tag overlay ve replica override uygulanır, schema effective env map üzerinde doğrulanır.
+	layers, err := resolveEnvMapLayers(sysPubKeyData, env.MainEnvMapField, mainEnvfileData.EnvMapInfos)
+	if err != nil {
+		return rejectEnvMapFile(env.EnvQuarantineSourceMainEnv, env.MainEnvMapField, err, &mainEnvfileData.SignatureInfos, &mainEnvfileData.EnvMapInfos)
+	}
+
+	if err := commitEnvMapLayers(env.MainEnvMapField, layers, true); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// main env datasının imza ve içerik kontrolü yapılır.
+func checkMainEnv(sysPubKeyData *e.PubKeyData, mainEnvfileData *e.EnvFileData[string, e.EnvData[[]byte]]) error {
 	//main env data imza kontrolü
 	if err := u.VerifySign(e.VerifySignInput[e.EnvMapData[string, e.EnvData[[]byte]]]{
 		SignType:  env.SignTypeED25519,
@@ -343,17 +496,17 @@ func incMainEnv(sysPubKeyData *e.PubKeyData) error {
 		return err
 	}
 
-	if err := env.SetNewEnvMap[string, e.EnvData[[]byte]](env.MainEnvMapField, mainEnvfileData.EnvMapInfos); err != nil {
-		return err
-	}
-
 	return nil
 }
 
 func includeInternalEnv() error {
-	//TODO: tek seferlik sonrasında silinen imzalı file okuma işlemi yapılacak.
 	//sistem setupları için yaml okuması //basit seviyedeki elle müdahale edilecek config yapıları için kullanılır.
 
+	//ilk açılışta tek seferlik imzalı bootstrap bundle varsa okunur, trust kurulur ve bundle silinir.
+	if err := includeBootstrapBundle(); err != nil {
+		return err
+	}
+
 	/*
 		- systemWhiteListData.EnvInfos imzasının kontrol edilmesi için sistem pub key belirtilen path üzerinden okunur.
 		Sonraki steplerde kullanılması için pubKey env eklenir.
@@ -363,12 +516,25 @@ func includeInternalEnv() error {
 		return err
 	}
 
+	//trust kurulmuş ise okunan system pub key trust kaydı ile eşleşmek zorundadır.
+	if err := checkTrustState(sysPubKeyData); err != nil {
+		return err
+	}
+
 	//çağrılan system pub key pub key box kaydedilir sonraki steplerde kullanılması durumundan
-	if err := env.SetNewPubKey(env.SystemKey, *sysPubKeyData); err != nil {
+	//system pub key ve system whitelist aynı transaction ile yüklenir, biri yüklenemezse diğeri de yüklenmez.
+	sysEnvTx := env.NewEnvTx()
+	sysEnvTx.SetNewPubKey(env.SystemKey, *sysPubKeyData)
+
+	if err := incSysWhitelist(sysEnvTx, sysPubKeyData); err != nil {
+		return err
+	}
+
+	if err := sysEnvTx.Commit(); err != nil {
 		return err
 	}
 
-	if err := incSysWhitelist(sysPubKeyData); err != nil {
+	if err := incEnvSchemaEnv(sysPubKeyData); err != nil {
 		return err
 	}
 
@@ -376,6 +542,14 @@ func includeInternalEnv() error {
 		return err
 	}
 
+	if err := incKafkaTopicPolicyEnv(sysPubKeyData); err != nil {
+		return err
+	}
+
+	if err := incFuncErrorEnv(sysPubKeyData); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -432,43 +606,78 @@ func includeExternalEnv(input e.IncludeExternalEnvInput) error {
 	//external eklenecek env üzerindeki erişimi uygunsa external envs sisteme eklenir.
 	// external env bulunduğu base env path alınır
 	externalBasePath, err := env.GetEnv(env.M)
-	//external env yüklemesi belli bir sırayı takip ederek yüklenmek istenirse.
-	currentKey := input.StartEnvMapField
-	for currentKey != env.EndEnvMapField {
-		//belirtilen key sahip env map mevcut mu kontrol edilir.
-		envMapChainInfo, ok := input.EnvMapChainInfos[currentKey]
-		if !ok {
-			return env.GetFuncError(env.InvalidTaskAuthn, nil, currentKey)
-		}
-
-		//yüklemeler için genel env box oluşturulur
-		var envBox map[string]e.EnvData = map[string]e.EnvData{}
-
-		if err := ownerEnvFE.IFGet(e.GetInput[map[string]e.EnvData]{
+	//external env map files önce okunur ve doğrulanır, references yükleme sırası için bağımlılık olarak kullanılır.
+	var externalEnvEng *FileEngine[e.EnvFileData[string, e.EnvData[[]byte]]] = &FileEngine[e.EnvFileData[string, e.EnvData[[]byte]]]{
+		Owner:      ownerEnvFE.Owner,
+		OADPathKey: ownerEnvFE.OADPathKey,
+		OADFields:  ownerEnvFE.OADFields,
+	}
+	readExternalEnvMap := func(currentKey string) (e.EnvMapData[string, e.EnvData[[]byte]], error) {
+		envFileData := &e.EnvFileData[string, e.EnvData[[]byte]]{}
+		if err := externalEnvEng.IFGet(e.GetInput[e.EnvFileData[string, e.EnvData[[]byte]]]{
 			PathKey:    env.SystemExternalEnvMainPathKey,
 			PathFields: []string{currentKey},
-			Data:       &envBox,
+			Data:       envFileData,
 		}); err != nil {
-			return err
+			return e.EnvMapData[string, e.EnvData[[]byte]]{}, err
 		}
 
 		//external env yüklenebilmesi için tam kontrol sağlanır.
-		if err := v.CheckEnvData(envBox); err != nil {
-			return err
+		if err := v.ValidateExternalEnvMapData(envFileData.EnvMapInfos); err != nil {
+			return e.EnvMapData[string, e.EnvData[[]byte]]{}, err
 		}
+		return envFileData.EnvMapInfos, nil
+	}
 
-		includeEnvMapfunc.IncludeFunc(envBox)
+	externalEnvMaps := map[string]e.EnvMapData[string, e.EnvData[[]byte]]{}
+	for currentKey := range input.EnvMapDependencyInfos {
+		//internal olarak yüklenen env maps graph üzerinde sadece yükleme sırası için bulunur.
+		if !isExternalEnvMapField(currentKey) {
+			continue
+		}
+		envMap, err := readExternalEnvMap(currentKey)
+		if err != nil {
+			return err
+		}
+		externalEnvMaps[currentKey] = envMap
+	}
 
-		currentKey = envMapChainInfo.NextEnvMap
+	//external env maps bağımlılık graph üzerinden yüklenir, birbirine bağımlı olmayan env maps paralel yüklenir.
+	dependencyInfos := withEnvMapRefDependencies(input.EnvMapDependencyInfos, externalEnvMaps)
+	if err := LoadEnvMapGraph(dependencyInfos, func(currentKey string) error {
+		envMap, ok := externalEnvMaps[currentKey]
+		if !ok {
+			return nil
+		}
+		//env map references çözülüp schema ile doğrulandıktan sonra yüklenir.
+		return loadExternalEnvMap(currentKey, envMap)
+	}); err != nil {
+		return err
 	}
 
-	return nil
+	//watcher tarafından değişikliği algılanan external env map ve bağımlı env maps aynı owner erişimi ile tekrar okunur ve yüklenir.
+	setExternalEnvReloader(dependencyInfos, func(currentKey string) error {
+		if !isExternalEnvMapField(currentKey) {
+			return nil
+		}
+		envMap, err := readExternalEnvMap(currentKey)
+		if err != nil {
+			return err
+		}
+		return loadExternalEnvMap(currentKey, envMap)
+	})
+
+	//bütün external env maps yüklendikten sonra bekleyen reference kalmamalıdır.
+	return checkPendingEnvRefs(env.LoadEnvView())
 }
 
-func InitConfig() (map[string]string, error) {
+/*
+- internal ve external env maps yüklenir. Hata dönerse env store yüklenmemiş kabul edilir ve env distribution başlatılmaz.
+*/
+func InitConfig() error {
 
 	if err := includeInternalEnv(); err != nil {
-		return nil, err
+		return err
 	}
 
 	//access durumu kontrol edilen task-permissontype reference (refTaskPerr) ikilisi include edilir
@@ -491,12 +700,13 @@ func InitConfig() (map[string]string, error) {
 	// 	env.FuncErrorEnvsField: env.End,
 	// }
 
-	includeEnvMapFuncInfos := map[string]e.EnvMapChainData{
-		env.PathEnvMapField:      e.EnvMapChainData{NextEnvMap: env.TaskEnvMapField, EnvKeyRefSlice: env.PathEnvKeyRefSlice},
-		env.TaskEnvMapField:      e.EnvMapChainData{NextEnvMap: env.RestEnvMapField, EnvKeyRefSlice: env.TaskEnvKeyRefSlice},
-		env.RestEnvMapField:      e.EnvMapChainData{NextEnvMap: env.FuncEnvMapField, EnvKeyRefSlice: env.RestEnvKeyRefSlice},
-		env.FuncEnvMapField:      e.EnvMapChainData{NextEnvMap: env.FuncErrorEnvMapField, EnvKeyRefSlice: env.FuncEnvKeyRefSlice},
-		env.FuncErrorEnvMapField: e.EnvMapChainData{NextEnvMap: env.EndEnvMapField, EnvKeyRefSlice: env.FuncErrorEnvKeyRefSlice},
+	//env map bağımlılıkları. Ex: rest env, path ve task env yüklendikten sonra yüklenir.
+	includeEnvMapFuncInfos := map[string]e.EnvMapDependencyData{
+		env.PathEnvMapField:      e.EnvMapDependencyData{EnvKeyRefSlice: env.PathEnvKeyRefSlice},
+		env.TaskEnvMapField:      e.EnvMapDependencyData{DependsOn: []string{env.PathEnvMapField}, EnvKeyRefSlice: env.TaskEnvKeyRefSlice},
+		env.RestEnvMapField:      e.EnvMapDependencyData{DependsOn: []string{env.PathEnvMapField, env.TaskEnvMapField}, EnvKeyRefSlice: env.RestEnvKeyRefSlice},
+		env.FuncEnvMapField:      e.EnvMapDependencyData{DependsOn: []string{env.TaskEnvMapField}, EnvKeyRefSlice: env.FuncEnvKeyRefSlice},
+		env.FuncErrorEnvMapField: e.EnvMapDependencyData{DependsOn: []string{env.FuncEnvMapField}, EnvKeyRefSlice: env.FuncErrorEnvKeyRefSlice},
 	}
 
 	// refTaskPerr := map[string]uint8{
@@ -509,16 +719,12 @@ func InitConfig() (map[string]string, error) {
 
 	if err := includeExternalEnv(e.IncludeExternalEnvInput{
 		Owner:            owner.Value.(string), //dışardan request ile alınır(external)
-		StartEnvMapField: env.PathEnvMapField,
-		EnvMapChainInfos: includeEnvMapFuncInfos,
+		EnvMapDependencyInfos: includeEnvMapFuncInfos,
 	}); err != nil {
-		return nil, err
+		return err
 	}
 
-	//bütün işlemler bitince
-	includeEnvMapFuncInfos[env.MainPathEnvField] = e.IncludeEnvData{IncludeFunc: env.IncludeSystemMainPathEnvMap, NextIncludePath: env.PathEnvField}
-
-	return includeEnvMapFuncInfos, nil
+	return nil
 }
 
 func ConfigFileWhatcher(configFiles ...string) error {
@@ -558,9 +764,22 @@ func configHandleFileChange(event fsnotify.Event) {
 	case event.Op&fsnotify.Write == fsnotify.Write:
 		filepath.Base(event.Name)
 		fmt.Printf("Dosya yazma işlemi algılandı: %s\n %v\n", event.Name, event.Op)
+		//geçersiz env file karantinaya alınır.
+		if err := checkWatchedEnvFile(event.Name); err != nil {
+			fmt.Printf("Dosya reddedildi: %s\n %v\n", event.Name, err)
+		} else if err := reloadWatchedEnvMap(event.Name); err != nil {
+			//değişen env map ve bağımlı env maps bağımlılık sırası ile tekrar yüklenir.
+			fmt.Printf("Dosya yüklenemedi: %s\n %v\n", event.Name, err)
+		}
 	case event.Op&fsnotify.Create == fsnotify.Create:
 		fmt.Printf("name: %s\n", filepath.Base(event.Name))
 		fmt.Printf("Dosya veya klasör oluşturuldu: %s\n", event.Name)
+		if err := checkWatchedEnvFile(event.Name); err != nil {
+			fmt.Printf("Dosya reddedildi: %s\n %v\n", event.Name, err)
+		} else if err := reloadWatchedEnvMap(event.Name); err != nil {
+			//değişen env map ve bağımlı env maps bağımlılık sırası ile tekrar yüklenir.
+			fmt.Printf("Dosya yüklenemedi: %s\n %v\n", event.Name, err)
+		}
 	case event.Op&fsnotify.Remove == fsnotify.Remove:
 		fmt.Printf("name: %s\n", filepath.Base(event.Name))
 		fmt.Printf("Dosya veya klasör silindi: %s\n", event.Name)
@@ -0,0 +1,307 @@
+package config
+
+import (
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+	u "web_server/utils"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// flatten edilen field. Render gösterilen, compare karşılaştırılan değerdir. Secret values için compare sealed value hash bilgisidir.
+type envDiffValue struct {
+	render  string
+	compare string
+}
+
+var envDataType = reflect.TypeFor[e.EnvData[[]byte]]()
+
+/*
+- value field path ile düz bir map üzerine yazılır. Ex: EnvInfos[broker-port].Value, EnvInfos[broker-port].StatusInfo.ActiveAt
+- EnvData[[]byte] Value cbor olarak decode edilir ve içerdiği maps ve slices de path olarak ayrılır. Secret values redact edilir.
+- zero value struct fields yazılmaz, maps ve slices içerisindeki values her zaman yazılır.
+*/
+func flattenEnvDiffValue(path string, value reflect.Value, out map[string]envDiffValue) {
+	if !value.IsValid() {
+		out[path] = envDiffValue{render: "null", compare: "null"}
+		return
+	}
+	if value.Kind() == reflect.Interface || value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			out[path] = envDiffValue{render: "null", compare: "null"}
+			return
+		}
+		flattenEnvDiffValue(path, value.Elem(), out)
+		return
+	}
+
+	if value.Type() == envDataType {
+		flattenEnvDiffData(path, value.Interface().(e.EnvData[[]byte]), out)
+		return
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		for i := range value.NumField() {
+			field := value.Type().Field(i)
+			if !field.IsExported() || value.Field(i).IsZero() {
+				continue
+			}
+			flattenEnvDiffValue(joinEnvDiffPath(path, field.Name), value.Field(i), out)
+		}
+	case reflect.Map:
+		if value.Len() == 0 {
+			out[path] = envDiffValue{render: "{}", compare: "{}"}
+			return
+		}
+		keys := value.MapKeys()
+		slices.SortFunc(keys, func(left, right reflect.Value) int {
+			return strings.Compare(fmt.Sprint(left.Interface()), fmt.Sprint(right.Interface()))
+		})
+		for _, key := range keys {
+			flattenEnvDiffValue(path+"["+fmt.Sprint(key.Interface())+"]", value.MapIndex(key), out)
+		}
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.Uint8 {
+			rendered := "0x" + hex.EncodeToString(value.Bytes())
+			out[path] = envDiffValue{render: rendered, compare: rendered}
+			return
+		}
+		if value.Len() == 0 {
+			out[path] = envDiffValue{render: "[]", compare: "[]"}
+			return
+		}
+		for i := range value.Len() {
+			flattenEnvDiffValue(path+"["+strconv.Itoa(i)+"]", value.Index(i), out)
+		}
+	case reflect.String:
+		rendered := strconv.Quote(value.String())
+		out[path] = envDiffValue{render: rendered, compare: rendered}
+	default:
+		rendered := fmt.Sprint(value.Interface())
+		out[path] = envDiffValue{render: rendered, compare: rendered}
+	}
+}
+
+// env value cbor olarak decode edilir. Secret value yerine redacted yazılır, sealed value değişikliği hash üzerinden bulunur.
+func flattenEnvDiffData(path string, envData e.EnvData[[]byte], out map[string]envDiffValue) {
+	valuePath := joinEnvDiffPath(path, "Value")
+	var decodedValue any
+	switch {
+	case envData.Kind == env.EnvValueKindSecret:
+		out[valuePath] = envDiffValue{render: env.RedactedEnvValue, compare: hex.EncodeToString(u.GenerateNativeBytetoSHA2(envData.Value))}
+	case cbor.Unmarshal(envData.Value, &decodedValue) == nil:
+		flattenEnvDiffValue(valuePath, reflect.ValueOf(decodedValue), out)
+	default:
+		flattenEnvDiffValue(valuePath, reflect.ValueOf(envData.Value), out)
+	}
+
+	flattenEnvDiffValue(path, reflect.ValueOf(struct {
+		SpecificInfo e.SpecificData
+		StatusInfo   e.StatusData
+		Kind         string
+	}{envData.SpecificInfo, envData.StatusInfo, envData.Kind}), out)
+}
+
+func joinEnvDiffPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// path env map key altında mı kontrol edilir. Ex: EnvInfos[port].Value => port
+func isEnvDiffKeyPath(path string, keyPath string) bool {
+	rest, ok := strings.CutPrefix(path, keyPath)
+	return ok && (rest == "" || rest[0] == '.' || rest[0] == '[')
+}
+
+/*
+- env maps arasındaki fark keys, decode edilmiş values, StatusData ve SpecificInfo değişiklikleri ile döner.
+- changes path sırasına göre sıralıdır. From ve To kaynak bilgileri çağıran tarafından yazılır.
+*/
+func DiffEnvMaps[V any](field string, from e.EnvMapData[string, V], to e.EnvMapData[string, V]) e.EnvMapDiffData {
+	return diffEnvMapValues(field, from, to)
+}
+
+// env map türü bilinmeden fark bulunur. Nil env map boş env map kabul edilir.
+func diffEnvMapValues(field string, from any, to any) e.EnvMapDiffData {
+	diff := e.EnvMapDiffData{Field: field, KeyInfos: []e.EnvMapKeyDiffData{}, ChangeInfos: []e.EnvMapChangeData{}}
+	fromValues := map[string]envDiffValue{}
+	toValues := map[string]envDiffValue{}
+	if from != nil {
+		flattenEnvDiffValue("", reflect.ValueOf(from), fromValues)
+	}
+	if to != nil {
+		flattenEnvDiffValue("", reflect.ValueOf(to), toValues)
+	}
+
+	paths := maps.Clone(fromValues)
+	maps.Copy(paths, toValues)
+	for _, path := range slices.Sorted(maps.Keys(paths)) {
+		fromValue, inFrom := fromValues[path]
+		toValue, inTo := toValues[path]
+		switch {
+		case !inFrom:
+			diff.ChangeInfos = append(diff.ChangeInfos, e.EnvMapChangeData{Path: path, Change: env.EnvMapKeyAdded, To: toValue.render})
+		case !inTo:
+			diff.ChangeInfos = append(diff.ChangeInfos, e.EnvMapChangeData{Path: path, Change: env.EnvMapKeyRemoved, From: fromValue.render})
+		case fromValue.compare != toValue.compare:
+			diff.ChangeInfos = append(diff.ChangeInfos, e.EnvMapChangeData{Path: path, Change: env.EnvMapKeyModified, From: fromValue.render, To: toValue.render})
+		}
+	}
+
+	//key değişiklikleri EnvInfos keys ve keys altındaki field değişiklikleri üzerinden bulunur.
+	fromKeys, toKeys := envDiffMapKeys(from), envDiffMapKeys(to)
+	keys := maps.Clone(fromKeys)
+	maps.Copy(keys, toKeys)
+	for _, key := range slices.Sorted(maps.Keys(keys)) {
+		keyDiff := e.EnvMapKeyDiffData{Key: key}
+		switch {
+		case !fromKeys[key]:
+			keyDiff.Change = env.EnvMapKeyAdded
+		case !toKeys[key]:
+			keyDiff.Change = env.EnvMapKeyRemoved
+		case slices.ContainsFunc(diff.ChangeInfos, func(change e.EnvMapChangeData) bool {
+			return isEnvDiffKeyPath(change.Path, "EnvInfos["+key+"]")
+		}):
+			keyDiff.Change = env.EnvMapKeyModified
+		default:
+			continue
+		}
+		diff.KeyInfos = append(diff.KeyInfos, keyDiff)
+	}
+	return diff
+}
+
+// env map EnvInfos keys döner.
+func envDiffMapKeys(envMap any) map[string]bool {
+	keys := map[string]bool{}
+	if envMap == nil {
+		return keys
+	}
+	envInfos := reflect.Indirect(reflect.ValueOf(envMap)).FieldByName("EnvInfos")
+	if !envInfos.IsValid() || envInfos.Kind() != reflect.Map {
+		return keys
+	}
+	for _, key := range envInfos.MapKeys() {
+		keys[fmt.Sprint(key.Interface())] = true
+	}
+	return keys
+}
+
+/*
+- diff onay süreçlerine eklenebilecek okunabilir metin olarak yazılır.
+- keys ve field değişiklikleri added +, removed -, modified ~ ile işaretlenir.
+*/
+func RenderEnvMapDiff(diff e.EnvMapDiffData) string {
+	marks := map[string]string{env.EnvMapKeyAdded: "+", env.EnvMapKeyRemoved: "-", env.EnvMapKeyModified: "~"}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "--- %s (%s)\n+++ %s (%s)\n", diff.Field, diff.From, diff.Field, diff.To)
+	if len(diff.ChangeInfos) == 0 {
+		text.WriteString("no changes\n")
+		return text.String()
+	}
+
+	text.WriteString("\nkeys:\n")
+	for _, keyDiff := range diff.KeyInfos {
+		fmt.Fprintf(&text, "%s %s\n", marks[keyDiff.Change], keyDiff.Key)
+	}
+	text.WriteString("\nchanges:\n")
+	for _, change := range diff.ChangeInfos {
+		switch {
+		case change.From == "" && change.To == "":
+			//values içermeyen changes sadece path ile yazılır.
+			fmt.Fprintf(&text, "%s %s\n", marks[change.Change], change.Path)
+		case change.Change == env.EnvMapKeyAdded:
+			fmt.Fprintf(&text, "+ %s: %s\n", change.Path, change.To)
+		case change.Change == env.EnvMapKeyRemoved:
+			fmt.Fprintf(&text, "- %s: %s\n", change.Path, change.From)
+		default:
+			fmt.Fprintf(&text, "~ %s: %s -> %s\n", change.Path, change.From, change.To)
+		}
+	}
+	return text.String()
+}
+
+// env map revisions layered env maps için base env map üzerinden tutulur.
+func envDiffStoreKey(field string) string {
+	if slices.Contains(env.LayeredEnvMapFieldSlice, field) {
+		return env.EnvLayerBaseKey(field)
+	}
+	return field
+}
+
+// yüklü env map ve history üzerindeki revisions eskiden yeniye sıralı döner.
+func GetEnvMapRevisions(field string) []uint64 {
+	revisions := env.LoadEnvView().EnvMapRevisions(envDiffStoreKey(field))
+	if revisions == nil {
+		return []uint64{}
+	}
+	return revisions
+}
+
+/*
+- yüklü env map ya da revision, candidate env file ya da başka bir revision ile karşılaştırılır.
+- candidate file imzası kontrol edilmez, doğrulama dry run ile yapılır. Whitelist dışındaki fields env file olarak decode edilir.
+- yüklü env map bulunamazsa boş env map ile karşılaştırılır.
+*/
+func DiffEnvMap(input e.EnvMapDiffInput) (e.EnvMapDiffData, error) {
+	view := env.LoadEnvView()
+	storeKey := envDiffStoreKey(input.Field)
+
+	from, fromSource := any(nil), env.EnvDiffSourceLive
+	if input.FromRevision == 0 {
+		from, _, _ = view.EnvMapRaw(storeKey)
+	} else {
+		data, exists := view.EnvMapRawRevision(storeKey, input.FromRevision)
+		if !exists {
+			return e.EnvMapDiffData{}, env.GetFuncError(env.EnvMapRevisionNotFound, nil, input.Field, input.FromRevision)
+		}
+		from, fromSource = data, env.EnvDiffSourceRevisionPrefix+strconv.FormatUint(input.FromRevision, 10)
+	}
+
+	var to any
+	var toSource string
+	switch {
+	case len(input.File) > 0:
+		toSource = env.EnvDiffSourceCandidate
+		if input.Field == env.WhitelistEnvMapField {
+			sysWhiteListData := &e.SystemWhiteListData[string, e.WhitelistOwnerData]{}
+			if err := cbor.Unmarshal(input.File, sysWhiteListData); err != nil {
+				return e.EnvMapDiffData{}, env.GetFuncError(env.InvalidMapValue, err, input.Field)
+			}
+			to = sysWhiteListData.WhitelistInfos
+		} else {
+			envFileData := &e.EnvFileData[string, e.EnvData[[]byte]]{}
+			if err := cbor.Unmarshal(input.File, envFileData); err != nil {
+				return e.EnvMapDiffData{}, env.GetFuncError(env.InvalidMapValue, err, input.Field)
+			}
+			to = envFileData.EnvMapInfos
+		}
+	case input.ToRevision != 0:
+		data, exists := view.EnvMapRawRevision(storeKey, input.ToRevision)
+		if !exists {
+			return e.EnvMapDiffData{}, env.GetFuncError(env.EnvMapRevisionNotFound, nil, input.Field, input.ToRevision)
+		}
+		to, toSource = data, env.EnvDiffSourceRevisionPrefix+strconv.FormatUint(input.ToRevision, 10)
+	default:
+		return e.EnvMapDiffData{}, env.GetFuncError(env.InvalidEnvMapDiff, nil, "file or to-revision required")
+	}
+
+	if from != nil && to != nil && reflect.TypeOf(from) != reflect.TypeOf(to) {
+		return e.EnvMapDiffData{}, env.GetFuncError(env.EnvMapTypeMismatch, nil)
+	}
+
+	diff := diffEnvMapValues(input.Field, from, to)
+	diff.From, diff.To = fromSource, toSource
+	return diff, nil
+}
@@ -0,0 +1,282 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+	a "web_server/domain/abstractions"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+	kafka "web_server/infrastructure/kafka"
+	u "web_server/utils"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// env distribution topic compact cleanup policy ile oluşturulur. Topic mevcut ve ayarları farklı ise conflict hatası döner.
+func EnsureEnvDistributionTopic(ctx context.Context, admin a.IKafkaTopicAdmin, replicationFactor int16) (e.KafkaTopicResultData, error) {
+	topic, err := getMainEnvValue[string](env.KafkaEnvDistributionTopic)
+	if err != nil {
+		return e.KafkaTopicResultData{}, err
+	}
+
+	return kafka.CreateTopicIfNotExists(ctx, admin, e.CreateKafkaTopicInput{
+		Name:              topic,
+		Partitions:        env.KafkaEnvDistributionPartitions,
+		ReplicationFactor: replicationFactor,
+		Configs:           map[string]string{env.KafkaTopicConfigCleanupPolicy: env.KafkaCleanupPolicyCompact},
+	})
+}
+
+/*
+- diskte bulunan imzalı env map files olduğu gibi env distribution topic üzerine yazılır. Record key env map field ismidir.
+- yayınlanmadan önce her file system pub key ile doğrulanır, doğrulanamayan file yayınlanmaz.
+- disk üzerinde bulunmayan fields atlanır.
+*/
+func PublishEnvMaps(ctx context.Context, client a.IKafkaProxyClient) ([]e.KafkaRecordOffsetData, error) {
+	topic, err := getMainEnvValue[string](env.KafkaEnvDistributionTopic)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []e.KafkaProduceRecordData
+	for _, field := range env.DistributedEnvMapFieldSlice {
+		exists, err := fileExists(env.MainPathEnvsPathKey, field)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+
+		var fileEng *FileEngine[[]byte] = &FileEngine[[]byte]{Owner: env.System}
+		filePath, err := fileEng.IFGetRootFilePath(env.MainPathEnvsPathKey, field)
+		if err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, env.GetFuncError(env.FileNotFound, nil, filePath)
+		}
+
+		if _, err := decodeDistributedEnvMap(field, data); err != nil {
+			return nil, err
+		}
+		records = append(records, e.KafkaProduceRecordData{Key: []byte(field), Value: data})
+	}
+
+	if len(records) == 0 {
+		return nil, nil
+	}
+	return client.IFProduce(ctx, topic, records)
+}
+
+// doğrulanmış env map ve güncelleme fonksiyonu
+type distributedEnvMap struct {
+	statusInfos e.StatusData
+	envMapInfos any
+	apply       func() error
+	current     func() (any, e.StatusData, bool)
+}
+
+/*
+- record value field türüne göre çözülür ve trust kaydı ile eşleşen system pub key ile doğrulanır.
+- broker güven kaynağı değildir, imzası doğrulanamayan record uygulanmaz.
+*/
+func decodeDistributedEnvMap(field string, data []byte) (distributedEnvMap, error) {
+	sysPubKeyData, err := env.GetPubKey(env.SystemKey)
+	if err != nil {
+		return distributedEnvMap{}, err
+	}
+
+	switch field {
+	case env.EnvSchemaEnvMapField, env.MainEnvMapField, env.KafkaTopicPolicyEnvMapField:
+		envFileData := &e.EnvFileData[string, e.EnvData[[]byte]]{}
+		if err := cbor.Unmarshal(data, envFileData); err != nil {
+			return distributedEnvMap{}, env.GetFuncError(env.InvalidMapValue, err, field)
+		}
+		if err := checkMainEnv(sysPubKeyData, envFileData); err != nil {
+			return distributedEnvMap{}, err
+		}
+		//layered fields için record base env map olarak değerlendirilir ve local overlays üzerine uygulanır.
+		if slices.Contains(env.LayeredEnvMapFieldSlice, field) {
+			layers, err := resolveEnvMapLayers(sysPubKeyData, field, envFileData.EnvMapInfos)
+			if err != nil {
+				return distributedEnvMap{}, err
+			}
+			return distributedEnvMap{
+				statusInfos: envFileData.EnvMapInfos.StatusInfos,
+				envMapInfos: envFileData.EnvMapInfos,
+				apply: func() error {
+					return commitEnvMapLayers(field, layers, false)
+				},
+				current: func() (any, e.StatusData, bool) {
+					current, err := env.GetEnvMap[string, e.EnvData[[]byte]](env.EnvLayerBaseKey(field))
+					return current, current.StatusInfos, err == nil
+				},
+			}, nil
+		}
+		if err := checkEnvMapSchema(field, envFileData.EnvMapInfos); err != nil {
+			return distributedEnvMap{}, err
+		}
+		return distributedEnvMap{
+			statusInfos: envFileData.EnvMapInfos.StatusInfos,
+			envMapInfos: envFileData.EnvMapInfos,
+			//record sadece açılışta yüklenmiş env map üzerine uygulanır.
+			apply: func() error {
+				return env.UpdateEnvMap(field, envFileData.EnvMapInfos)
+			},
+			current: func() (any, e.StatusData, bool) {
+				current, err := env.GetEnvMap[string, e.EnvData[[]byte]](field)
+				return current, current.StatusInfos, err == nil
+			},
+		}, nil
+	case env.WhitelistEnvMapField:
+		sysWhiteListData := &e.SystemWhiteListData[string, e.WhitelistOwnerData]{}
+		if err := cbor.Unmarshal(data, sysWhiteListData); err != nil {
+			return distributedEnvMap{}, env.GetFuncError(env.InvalidMapValue, err, field)
+		}
+		if err := checkSysWhitelist(sysPubKeyData, sysWhiteListData); err != nil {
+			return distributedEnvMap{}, err
+		}
+		return distributedEnvMap{
+			statusInfos: sysWhiteListData.WhitelistInfos.StatusInfos,
+			envMapInfos: sysWhiteListData.WhitelistInfos,
+			apply: func() error {
+				return env.UpdateEnvMap(field, sysWhiteListData.WhitelistInfos)
+			},
+			current: func() (any, e.StatusData, bool) {
+				current, err := env.GetEnvMap[string, e.WhitelistOwnerData](field)
+				return current, current.StatusInfos, err == nil
+			},
+		}, nil
+	default:
+		return distributedEnvMap{}, env.GetFuncError(env.UnsupportedEnvMapField, nil, field)
+	}
+}
+
+/*
+- env distribution record doğrulanır ve env map güncellenir. Güncelleme yapıldıysa true döner.
+- tombstone record env map silmez, atlanır.
+- UpdatedAt bilgisi mevcut env map ile aynı ve içerik aynı ise record tekrar uygulanmaz.
+- UpdatedAt bilgisi mevcut env map üzerindekinden küçük ya da aynı olup içerik farklı ise eski imzalı datanın tekrar yayınlanması olarak değerlendirilir ve reddedilir.
+*/
+func ApplyEnvMapRecord(record e.KafkaConsumedRecordData) (bool, error) {
+	field := string(record.Key)
+	if !slices.Contains(env.DistributedEnvMapFieldSlice, field) {
+		return false, env.GetFuncError(env.UnsupportedEnvMapField, nil, field)
+	}
+	if record.Value == nil {
+		return false, nil
+	}
+
+	envMap, err := decodeDistributedEnvMap(field, record.Value)
+	if err != nil {
+		return false, err
+	}
+
+	if current, currentStatusInfos, ok := envMap.current(); ok {
+		switch {
+		case envMap.statusInfos.UpdatedAt > currentStatusInfos.UpdatedAt:
+		case envMap.statusInfos.UpdatedAt < currentStatusInfos.UpdatedAt:
+			return false, env.GetFuncError(env.StaleEnvMapUpdate, nil, field, "older updated-at")
+		default:
+			currentCID, err := generateDataCID(current)
+			if err != nil {
+				return false, err
+			}
+			recordCID, err := generateDataCID(envMap.envMapInfos)
+			if err != nil {
+				return false, err
+			}
+			if u.ComparisonHash(currentCID, recordCID) {
+				return false, nil
+			}
+			return false, env.GetFuncError(env.StaleEnvMapUpdate, nil, field, "same updated-at with different content")
+		}
+	}
+
+	if err := envMap.apply(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+/*
+- replica kendi consumer group'u ile env distribution topic'i baştan okur, böylece her açılışta compacted topic üzerindeki son env maps uygulanır.
+- record bazlı hatalar onRecordError ile bildirilir ve okumaya devam edilir. Context kapatılana kadar çalışır.
+*/
+func RunEnvDistribution(ctx context.Context, client a.IKafkaProxyClient, replicaID string, onRecordError func(record e.KafkaConsumedRecordData, err error)) error {
+	topic, err := getMainEnvValue[string](env.KafkaEnvDistributionTopic)
+	if err != nil {
+		return err
+	}
+
+	consumer, err := client.IFNewConsumer(ctx, e.CreateKafkaConsumerInput{
+		Group:       env.KafkaEnvDistributionGroupPrefix + replicaID,
+		Topics:      []string{topic},
+		OffsetReset: env.KafkaOffsetResetEarliest,
+	})
+	if err != nil {
+		return err
+	}
+	defer consumer.IFClose()
+
+	for ctx.Err() == nil {
+		records, err := consumer.IFPoll(ctx, 0)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			return err
+		}
+		for _, record := range records {
+			if _, err := ApplyEnvMapRecord(record); err != nil && onRecordError != nil {
+				onRecordError(record, err)
+			}
+		}
+
+		//yeni record yoksa tekrar poll öncesi beklenir.
+		if len(records) == 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(env.KafkaProxyDefaultPollTimeoutMs * time.Millisecond):
+			}
+		}
+	}
+	return nil
+}
+
+/*
+- main env üzerinde env distribution topic tanımlı ise env distribution arka planda başlatılır. Tanımlı değilse atlanır.
+- consumer group replica id ile oluşturulur, replica id tanımlı değilse host ismi kullanılır.
+*/
+func startEnvDistribution() error {
+	topic, err := getMainEnvValueOr(env.KafkaEnvDistributionTopic, "")
+	if err != nil || topic == "" {
+		return err
+	}
+
+	replicaID := env.GetEnvLayerInfos().ReplicaID
+	if replicaID == "" {
+		if replicaID, err = os.Hostname(); err != nil {
+			return env.GetFuncError(env.UnexpectedError, err)
+		}
+	}
+
+	client, err := NewKafkaProxyClient()
+	if err != nil {
+		return err
+	}
+	go func() {
+		defer client.Close()
+		if err := RunEnvDistribution(context.Background(), client, replicaID, func(record e.KafkaConsumedRecordData, err error) {
+			fmt.Printf("Env distribution record reddedildi: %s\n %v\n", record.Key, err)
+		}); err != nil {
+			fmt.Printf("Env distribution durdu: %v\n", err)
+		}
+	}()
+	return nil
+}
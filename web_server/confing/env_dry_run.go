@@ -0,0 +1,291 @@
+package config
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+	u "web_server/utils"
+	v "web_server/validations"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+/*
+- candidate env map, whitelist ya da access data file yüklenmeden doğrulanır. Store ve files üzerinde hiçbir değişiklik yapılmaz.
+- imza, status, içerik, reference key slice ve schema kontrolleri ilk hatada durmadan çalıştırılır ve tek rapor üzerinde toplanır.
+- candidate file yüklü data ile diff engine üzerinden karşılaştırılır. Layered env maps için candidate base file olduğu için yüklü base env map ile karşılaştırılır.
+- imzası doğrulanamayan candidate için yüklü values diff üzerinde gösterilmez, sadece keys değişiklikleri döner.
+- desteklenmeyen input hata döner, candidate file üzerindeki hatalar rapor içerisinde döner.
+*/
+func DryRunEnvFile(input e.EnvDryRunInput, locale string) (e.EnvDryRunResultData, error) {
+	result := e.EnvDryRunResultData{Kind: input.Kind, Field: input.Field}
+	output, diff, err := dryRunEnvFile(input)
+	if err != nil {
+		return result, err
+	}
+
+	result.ReportInfos = output.Report(locale)
+	result.DiffInfos = diff
+	return result, nil
+}
+
+// doğrulama çıktısı watcher tarafından hata olarak da kullanılır.
+func dryRunEnvFile(input e.EnvDryRunInput) (v.ValidateEnvOutput, e.EnvMapDiffData, error) {
+	sysPubKeyData, err := env.GetPubKey(env.SystemKey)
+	if err != nil {
+		return v.ValidateEnvOutput{}, e.EnvMapDiffData{}, err
+	}
+
+	output := v.NewValidateEnvOutput()
+	var diff e.EnvMapDiffData
+	switch input.Kind {
+	case env.EnvDryRunKindEnvMap:
+		diff, err = dryRunEnvMap(sysPubKeyData, input, &output)
+	case env.EnvDryRunKindWhitelist:
+		diff, err = dryRunWhitelist(sysPubKeyData, input, &output)
+	case env.EnvDryRunKindAccessData:
+		diff, err = dryRunAccessData(sysPubKeyData, input, &output)
+	default:
+		return v.ValidateEnvOutput{}, e.EnvMapDiffData{}, env.GetFuncError(env.UnsupportedEnvDryRun, nil, input.Kind)
+	}
+	if err != nil {
+		return v.ValidateEnvOutput{}, e.EnvMapDiffData{}, err
+	}
+	return output.Finalize(), diff, nil
+}
+
+func dryRunEnvMap(sysPubKeyData *e.PubKeyData, input e.EnvDryRunInput, output *v.ValidateEnvOutput) (e.EnvMapDiffData, error) {
+	if !slices.Contains(env.DryRunEnvMapFieldSlice, input.Field) {
+		return e.EnvMapDiffData{}, env.GetFuncError(env.UnsupportedEnvDryRun, nil, input.Field)
+	}
+
+	envFileData := &e.EnvFileData[string, e.EnvData[[]byte]]{}
+	if err := cbor.Unmarshal(input.File, envFileData); err != nil {
+		output.AppendError(env.GetFuncError(env.InvalidMapValue, err, input.Field), "File")
+		return e.EnvMapDiffData{}, nil
+	}
+	envMap := envFileData.EnvMapInfos
+
+	signed := true
+	if err := u.VerifySign(e.VerifySignInput[e.EnvMapData[string, e.EnvData[[]byte]]]{
+		SignType:  env.SignTypeED25519,
+		PublicKey: sysPubKeyData.PubKey,
+		Signed:    envFileData.SignatureInfos.Signature,
+		Data:      envMap,
+	}); err != nil {
+		output.AppendError(err, "SignatureInfos")
+		signed = false
+	}
+
+	//env map ve values status ve içerik kontrolleri
+	output.Merge(v.ValidateExternalEnvMapDataReport(envMap))
+
+	schemaField := input.Field
+	effective := envMap
+	var pending []string
+	layered := slices.Contains(env.LayeredEnvMapFieldSlice, input.Field)
+	if layered {
+		//overlays uygulanır ve references çözülür, schema effective env map üzerinde doğrulanır.
+		layers, err := buildEnvMapLayers(sysPubKeyData, input.Field, envMap)
+		if err != nil {
+			output.AppendError(err, "EnvMapData")
+			schemaField = ""
+		} else {
+			effective = layers.effective
+			pending = layers.pending
+			for _, dependentField := range slices.Sorted(maps.Keys(layers.dependents)) {
+				dependent := layers.dependents[dependentField]
+				if err := checkEnvMapSchema(dependentField, dependent.envMap, dependent.pending...); err != nil {
+					output.AppendError(err, dependentField)
+				}
+			}
+		}
+	}
+
+	if err := checkEnvMapKeyRefSlice(input.Field, effective, output); err != nil {
+		return e.EnvMapDiffData{}, err
+	}
+	if schemaField != "" {
+		schemaOutput, err := envMapSchemaReport(schemaField, effective, pending...)
+		if err != nil {
+			return e.EnvMapDiffData{}, err
+		}
+		output.Merge(schemaOutput)
+	}
+
+	current, _, _ := env.LoadEnvView().EnvMapRaw(envDiffStoreKey(input.Field))
+	return dryRunEnvDiff(input.Field, current, envMap, signed), nil
+}
+
+/*
+- env map keys field için tanımlı reference key slice ile kontrol edilir. Schema içerisinde tanımlı keys de kabul edilir.
+- func error env içerisinde reference slice locales bulunmak zorundadır.
+*/
+func checkEnvMapKeyRefSlice(field string, envMap e.EnvMapData[string, e.EnvData[[]byte]], output *v.ValidateEnvOutput) error {
+	refSlice, ok := env.EnvMapKeyRefSliceInfos[field]
+	if !ok {
+		return nil
+	}
+	//aktif olmayan schema hatası schema raporu üzerinde yazılır, keys sadece reference slice ile kontrol edilir.
+	schema, _, err := getEnvMapSchema(field)
+	if err != nil && !env.IsFuncError(err, env.InvalidEnvSchema) {
+		return err
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(envMap.EnvInfos)) {
+		if _, declared := schema.Fields[key]; declared || slices.Contains(refSlice, key) {
+			continue
+		}
+		output.AppendError(env.GetFuncError(env.UnknownEnvMapKey, nil, key), "EnvMapData", "EnvInfos", "["+key+"]")
+	}
+
+	if field == env.FuncErrorEnvMapField {
+		for _, locale := range refSlice {
+			if _, ok := envMap.EnvInfos[locale]; !ok {
+				output.AppendError(env.GetFuncError(env.InvalidEnvMapKeySlice, nil, env.FuncErrorEnvTag), "EnvMapData", "EnvInfos", "["+locale+"]")
+			}
+		}
+	}
+	return nil
+}
+
+func dryRunWhitelist(sysPubKeyData *e.PubKeyData, input e.EnvDryRunInput, output *v.ValidateEnvOutput) (e.EnvMapDiffData, error) {
+	sysWhiteListData := &e.SystemWhiteListData[string, e.WhitelistOwnerData]{}
+	if err := cbor.Unmarshal(input.File, sysWhiteListData); err != nil {
+		output.AppendError(env.GetFuncError(env.InvalidMapValue, err, env.WhitelistEnvMapField), "File")
+		return e.EnvMapDiffData{}, nil
+	}
+	whitelistInfos := sysWhiteListData.WhitelistInfos
+
+	if err := u.CheckNewDataStatusInfos(&e.CheckDataStatusInfosInput{
+		Status:      whitelistInfos.StatusInfos.Status,
+		ActiveAt:    whitelistInfos.StatusInfos.ActiveAt,
+		ExpiresAt:   whitelistInfos.StatusInfos.ExpiresAt,
+		Description: whitelistInfos.StatusInfos.Description,
+	}); err != nil {
+		output.AppendError(err, "WhitelistInfos", "StatusInfos")
+	}
+
+	signed := true
+	if err := u.VerifySign(e.VerifySignInput[e.EnvMapData[string, e.WhitelistOwnerData]]{
+		SignType:  env.SignTypeED25519,
+		PublicKey: sysPubKeyData.PubKey,
+		Signed:    sysWhiteListData.SignatureInfos.Signature,
+		Data:      whitelistInfos,
+	}); err != nil {
+		output.AppendError(err, "SignatureInfos")
+		signed = false
+	}
+
+	//owner datası access data ve pub key bulunabilecek şekilde tanımlanmış olmak zorundadır.
+	for _, ownerKey := range slices.Sorted(maps.Keys(whitelistInfos.EnvInfos)) {
+		ownerData := whitelistInfos.EnvInfos[ownerKey]
+		if ownerKey == "" {
+			output.AppendError(env.GetFuncError(env.InvalidMapValue, errors.New("empty owner key"), env.WhitelistEnvMapField), "WhitelistInfos", "EnvInfos", "[]")
+			continue
+		}
+		if ownerData.PubKeyDataURI == "" {
+			output.AppendError(env.GetFuncError(env.InvalidMapValue, errors.New("empty pub key data uri"), ownerKey), "WhitelistInfos", "EnvInfos", "["+ownerKey+"]", "PubKeyDataURI")
+		}
+		if _, err := u.CIDv1BytesToString(ownerData.AccessDataCID); err != nil {
+			output.AppendError(err, "WhitelistInfos", "EnvInfos", "["+ownerKey+"]", "AccessDataCID")
+		}
+	}
+
+	current, _, _ := env.LoadEnvView().EnvMapRaw(env.WhitelistEnvMapField)
+	return dryRunEnvDiff(env.WhitelistEnvMapField, current, whitelistInfos, signed), nil
+}
+
+/*
+- access data yüklü whitelist üzerindeki owner datası ile doğrulanır. Whitelist güncellenmeden yüklenecek access data cid eşleşmez.
+- diff owner PermInfos ve AuthnInfos status değişikliklerini döner.
+*/
+func dryRunAccessData(sysPubKeyData *e.PubKeyData, input e.EnvDryRunInput, output *v.ValidateEnvOutput) (e.EnvMapDiffData, error) {
+	if input.WhitelistKey == "" {
+		return e.EnvMapDiffData{}, env.GetFuncError(env.UnsupportedEnvDryRun, nil, "whitelist key required")
+	}
+
+	accessData := &e.AccessData{}
+	if err := cbor.Unmarshal(input.File, accessData); err != nil {
+		output.AppendError(env.GetFuncError(env.InvalidMapValue, err, input.WhitelistKey), "File")
+		return e.EnvMapDiffData{}, nil
+	}
+
+	whitelistOwnerData, err := getWhitelistOwnerData(input.WhitelistKey)
+	if err != nil {
+		output.AppendError(err, "WhitelistKey")
+		return dryRunEnvDiff(input.WhitelistKey, nil, accessPermEnvMap(accessData), false), nil
+	}
+
+	authnInfosCID, err := generateDataCID(accessData.AuthnInfos)
+	if err != nil {
+		return e.EnvMapDiffData{}, err
+	}
+	if !u.ComparisonHash(whitelistOwnerData.AccessDataCID, authnInfosCID) {
+		output.AppendError(env.GetFuncError(env.CIDMismatch, nil), "AuthnInfos")
+	}
+
+	signed := true
+	if ownerPubKeyData, err := getPubKey(env.SpecificPathKey, whitelistOwnerData.PubKeyDataURI); err != nil {
+		output.AppendError(err, "AuthnInfosSignInfos")
+		signed = false
+	} else if err := u.VerifySign(e.VerifySignInput[e.AuthnData]{
+		SignType:  env.SignTypeED25519,
+		PublicKey: ownerPubKeyData.PubKey,
+		Signed:    accessData.AuthnInfosSignInfos.Signature,
+		Data:      accessData.AuthnInfos,
+	}); err != nil {
+		output.AppendError(err, "AuthnInfosSignInfos")
+		signed = false
+	}
+
+	if err := u.VerifySign(e.VerifySignInput[map[string]e.PermissionData]{
+		SignType:  env.SignTypeED25519,
+		PublicKey: sysPubKeyData.PubKey,
+		Signed:    accessData.TaskInfosSignInfos.Signature,
+		Data:      accessData.AuthnInfos.PermInfos,
+	}); err != nil {
+		output.AppendError(err, "TaskInfosSignInfos")
+		signed = false
+	}
+
+	if err := u.CheckDataStatusInfos(&e.CheckDataStatusInfosInput{
+		Status:      accessData.AuthnInfos.StatusInfos.Status,
+		ActiveAt:    accessData.AuthnInfos.StatusInfos.ActiveAt,
+		ExpiresAt:   accessData.AuthnInfos.StatusInfos.ExpiresAt,
+		Description: accessData.AuthnInfos.StatusInfos.Description,
+	}); err != nil {
+		output.AppendError(err, "AuthnInfos", "StatusInfos")
+	}
+
+	var current any
+	if currentAccessData, err := getOwnerAccessData(whitelistOwnerData); err == nil {
+		current = accessPermEnvMap(currentAccessData)
+	}
+	return dryRunEnvDiff(input.WhitelistKey, current, accessPermEnvMap(accessData), signed), nil
+}
+
+// owner yetkileri diff için AuthnInfos status bilgisi ile env map olarak alınır.
+func accessPermEnvMap(accessData *e.AccessData) e.EnvMapData[string, e.PermissionData] {
+	return e.EnvMapData[string, e.PermissionData]{EnvInfos: accessData.AuthnInfos.PermInfos, StatusInfos: accessData.AuthnInfos.StatusInfos}
+}
+
+/*
+- candidate yüklü data ile karşılaştırılır. Yüklü data yoksa bütün keys added döner.
+- imzası doğrulanamayan candidate için changes sadece keys üzerinden yazılır, yüklü values dönmez.
+*/
+func dryRunEnvDiff(field string, current any, candidate any, signed bool) e.EnvMapDiffData {
+	diff := diffEnvMapValues(field, current, candidate)
+	diff.From, diff.To = env.EnvDiffSourceLive, env.EnvDiffSourceCandidate
+	if signed {
+		return diff
+	}
+
+	diff.ChangeInfos = make([]e.EnvMapChangeData, 0, len(diff.KeyInfos))
+	for _, keyDiff := range diff.KeyInfos {
+		diff.ChangeInfos = append(diff.ChangeInfos, e.EnvMapChangeData{Path: "EnvInfos[" + keyDiff.Key + "]", Change: keyDiff.Change})
+	}
+	return diff
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+)
+
+// field owner tarafından external olarak yüklenen env map mi kontrol edilir.
+func isExternalEnvMapField(field string) bool {
+	return slices.Contains(env.ExternalEnvMapFieldSlice, field)
+}
+
+// external env maps ve onlara reference veren env maps aynı anda tekrar çözülmemesi için yüklemeler sıralanır.
+var externalEnvMapMu sync.Mutex
+
+/*
+- owner tarafından yüklenen external env map references çözülür, field için tanımlı schema ile doğrulanır ve store üzerine yazılır.
+- env map üzerine reference veren ve önceden yüklenmiş env maps tekrar çözülür, doğrulanır ve aynı transaction ile güncellenir.
+- schema kontrolünden geçemeyen env map yüklenmez, mevcut env maps değişmez.
+*/
+func loadExternalEnvMap(field string, envMap e.EnvMapData[string, e.EnvData[[]byte]]) error {
+	if !isExternalEnvMapField(field) {
+		return env.GetFuncError(env.UnsupportedEnvMapField, nil, field)
+	}
+
+	externalEnvMapMu.Lock()
+	defer externalEnvMapMu.Unlock()
+
+	resolvedMaps, err := resolveEnvMapRefs(env.LoadEnvView(), map[string]e.EnvMapData[string, e.EnvData[[]byte]]{field: envMap})
+	if err != nil {
+		return err
+	}
+	for resolvedField, resolved := range resolvedMaps {
+		if err := checkEnvMapSchema(resolvedField, resolved.envMap, resolved.pending...); err != nil {
+			return err
+		}
+	}
+
+	tx := env.NewEnvTx()
+	var catalogs map[string]e.FuncMessageCatalogData
+	for resolvedField, resolved := range resolvedMaps {
+		if resolvedField == field {
+			env.TxPutEnvMap(tx, resolvedField, resolved.envMap)
+		} else {
+			if resolvedField == env.FuncErrorEnvMapField {
+				if catalogs, err = decodeFuncMessageCatalogs(resolved.envMap); err != nil {
+					return err
+				}
+			}
+			env.TxUpdateEnvMap(tx, resolvedField, resolved.envMap)
+		}
+		env.TxPutEnvMap(tx, env.EnvRefKey(resolvedField), resolved.refs)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	if catalogs != nil {
+		return env.SetFuncMessageCatalogs(catalogs)
+	}
+	return nil
+}
+
+// external env maps yüklendikten sonra watcher değişikliklerinde kullanılan bağımlılıklar ve loader
+type externalEnvReloader struct {
+	dependencyInfos map[string]e.EnvMapDependencyData
+	loadFunc        func(field string) error
+}
+
+var externalEnvReloaderInfo atomic.Pointer[externalEnvReloader]
+
+func setExternalEnvReloader(dependencyInfos map[string]e.EnvMapDependencyData, loadFunc func(field string) error) {
+	externalEnvReloaderInfo.Store(&externalEnvReloader{dependencyInfos: dependencyInfos, loadFunc: loadFunc})
+}
+
+/*
+- watcher tarafından değişikliği algılanan external env map ve ona bağımlı env maps bağımlılık sırası ile tekrar yüklenir.
+- external env maps henüz yüklenmemişse ya da file graph üzerinde tanımlı değilse işlem yapılmaz.
+- yüklemeden sonra bekleyen reference kalmamalıdır.
+*/
+func reloadWatchedEnvMap(filePath string) error {
+	reloader := externalEnvReloaderInfo.Load()
+	if reloader == nil {
+		return nil
+	}
+	field := filepath.Base(filePath)
+	if _, ok := reloader.dependencyInfos[field]; !ok {
+		return nil
+	}
+	if err := ReloadEnvMapGraph(reloader.dependencyInfos, reloader.loadFunc, field); err != nil {
+		return err
+	}
+	return checkPendingEnvRefs(env.LoadEnvView())
+}
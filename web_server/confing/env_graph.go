@@ -0,0 +1,208 @@
+package config
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+)
+
+/*
+- env maps bağımlılıkları üzerinden oluşturulan yönlü ve döngüsüz graph.
+- levels: aynı level içerisindeki env maps birbirine bağımlı değildir ve paralel yüklenir. Her level önceki levels tamamlandıktan sonra yüklenir.
+- dependents: env map değiştiğinde tekrar yüklenmesi gereken doğrudan bağımlı env maps
+*/
+type envMapGraph struct {
+	levels     [][]string
+	dependents map[string][]string
+}
+
+/*
+- bağımlılıklar doğrulanır. Tanımlı olmayan bağımlılık ve döngü bulunursa graph oluşturulmaz.
+- levels ve level içerisindeki sıra field ismine göre sıralanır, aynı bağımlılıklar için her zaman aynı yükleme planı üretilir.
+*/
+func newEnvMapGraph(dependencyInfos map[string]e.EnvMapDependencyData) (*envMapGraph, error) {
+	graph := &envMapGraph{dependents: make(map[string][]string, len(dependencyInfos))}
+	inDegrees := make(map[string]int, len(dependencyInfos))
+
+	for _, field := range slices.Sorted(maps.Keys(dependencyInfos)) {
+		inDegrees[field] = 0
+		for _, dependency := range slices.Compact(slices.Sorted(slices.Values(dependencyInfos[field].DependsOn))) {
+			if _, ok := dependencyInfos[dependency]; !ok {
+				return nil, env.GetFuncError(env.EnvMapDependencyNotFound, nil, dependency, field)
+			}
+			graph.dependents[dependency] = append(graph.dependents[dependency], field)
+			inDegrees[field]++
+		}
+	}
+
+	//bağımlılığı kalmayan env maps level olarak ayrılır ve bağımlı env maps bağımlılık sayısı düşürülür.
+	var level []string
+	for field, inDegree := range inDegrees {
+		if inDegree == 0 {
+			level = append(level, field)
+		}
+	}
+	loaded := 0
+	for len(level) > 0 {
+		slices.Sort(level)
+		graph.levels = append(graph.levels, level)
+		loaded += len(level)
+
+		var nextLevel []string
+		for _, field := range level {
+			for _, dependent := range graph.dependents[field] {
+				if inDegrees[dependent]--; inDegrees[dependent] == 0 {
+					nextLevel = append(nextLevel, dependent)
+				}
+			}
+		}
+		level = nextLevel
+	}
+
+	if loaded != len(dependencyInfos) {
+		return nil, env.GetFuncError(env.EnvMapDependencyCycle, nil, findEnvMapCycle(dependencyInfos, inDegrees))
+	}
+	return graph, nil
+}
+
+// level planına alınamayan env maps üzerinden döngü bulunur. Ex: rest-env.cbor -> task-env.cbor -> rest-env.cbor
+func findEnvMapCycle(dependencyInfos map[string]e.EnvMapDependencyData, inDegrees map[string]int) string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	states := make(map[string]int, len(dependencyInfos))
+	var path []string
+
+	var visit func(field string) []string
+	visit = func(field string) []string {
+		states[field] = visiting
+		path = append(path, field)
+		for _, dependency := range slices.Sorted(slices.Values(dependencyInfos[field].DependsOn)) {
+			switch states[dependency] {
+			case visiting:
+				start := slices.Index(path, dependency)
+				return append(slices.Clone(path[start:]), dependency)
+			case unvisited:
+				if cycle := visit(dependency); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		states[field] = visited
+		return nil
+	}
+
+	for _, field := range slices.Sorted(maps.Keys(dependencyInfos)) {
+		if inDegrees[field] == 0 || states[field] != unvisited {
+			continue
+		}
+		if cycle := visit(field); cycle != nil {
+			return strings.Join(cycle, " -> ")
+		}
+	}
+	return ""
+}
+
+/*
+- env maps level sırası ile yüklenir, level içerisindeki env maps paralel yüklenir.
+- fields nil değilse sadece fields içerisindeki env maps yüklenir, level sırası korunur.
+- level içerisinde hata olursa sonraki levels yüklenmez. Birden fazla hata varsa field sırasına göre ilk hata döner.
+*/
+func (graph *envMapGraph) load(fields map[string]bool, loadFunc func(field string) error) error {
+	for _, level := range graph.levels {
+		var levelFields []string
+		for _, field := range level {
+			if fields == nil || fields[field] {
+				levelFields = append(levelFields, field)
+			}
+		}
+
+		errs := make([]error, len(levelFields))
+		var wg sync.WaitGroup
+		for i, field := range levelFields {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = loadFunc(field)
+			}()
+		}
+		wg.Wait()
+
+		for _, err := range errs {
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// değişen env map ve ona doğrudan ya da dolaylı bağımlı bütün env maps döner.
+func (graph *envMapGraph) affectedBy(changedFields ...string) map[string]bool {
+	affected := map[string]bool{}
+	queue := slices.Clone(changedFields)
+	for len(queue) > 0 {
+		field := queue[0]
+		queue = queue[1:]
+		if affected[field] {
+			continue
+		}
+		affected[field] = true
+		queue = append(queue, graph.dependents[field]...)
+	}
+	return affected
+}
+
+/*
+- env maps içerisindeki references bağımlılık olarak eklenir, reference verilen env map önce yüklenir.
+- kendi üzerine verilen references ve graph üzerinde tanımlı olmayan env maps eklenmez. Yeni bağımlılıklar ile döngü oluşursa graph oluşturulmaz.
+*/
+func withEnvMapRefDependencies(dependencyInfos map[string]e.EnvMapDependencyData, envMaps map[string]e.EnvMapData[string, e.EnvData[[]byte]]) map[string]e.EnvMapDependencyData {
+	withRefs := make(map[string]e.EnvMapDependencyData, len(dependencyInfos))
+	for field, dependencyData := range dependencyInfos {
+		dependencyData.DependsOn = slices.Clone(dependencyData.DependsOn)
+		for _, envData := range envMaps[field].EnvInfos {
+			_, refs, _, _ := parseEnvRefs(envData)
+			for _, ref := range refs {
+				if _, ok := dependencyInfos[ref.field]; !ok || ref.field == field {
+					continue
+				}
+				dependencyData.DependsOn = append(dependencyData.DependsOn, ref.field)
+			}
+		}
+		withRefs[field] = dependencyData
+	}
+	return withRefs
+}
+
+// env maps bağımlılık graph üzerinden yüklenir. Bağımsız env maps paralel yüklenir.
+func LoadEnvMapGraph(dependencyInfos map[string]e.EnvMapDependencyData, loadFunc func(field string) error) error {
+	graph, err := newEnvMapGraph(dependencyInfos)
+	if err != nil {
+		return err
+	}
+	return graph.load(nil, loadFunc)
+}
+
+/*
+- değişen env maps ve onlara bağımlı env maps bağımlılık sırası ile tekrar yüklenir. Diğer env maps tekrar yüklenmez.
+- graph üzerinde tanımlı olmayan field değişikliği hata döner.
+*/
+func ReloadEnvMapGraph(dependencyInfos map[string]e.EnvMapDependencyData, loadFunc func(field string) error, changedFields ...string) error {
+	graph, err := newEnvMapGraph(dependencyInfos)
+	if err != nil {
+		return err
+	}
+	for _, field := range changedFields {
+		if _, ok := dependencyInfos[field]; !ok {
+			return env.GetFuncError(env.EnvMapKeyNotFound, nil, field)
+		}
+	}
+	return graph.load(graph.affectedBy(changedFields...), loadFunc)
+}
@@ -0,0 +1,224 @@
+package config
+
+import (
+	"maps"
+	"path/filepath"
+	"slices"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+	u "web_server/utils"
+)
+
+// layer file store path bilgisi. Ex: overlays/production/main-env.cbor, overlays/replicas/replica-1/main-env.cbor
+func envLayerPathField(layer string, name string, field string) string {
+	switch layer {
+	case env.EnvLayerTag:
+		return filepath.Join(env.EnvOverlaysDir, name, field)
+	case env.EnvLayerReplica:
+		return filepath.Join(env.EnvOverlaysDir, env.EnvReplicaOverridesDir, name, field)
+	default:
+		return field
+	}
+}
+
+// base, effective env map ve effective keys kaynakları. Effective env map references çözülmüş halidir.
+type envMapLayers struct {
+	base       e.EnvMapData[string, e.EnvData[[]byte]]
+	effective  e.EnvMapData[string, e.EnvData[[]byte]]
+	sources    e.EnvMapData[string, e.EnvLayerSourceData]
+	refs       e.EnvMapData[string, e.EnvData[[]byte]]
+	pending    []string                  //yüklenmemiş external env map üzerine reference veren keys
+	dependents map[string]resolvedEnvMap //effective env map üzerine reference veren ve tekrar çözülen env maps
+}
+
+/*
+- base env map üzerine sırası ile system tag overlay ve replica override uygulanır ve references çözülür.
+- effective env map ve tekrar çözülen bağımlı env maps field schema ile doğrulanır.
+*/
+func resolveEnvMapLayers(sysPubKeyData *e.PubKeyData, field string, base e.EnvMapData[string, e.EnvData[[]byte]]) (envMapLayers, error) {
+	layers, err := buildEnvMapLayers(sysPubKeyData, field, base)
+	if err != nil {
+		return envMapLayers{}, err
+	}
+
+	if err := checkEnvMapSchema(field, layers.effective, layers.pending...); err != nil {
+		return envMapLayers{}, err
+	}
+	for dependentField, dependent := range layers.dependents {
+		if err := checkEnvMapSchema(dependentField, dependent.envMap, dependent.pending...); err != nil {
+			return envMapLayers{}, err
+		}
+	}
+	return layers, nil
+}
+
+/*
+- base env map üzerine sırası ile system tag overlay ve replica override uygulanır. Layer file yoksa atlanır.
+- her layer file ayrı olarak system pub key ile imzalanmak zorundadır, sadece base üzerinden farklı olan keys içerir.
+- üst layer içerisindeki key alt layer değerinin tamamının üzerine yazar. Layers uygulandıktan sonra references çözülür.
+*/
+func buildEnvMapLayers(sysPubKeyData *e.PubKeyData, field string, base e.EnvMapData[string, e.EnvData[[]byte]]) (envMapLayers, error) {
+	layers := envMapLayers{
+		base: base,
+		effective: e.EnvMapData[string, e.EnvData[[]byte]]{
+			EnvInfos:     maps.Clone(base.EnvInfos),
+			StatusInfos:  base.StatusInfos,
+			SpecificInfo: base.SpecificInfo,
+		},
+		sources: e.EnvMapData[string, e.EnvLayerSourceData]{
+			EnvInfos:    make(map[string]e.EnvLayerSourceData, len(base.EnvInfos)),
+			StatusInfos: base.StatusInfos,
+		},
+	}
+	if layers.effective.EnvInfos == nil {
+		layers.effective.EnvInfos = map[string]e.EnvData[[]byte]{}
+	}
+	for key := range base.EnvInfos {
+		layers.sources.EnvInfos[key] = e.EnvLayerSourceData{Layer: env.EnvLayerBase, File: field}
+	}
+
+	layerInfos := env.GetEnvLayerInfos()
+	for _, layer := range []e.EnvLayerSourceData{
+		{Layer: env.EnvLayerTag, Name: layerInfos.SystemTag},
+		{Layer: env.EnvLayerReplica, Name: layerInfos.ReplicaID},
+	} {
+		if layer.Name == "" {
+			continue
+		}
+		layer.File = envLayerPathField(layer.Layer, layer.Name, field)
+
+		exists, err := fileExists(env.MainPathEnvsPathKey, layer.File)
+		if err != nil {
+			return envMapLayers{}, err
+		}
+		if !exists {
+			continue
+		}
+
+		var layerEnvEng *FileEngine[e.EnvFileData[string, e.EnvData[[]byte]]] = &FileEngine[e.EnvFileData[string, e.EnvData[[]byte]]]{Owner: env.System}
+		layerEnvFileData := &e.EnvFileData[string, e.EnvData[[]byte]]{}
+		if err := layerEnvEng.IFGet(e.GetInput[e.EnvFileData[string, e.EnvData[[]byte]]]{
+			PathKey:    env.MainPathEnvsPathKey,
+			PathFields: []string{layer.File},
+			Data:       layerEnvFileData,
+		}); err != nil {
+			return envMapLayers{}, err
+		}
+
+		if err := checkMainEnv(sysPubKeyData, layerEnvFileData); err != nil {
+			return envMapLayers{}, env.GetFuncError(env.InvalidEnvLayer, err, layer.File, "overlay verification failed")
+		}
+
+		//imzalı layer file başka bir layer, name ya da field için kopyalanarak uygulanamaz.
+		if !checkEnvLayerBinding(layerEnvFileData.EnvMapInfos, env.EnvLayerBinding(layer.Layer, layer.Name, field)) {
+			return envMapLayers{}, env.GetFuncError(env.InvalidEnvLayer, nil, layer.File, "overlay is not bound to "+env.EnvLayerBinding(layer.Layer, layer.Name, field))
+		}
+
+		for key, envData := range layerEnvFileData.EnvMapInfos.EnvInfos {
+			source := layer
+			if previous, ok := layers.sources.EnvInfos[key]; ok {
+				source.Overrides = append(slices.Clone(previous.Overrides), previous.File)
+			}
+			layers.effective.EnvInfos[key] = envData
+			layers.sources.EnvInfos[key] = source
+		}
+	}
+
+	resolvedMaps, err := resolveEnvMapRefs(env.LoadEnvView(), map[string]e.EnvMapData[string, e.EnvData[[]byte]]{field: layers.effective})
+	if err != nil {
+		return envMapLayers{}, err
+	}
+	layers.effective = resolvedMaps[field].envMap
+	layers.refs = resolvedMaps[field].refs
+	layers.pending = resolvedMaps[field].pending
+	delete(resolvedMaps, field)
+	layers.dependents = resolvedMaps
+	return layers, nil
+}
+
+// imzalı StatusInfos ya da SpecificInfo description bilgisi layer binding ile eşleşmek zorundadır.
+func checkEnvLayerBinding(layerEnvMap e.EnvMapData[string, e.EnvData[[]byte]], binding string) bool {
+	return layerEnvMap.StatusInfos.Description == binding || layerEnvMap.SpecificInfo.FieldInfos.Description == binding
+}
+
+/*
+- effective env map, base env map, keys kaynakları, references ve tekrar çözülen bağımlı env maps tek transaction ile yüklenir.
+- setNew true ise effective env map mevcut olmamalıdır, false ise mevcut env map güncellenir.
+- func error env tekrar çözüldüyse message catalogs da güncellenir.
+*/
+func commitEnvMapLayers(field string, layers envMapLayers, setNew bool) error {
+	tx := env.NewEnvTx()
+	if setNew {
+		env.TxSetNewEnvMap(tx, field, layers.effective)
+	} else {
+		env.TxPutEnvMap(tx, field, layers.effective)
+	}
+	env.TxPutEnvMap(tx, env.EnvLayerBaseKey(field), layers.base)
+	env.TxPutEnvMap(tx, env.EnvLayerSourcesKey(field), layers.sources)
+	env.TxPutEnvMap(tx, env.EnvRefKey(field), layers.refs)
+
+	var catalogs map[string]e.FuncMessageCatalogData
+	for dependentField, dependent := range layers.dependents {
+		if dependentField == env.FuncErrorEnvMapField {
+			var err error
+			if catalogs, err = decodeFuncMessageCatalogs(dependent.envMap); err != nil {
+				return err
+			}
+		}
+		env.TxUpdateEnvMap(tx, dependentField, dependent.envMap)
+		env.TxPutEnvMap(tx, env.EnvRefKey(dependentField), dependent.refs)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	if catalogs != nil {
+		return env.SetFuncMessageCatalogs(catalogs)
+	}
+	return nil
+}
+
+/*
+- layered env map içerisindeki effective values geldiği layer bilgisi ile key sırasına göre döner. Reference içeren values template ile döner.
+- effective env map ve kaynaklar aynı store snapshot üzerinden okunur. Secret values redact edilir.
+*/
+func GetEffectiveEnvMap(field string) ([]e.EffectiveEnvValueData, error) {
+	if !slices.Contains(env.LayeredEnvMapFieldSlice, field) {
+		return nil, env.GetFuncError(env.EnvMapKeyNotFound, nil, field)
+	}
+
+	view := env.LoadEnvView()
+	effective, err := env.ViewEnvMap[string, e.EnvData[[]byte]](view, field)
+	if err != nil {
+		return nil, err
+	}
+	if effective, err = u.RedactEnvSecrets(effective); err != nil {
+		return nil, err
+	}
+	sources, err := env.ViewEnvMap[string, e.EnvLayerSourceData](view, env.EnvLayerSourcesKey(field))
+	if err != nil {
+		return nil, err
+	}
+	refs, _ := env.ViewEnvMap[string, e.EnvData[[]byte]](view, env.EnvRefKey(field))
+
+	effectiveValues := make([]e.EffectiveEnvValueData, 0, len(effective.EnvInfos))
+	for _, key := range slices.Sorted(maps.Keys(effective.EnvInfos)) {
+		envData := effective.EnvInfos[key]
+		source, ok := sources.EnvInfos[key]
+		if !ok {
+			source = e.EnvLayerSourceData{Layer: env.EnvLayerBase, File: field}
+		}
+		effectiveValue := e.EffectiveEnvValueData{
+			Key:        key,
+			Value:      envData.Value,
+			Kind:       envData.Kind,
+			StatusInfo: envData.StatusInfo,
+			SourceInfo: source,
+		}
+		if refData, ok := refs.EnvInfos[key]; ok {
+			effectiveValue.Template = refData.Value
+		}
+		effectiveValues = append(effectiveValues, effectiveValue)
+	}
+	return effectiveValues, nil
+}
@@ -0,0 +1,279 @@
+package config
+
+import (
+	"cmp"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+	prom "web_server/infrastructure/prometheus"
+	u "web_server/utils"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// karantinaya alınacak reddedilen file ve red bilgileri
+type envQuarantineInput struct {
+	source      string
+	field       string
+	file        []byte
+	cause       error
+	signedBy    string
+	expectedCID []byte
+	actualCID   []byte
+}
+
+// karantina counter varsayılan prometheus registry üzerine kaydedilir. Metrics mevcut /metrics path üzerinden sunulur.
+func RegisterEnvQuarantineMetrics() error {
+	if err := prometheus.Register(prom.EnvQuarantinedFilesTotal); err != nil {
+		return env.GetFuncError(env.UnexpectedError, err)
+	}
+	return nil
+}
+
+func envQuarantinePathField(id string) string {
+	return filepath.Join(env.EnvQuarantineDir, id+env.Cbor)
+}
+
+/*
+- reddedilen file içeriği değiştirilmeden red sebebi, cid, imzalayan ve zaman bilgisi ile karantinaya kopyalanır.
+- ID file içeriğinin cid bilgisidir. Aynı file tekrar reddedilirse kayıt son red bilgisi ile güncellenir ve counter artırılmaz.
+*/
+func quarantineEnvFile(input envQuarantineInput) error {
+	fileCID, err := u.DatatoCIDv1Byte(input.file)
+	if err != nil {
+		return err
+	}
+	id, err := u.CIDv1BytesToString(fileCID)
+	if err != nil {
+		return err
+	}
+
+	quarantineInfos := e.EnvQuarantineData{
+		ID:            id,
+		Source:        input.source,
+		Field:         input.field,
+		Reason:        input.cause.Error(),
+		SignedBy:      input.signedBy,
+		QuarantinedAt: time.Now().Unix(),
+		Size:          len(input.file),
+	}
+	var funcErr *env.FuncError
+	if errors.As(input.cause, &funcErr) {
+		quarantineInfos.ErrorName = funcErr.Name
+	}
+	//geçersiz cid bilgisi red sebebi olabilir, string formatına çevrilemeyen cid boş bırakılır.
+	quarantineInfos.ExpectedCID, _ = u.CIDv1BytesToString(input.expectedCID)
+	quarantineInfos.ActualCID, _ = u.CIDv1BytesToString(input.actualCID)
+
+	var quarantineEng *FileEngine[e.EnvQuarantineFileData] = &FileEngine[e.EnvQuarantineFileData]{Owner: env.System}
+	quarantineDir, err := quarantineEng.IFGetRootFilePath(env.MainPathEnvsPathKey, env.EnvQuarantineDir)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(quarantineDir, 0o700); err != nil {
+		return env.GetFuncError(env.UnexpectedError, err)
+	}
+
+	exists, err := fileExists(env.MainPathEnvsPathKey, envQuarantinePathField(id))
+	if err != nil {
+		return err
+	}
+	if err := quarantineEng.IFPut(e.PutInput[e.EnvQuarantineFileData]{
+		PathKey:    env.MainPathEnvsPathKey,
+		PathFields: []string{envQuarantinePathField(id)},
+		Data:       &e.EnvQuarantineFileData{QuarantineInfos: quarantineInfos, File: input.file},
+	}); err != nil {
+		return err
+	}
+
+	if !exists {
+		prom.EnvQuarantinedFilesTotal.WithLabelValues(input.source).Inc()
+	}
+	return nil
+}
+
+// file karantinaya alınır ve red sebebi döner. Karantina yazılamazsa red sebebi ile birlikte karantina hatası döner.
+func rejectEnvFile(input envQuarantineInput) error {
+	if err := quarantineEnvFile(input); err != nil {
+		return errors.Join(input.cause, err)
+	}
+	return input.cause
+}
+
+/*
+- reddedilen env map file karantinaya alınır. ActualCID reddedilen env map, ExpectedCID yüklü env map cid bilgisidir.
+- layered env maps için yüklü base env map ile karşılaştırılır. File decode edilemediyse envMap nil gönderilir.
+*/
+func rejectEnvMapFile[V any](source string, field string, cause error, signature *e.SignatureData, envMap *e.EnvMapData[string, V]) error {
+	input := envQuarantineInput{source: source, field: field, cause: cause}
+
+	var readEng *FileEngine[struct{}] = &FileEngine[struct{}]{Owner: env.System}
+	filePath, err := readEng.IFGetRootFilePath(env.MainPathEnvsPathKey, field)
+	if err != nil {
+		return errors.Join(cause, err)
+	}
+	if input.file, err = os.ReadFile(filePath); err != nil {
+		return errors.Join(cause, env.GetFuncError(env.UnexpectedError, err))
+	}
+
+	if signature != nil {
+		input.signedBy = signature.SignedBy
+	}
+	if envMap != nil {
+		input.actualCID, _ = generateDataCID(*envMap)
+	}
+	input.expectedCID = loadedEnvMapCID[V](field)
+	return rejectEnvFile(input)
+}
+
+// yüklü env map cid bilgisi döner. Layered env maps için base env map kullanılır, env map yüklü değilse nil döner.
+func loadedEnvMapCID[V any](field string) []byte {
+	currentKey := field
+	if slices.Contains(env.LayeredEnvMapFieldSlice, field) {
+		currentKey = env.EnvLayerBaseKey(field)
+	}
+	current, err := env.GetEnvMap[string, V](currentKey)
+	if err != nil {
+		return nil
+	}
+	currentCID, _ := generateDataCID(current)
+	return currentCID
+}
+
+// access data file specific path üzerinden okunur ve karantinaya alınır.
+func rejectAccessDataFile(input envQuarantineInput, accessDataFilePath string, cause error) error {
+	input.cause = cause
+
+	var readEng *FileEngine[struct{}] = &FileEngine[struct{}]{Owner: env.System}
+	filePath, err := readEng.IFGetRootFilePath(env.SpecificPathKey, accessDataFilePath)
+	if err != nil {
+		return errors.Join(cause, err)
+	}
+	if input.file, err = os.ReadFile(filePath); err != nil {
+		return errors.Join(cause, env.GetFuncError(env.UnexpectedError, err))
+	}
+	return rejectEnvFile(input)
+}
+
+/*
+- watcher tarafından değişikliği algılanan system env map ve whitelist files dry run ile doğrulanır. Geçersiz files karantinaya alınır.
+- file yüklenmez. Diğer files için işlem yapılmaz.
+*/
+func checkWatchedEnvFile(filePath string) error {
+	field := filepath.Base(filePath)
+	input := e.EnvDryRunInput{Field: field}
+	switch {
+	case field == env.WhitelistEnvMapField:
+		input.Kind = env.EnvDryRunKindWhitelist
+	case slices.Contains(env.DryRunEnvMapFieldSlice, field):
+		input.Kind = env.EnvDryRunKindEnvMap
+	default:
+		return nil
+	}
+	file, err := os.ReadFile(filePath)
+	if err != nil {
+		return env.GetFuncError(env.UnexpectedError, err)
+	}
+	input.File = file
+
+	output, _, err := dryRunEnvFile(input)
+	if err != nil {
+		return err
+	}
+	cause := output.Err()
+	if cause == nil {
+		return nil
+	}
+
+	quarantineInput := envQuarantineInput{source: env.EnvQuarantineSourceWatcher, field: field, file: file, cause: cause}
+	switch input.Kind {
+	case env.EnvDryRunKindWhitelist:
+		sysWhiteListData := &e.SystemWhiteListData[string, e.WhitelistOwnerData]{}
+		if cbor.Unmarshal(file, sysWhiteListData) == nil {
+			quarantineInput.signedBy = sysWhiteListData.SignatureInfos.SignedBy
+			quarantineInput.actualCID, _ = generateDataCID(sysWhiteListData.WhitelistInfos)
+		}
+		quarantineInput.expectedCID = loadedEnvMapCID[e.WhitelistOwnerData](field)
+	default:
+		envFileData := &e.EnvFileData[string, e.EnvData[[]byte]]{}
+		if cbor.Unmarshal(file, envFileData) == nil {
+			quarantineInput.signedBy = envFileData.SignatureInfos.SignedBy
+			quarantineInput.actualCID, _ = generateDataCID(envFileData.EnvMapInfos)
+		}
+		quarantineInput.expectedCID = loadedEnvMapCID[e.EnvData[[]byte]](field)
+	}
+	return rejectEnvFile(quarantineInput)
+}
+
+// karantina kayıtları file içerikleri olmadan en son karantinaya alınan ilk sırada olacak şekilde döner.
+func ListEnvQuarantine() ([]e.EnvQuarantineData, error) {
+	var quarantineEng *FileEngine[e.EnvQuarantineFileData] = &FileEngine[e.EnvQuarantineFileData]{Owner: env.System}
+	quarantineDir, err := quarantineEng.IFGetRootFilePath(env.MainPathEnvsPathKey, env.EnvQuarantineDir)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(quarantineDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []e.EnvQuarantineData{}, nil
+		}
+		return nil, env.GetFuncError(env.UnexpectedError, err)
+	}
+
+	quarantineInfos := []e.EnvQuarantineData{}
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), env.Cbor)
+		if entry.IsDir() || !ok || u.IsValidCID(id) != nil {
+			continue
+		}
+		quarantineFileData, err := GetEnvQuarantine(id)
+		if err != nil {
+			return nil, err
+		}
+		quarantineInfos = append(quarantineInfos, quarantineFileData.QuarantineInfos)
+	}
+	slices.SortFunc(quarantineInfos, func(left, right e.EnvQuarantineData) int {
+		return cmp.Or(cmp.Compare(right.QuarantinedAt, left.QuarantinedAt), strings.Compare(left.ID, right.ID))
+	})
+	return quarantineInfos, nil
+}
+
+// karantina kaydı reddedilen file içeriği ile birlikte döner. ID cid formatında değilse path üzerinde kullanılmaz.
+func GetEnvQuarantine(id string) (e.EnvQuarantineFileData, error) {
+	if err := u.IsValidCID(id); err != nil {
+		return e.EnvQuarantineFileData{}, env.GetFuncError(env.InvalidEnvQuarantineID, nil, id)
+	}
+	exists, err := fileExists(env.MainPathEnvsPathKey, envQuarantinePathField(id))
+	if err != nil {
+		return e.EnvQuarantineFileData{}, err
+	}
+	if !exists {
+		return e.EnvQuarantineFileData{}, env.GetFuncError(env.EnvQuarantineNotFound, nil, id)
+	}
+
+	var quarantineEng *FileEngine[e.EnvQuarantineFileData] = &FileEngine[e.EnvQuarantineFileData]{Owner: env.System}
+	quarantineFileData := &e.EnvQuarantineFileData{}
+	if err := quarantineEng.IFGet(e.GetInput[e.EnvQuarantineFileData]{
+		PathKey:    env.MainPathEnvsPathKey,
+		PathFields: []string{envQuarantinePathField(id)},
+		Data:       quarantineFileData,
+	}); err != nil {
+		return e.EnvQuarantineFileData{}, err
+	}
+	return *quarantineFileData, nil
+}
+
+// karantina kaydı güvenli olarak silinir.
+func PurgeEnvQuarantine(id string) error {
+	if _, err := GetEnvQuarantine(id); err != nil {
+		return err
+	}
+	var quarantineEng *FileEngine[e.EnvQuarantineFileData] = &FileEngine[e.EnvQuarantineFileData]{Owner: env.System}
+	return quarantineEng.IFSecureRemove(env.MainPathEnvsPathKey, envQuarantinePathField(id))
+}
@@ -0,0 +1,300 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// env value içerisindeki reference. Ex: ${path-env:broker-host} => {path-env.cbor, broker-host}
+type envRef struct {
+	field string
+	key   string
+}
+
+func (ref envRef) String() string {
+	return ref.field + ":" + ref.key
+}
+
+// çözülmüş env map, references içeren values çözülmemiş halleri ve reference verilen env map yüklenene kadar bekleyen keys
+type resolvedEnvMap struct {
+	envMap  e.EnvMapData[string, e.EnvData[[]byte]]
+	refs    e.EnvMapData[string, e.EnvData[[]byte]]
+	pending []string
+}
+
+// references içerebilen env maps. References çözülmemiş halleri EnvRefKey üzerinde tutulur.
+func refEnvMapFields() []string {
+	return slices.Concat(env.LayeredEnvMapFieldSlice, env.ExternalEnvMapFieldSlice)
+}
+
+/*
+- cbor string value içerisinde reference varsa template ve references döner.
+- secret values ve string olmayan values reference içeremez.
+*/
+func parseEnvRefs(envData e.EnvData[[]byte]) (string, []envRef, [][]int, bool) {
+	if envData.Kind != "" {
+		return "", nil, nil, false
+	}
+	var template string
+	if err := cbor.Unmarshal(envData.Value, &template); err != nil {
+		return "", nil, nil, false
+	}
+	matches := env.EnvRefPattern.FindAllStringSubmatchIndex(template, -1)
+	if len(matches) == 0 {
+		return "", nil, nil, false
+	}
+
+	refs := make([]envRef, 0, len(matches))
+	for _, match := range matches {
+		refs = append(refs, envRef{
+			field: env.EnvRefEnvMapField(template[match[2]:match[3]]),
+			key:   template[match[4]:match[5]],
+		})
+	}
+	return template, refs, matches, true
+}
+
+/*
+- reference içeren value referans verilen value status bilgisini taşır. Referans verilen value aktif değilse value da aktif değildir.
+- Status birlikte değerlendirilir, ActiveAt en geç ve ExpiresAt en erken olan alınır.
+*/
+func combineEnvRefStatus(status e.StatusData, refStatus e.StatusData) e.StatusData {
+	status.Status = status.Status && refStatus.Status
+	status.ActiveAt = max(status.ActiveAt, refStatus.ActiveAt)
+	if refStatus.ExpiresAt != 0 && (status.ExpiresAt == 0 || refStatus.ExpiresAt < status.ExpiresAt) {
+		status.ExpiresAt = refStatus.ExpiresAt
+	}
+	return status
+}
+
+/*
+- raw: çözülecek env maps references çözülmemiş halleri ile tutulur. Raw içerisinde olmayan env maps view üzerinden okunur.
+- view üzerindeki env map için references çözülmemiş hali varsa reference tekrar çözülür, böylece env maps arası döngüler bulunur.
+- pending: henüz yüklenmemiş external env map üzerine reference veren values. Values aktif değildir ve template olarak kalır.
+*/
+type envRefResolver struct {
+	view     env.EnvView
+	raw      map[string]e.EnvMapData[string, e.EnvData[[]byte]]
+	resolved map[envRef]e.EnvData[[]byte]
+	pending  map[envRef]bool
+	visiting []envRef
+}
+
+/*
+- internal env maps external env maps yüklenmeden önce yüklenir. Yüklenmemiş external env map üzerine verilen reference bekletilir.
+- external env map yüklendiğinde bekleyen values bağımlı env maps olarak tekrar çözülür.
+*/
+func (resolver *envRefResolver) deferred(ref envRef) bool {
+	if _, ok := resolver.raw[ref.field]; ok || !isExternalEnvMapField(ref.field) {
+		return false
+	}
+	_, _, exists := resolver.view.EnvMapRaw(ref.field)
+	return !exists
+}
+
+// reference çözülmemiş env value getirilir.
+func (resolver *envRefResolver) rawEnvData(ref envRef) (e.EnvData[[]byte], error) {
+	if envMap, ok := resolver.raw[ref.field]; ok {
+		envData, ok := envMap.EnvInfos[ref.key]
+		if !ok {
+			return e.EnvData[[]byte]{}, env.GetFuncError(env.InvalidEnvReference, nil, ref.String(), "referenced key not found")
+		}
+		return envData, nil
+	}
+
+	if envData, err := env.ViewEnv[string, e.EnvData[[]byte]](resolver.view, env.EnvRefKey(ref.field), ref.key); err == nil {
+		return envData, nil
+	}
+	envData, err := env.ViewEnv[string, e.EnvData[[]byte]](resolver.view, ref.field, ref.key)
+	if err != nil {
+		return e.EnvData[[]byte]{}, env.GetFuncError(env.InvalidEnvReference, err, ref.String(), "referenced key not found")
+	}
+	return envData, nil
+}
+
+/*
+- value içerisindeki references sırası ile çözülür. Value tek bir reference ise referans verilen value türü ile birlikte alınır.
+- metin içerisindeki references sadece string, sayı ve bool values ile çözülür. Secret values referans verilemez.
+*/
+func (resolver *envRefResolver) resolve(ref envRef) (e.EnvData[[]byte], error) {
+	if envData, ok := resolver.resolved[ref]; ok {
+		return envData, nil
+	}
+	if i := slices.Index(resolver.visiting, ref); i >= 0 {
+		cycle := make([]string, 0, len(resolver.visiting)-i+1)
+		for _, visiting := range append(resolver.visiting[i:], ref) {
+			cycle = append(cycle, visiting.String())
+		}
+		return e.EnvData[[]byte]{}, env.GetFuncError(env.EnvReferenceCycle, nil, strings.Join(cycle, " -> "))
+	}
+
+	envData, err := resolver.rawEnvData(ref)
+	if err != nil {
+		return e.EnvData[[]byte]{}, err
+	}
+	template, refs, matches, ok := parseEnvRefs(envData)
+	if !ok {
+		resolver.resolved[ref] = envData
+		return envData, nil
+	}
+
+	resolver.visiting = append(resolver.visiting, ref)
+	defer func() { resolver.visiting = resolver.visiting[:len(resolver.visiting)-1] }()
+
+	var value strings.Builder
+	last := 0
+	for i, targetRef := range refs {
+		//yüklenmemiş external env map üzerine reference veren value bekletilir.
+		if resolver.deferred(targetRef) {
+			return resolver.setPending(ref, envData), nil
+		}
+		targetData, err := resolver.resolve(targetRef)
+		if err != nil {
+			return e.EnvData[[]byte]{}, err
+		}
+		if resolver.pending[targetRef] {
+			return resolver.setPending(ref, envData), nil
+		}
+		if targetData.Kind == env.EnvValueKindSecret {
+			return e.EnvData[[]byte]{}, env.GetFuncError(env.InvalidEnvReference, nil, targetRef.String(), "secret values cannot be referenced")
+		}
+		envData.StatusInfo = combineEnvRefStatus(envData.StatusInfo, targetData.StatusInfo)
+
+		//value sadece reference içeriyorsa referans verilen value olduğu gibi alınır. Ex: port int olarak kalır.
+		if len(refs) == 1 && matches[i][0] == 0 && matches[i][1] == len(template) {
+			envData.Value = targetData.Value
+			resolver.resolved[ref] = envData
+			return envData, nil
+		}
+
+		var targetValue any
+		if err := cbor.Unmarshal(targetData.Value, &targetValue); err != nil {
+			return e.EnvData[[]byte]{}, env.GetFuncError(env.InvalidEnvReference, err, targetRef.String(), "referenced value is not cbor")
+		}
+		switch targetValue.(type) {
+		case string, int64, uint64, float64, bool:
+		default:
+			return e.EnvData[[]byte]{}, env.GetFuncError(env.InvalidEnvReference, nil, targetRef.String(), "referenced value is not a scalar")
+		}
+		value.WriteString(template[last:matches[i][0]])
+		value.WriteString(fmt.Sprint(targetValue))
+		last = matches[i][1]
+	}
+	value.WriteString(template[last:])
+
+	encodedValue, err := cbor.Marshal(value.String())
+	if err != nil {
+		return e.EnvData[[]byte]{}, env.GetFuncError(env.UnexpectedError, err)
+	}
+	envData.Value = encodedValue
+	resolver.resolved[ref] = envData
+	return envData, nil
+}
+
+// bekleyen value template olarak ve aktif olmayan status ile tutulur.
+func (resolver *envRefResolver) setPending(ref envRef, envData e.EnvData[[]byte]) e.EnvData[[]byte] {
+	envData.StatusInfo.Status = false
+	resolver.resolved[ref] = envData
+	resolver.pending[ref] = true
+	return envData
+}
+
+/*
+- changed env maps references çözülür. Store üzerinde changed env maps üzerine reference veren env maps de tekrar çözülür.
+- yüklenmemiş external env maps üzerine verilen references bekletilir ve pending olarak döner.
+- herhangi bir reference çözülemezse hiçbir env map döndürülmez. Schema kontrolü çağıran tarafından yapılır.
+*/
+func resolveEnvMapRefs(view env.EnvView, changed map[string]e.EnvMapData[string, e.EnvData[[]byte]]) (map[string]resolvedEnvMap, error) {
+	raw := maps.Clone(changed)
+
+	//reference veren env maps değişen env maps üzerinden bulunur, bulunan env maps de değişmiş kabul edilir.
+	for found := true; found; {
+		found = false
+		for _, field := range refEnvMapFields() {
+			if _, ok := raw[field]; ok {
+				continue
+			}
+			refMap, err := env.ViewEnvMap[string, e.EnvData[[]byte]](view, env.EnvRefKey(field))
+			if err != nil {
+				continue
+			}
+			if !envMapRefersTo(refMap, raw) {
+				continue
+			}
+			current, err := env.ViewEnvMap[string, e.EnvData[[]byte]](view, field)
+			if err != nil {
+				return nil, err
+			}
+			current.EnvInfos = maps.Clone(current.EnvInfos)
+			maps.Copy(current.EnvInfos, refMap.EnvInfos)
+			raw[field] = current
+			found = true
+		}
+	}
+
+	resolver := &envRefResolver{view: view, raw: raw, resolved: map[envRef]e.EnvData[[]byte]{}, pending: map[envRef]bool{}}
+	resolvedMaps := make(map[string]resolvedEnvMap, len(raw))
+	for _, field := range slices.Sorted(maps.Keys(raw)) {
+		rawMap := raw[field]
+		resolved := resolvedEnvMap{
+			envMap: e.EnvMapData[string, e.EnvData[[]byte]]{EnvInfos: make(map[string]e.EnvData[[]byte], len(rawMap.EnvInfos)), SpecificInfo: rawMap.SpecificInfo, StatusInfos: rawMap.StatusInfos},
+			refs:   e.EnvMapData[string, e.EnvData[[]byte]]{EnvInfos: map[string]e.EnvData[[]byte]{}, StatusInfos: rawMap.StatusInfos},
+		}
+		for _, key := range slices.Sorted(maps.Keys(rawMap.EnvInfos)) {
+			envData, err := resolver.resolve(envRef{field: field, key: key})
+			if err != nil {
+				return nil, err
+			}
+			resolved.envMap.EnvInfos[key] = envData
+			if _, _, _, ok := parseEnvRefs(rawMap.EnvInfos[key]); ok {
+				resolved.refs.EnvInfos[key] = rawMap.EnvInfos[key]
+			}
+			if resolver.pending[envRef{field: field, key: key}] {
+				resolved.pending = append(resolved.pending, key)
+			}
+		}
+		resolvedMaps[field] = resolved
+	}
+	return resolvedMaps, nil
+}
+
+// env map içerisindeki references fields içerisindeki env maps üzerine reference veriyor mu kontrol edilir.
+func envMapRefersTo(refMap e.EnvMapData[string, e.EnvData[[]byte]], fields map[string]e.EnvMapData[string, e.EnvData[[]byte]]) bool {
+	for _, envData := range refMap.EnvInfos {
+		_, refs, _, _ := parseEnvRefs(envData)
+		for _, ref := range refs {
+			if _, ok := fields[ref.field]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+/*
+- external env maps yüklendikten sonra hala yüklenmemiş env map üzerine reference veren value varsa hata döner.
+- bekleyen reference ile açılış tamamlanmaz, value aktif olmadığı için kullanılamaz.
+*/
+func checkPendingEnvRefs(view env.EnvView) error {
+	for _, field := range refEnvMapFields() {
+		refMap, err := env.ViewEnvMap[string, e.EnvData[[]byte]](view, env.EnvRefKey(field))
+		if err != nil {
+			continue
+		}
+		for _, key := range slices.Sorted(maps.Keys(refMap.EnvInfos)) {
+			_, refs, _, _ := parseEnvRefs(refMap.EnvInfos[key])
+			for _, ref := range refs {
+				if _, _, exists := view.EnvMapRaw(ref.field); !exists {
+					return env.GetFuncError(env.InvalidEnvReference, nil, ref.String(), "referenced env map not loaded, required by "+field+":"+key)
+				}
+			}
+		}
+	}
+	return nil
+}
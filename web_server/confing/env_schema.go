@@ -0,0 +1,152 @@
+package config
+
+import (
+	"maps"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+	u "web_server/utils"
+	v "web_server/validations"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+/*
+- env schema file varsa system pub key ile doğrulanır ve diğer env maps yüklenmeden önce eklenir.
+- file yoksa env maps schema kontrolü yapılmadan yüklenir.
+*/
+func incEnvSchemaEnv(sysPubKeyData *e.PubKeyData) error {
+	exists, err := fileExists(env.MainPathEnvsPathKey, env.EnvSchemaEnvMapField)
+	if err != nil || !exists {
+		return err
+	}
+
+	var schemaEnvEng *FileEngine[e.EnvFileData[string, e.EnvData[[]byte]]] = &FileEngine[e.EnvFileData[string, e.EnvData[[]byte]]]{Owner: env.System}
+	schemaEnvFileData := &e.EnvFileData[string, e.EnvData[[]byte]]{}
+	if err := schemaEnvEng.IFGet(e.GetInput[e.EnvFileData[string, e.EnvData[[]byte]]]{
+		PathKey:    env.MainPathEnvsPathKey,
+		PathFields: []string{env.EnvSchemaEnvMapField},
+		Data:       schemaEnvFileData,
+	}); err != nil {
+		return err
+	}
+
+	if err := checkMainEnv(sysPubKeyData, schemaEnvFileData); err != nil {
+		return err
+	}
+
+	if err := checkEnvMapSchema(env.EnvSchemaEnvMapField, schemaEnvFileData.EnvMapInfos); err != nil {
+		return err
+	}
+
+	return env.SetNewEnvMap[string, e.EnvData[[]byte]](env.EnvSchemaEnvMapField, schemaEnvFileData.EnvMapInfos)
+}
+
+// env map field için tanımlı schema çözülür ve doğrulanır.
+func decodeEnvSchema(field string, envData e.EnvData[[]byte]) (e.EnvSchemaData, error) {
+	schema := e.EnvSchemaData{}
+	if err := cbor.Unmarshal(envData.Value, &schema); err != nil {
+		return schema, env.GetFuncError(env.InvalidEnvSchema, err, field, "schema is not cbor")
+	}
+	if err := v.ValidateEnvSchema(schema).Err(); err != nil {
+		return schema, err
+	}
+	return schema, nil
+}
+
+/*
+- yüklü schema env üzerinde field için tanımlı schema döner. Schema env yüklenmemiş ya da field için schema yoksa false döner.
+- schema tanımlı fakat aktif değilse kontrol atlanmasın diye hata döner.
+*/
+func getEnvMapSchema(field string) (e.EnvSchemaData, bool, error) {
+	schemaEnvMap, err := env.GetEnvMap[string, e.EnvData[[]byte]](env.EnvSchemaEnvMapField)
+	if err != nil {
+		if env.IsFuncError(err, env.EnvMapKeyNotFound) {
+			return e.EnvSchemaData{}, false, nil
+		}
+		return e.EnvSchemaData{}, false, err
+	}
+
+	envData, ok := schemaEnvMap.EnvInfos[field]
+	if !ok {
+		return e.EnvSchemaData{}, false, nil
+	}
+	if err := u.CheckDataStatusInfos(&e.CheckDataStatusInfosInput{
+		Status:      envData.StatusInfo.Status,
+		ActiveAt:    envData.StatusInfo.ActiveAt,
+		ExpiresAt:   envData.StatusInfo.ExpiresAt,
+		Description: envData.StatusInfo.Description,
+	}); err != nil {
+		return e.EnvSchemaData{}, false, env.GetFuncError(env.InvalidEnvSchema, err, field, "schema is not active")
+	}
+
+	schema, err := decodeEnvSchema(field, envData)
+	if err != nil {
+		return e.EnvSchemaData{}, false, err
+	}
+	return schema, true, nil
+}
+
+/*
+- env map SetNewEnvMap ile eklenmeden önce field için tanımlı schema ile doğrulanır. Schema yoksa kontrol yapılmaz.
+- schema env map kendisi yükleniyorsa içerisindeki her schema tanımı doğrulanır.
+- pending keys yüklenmemiş env map üzerine reference veren keys, reference çözüldüğünde doğrulanır.
+*/
+func checkEnvMapSchema(field string, envMap e.EnvMapData[string, e.EnvData[[]byte]], pending ...string) error {
+	if field == env.EnvSchemaEnvMapField {
+		for schemaField, envData := range envMap.EnvInfos {
+			if _, err := decodeEnvSchema(schemaField, envData); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	schema, ok, err := getEnvMapSchema(field)
+	if err != nil || !ok {
+		return err
+	}
+	schema, envMap = withoutPendingEnvKeys(schema, envMap, pending)
+	return v.ValidateEnvMapSchema(schema, envMap).Err()
+}
+
+// bekleyen keys schema ve env map üzerinden çıkarılır.
+func withoutPendingEnvKeys(schema e.EnvSchemaData, envMap e.EnvMapData[string, e.EnvData[[]byte]], pending []string) (e.EnvSchemaData, e.EnvMapData[string, e.EnvData[[]byte]]) {
+	if len(pending) == 0 {
+		return schema, envMap
+	}
+	schema.Fields = maps.Clone(schema.Fields)
+	envMap.EnvInfos = maps.Clone(envMap.EnvInfos)
+	for _, key := range pending {
+		delete(schema.Fields, key)
+		delete(envMap.EnvInfos, key)
+	}
+	return schema, envMap
+}
+
+/*
+- checkEnvMapSchema ile aynı kontroller ilk hatada durmadan rapor olarak döner. Dry run için kullanılır.
+- schema env map içerisindeki her schema tanımı ayrı olarak raporlanır.
+*/
+func envMapSchemaReport(field string, envMap e.EnvMapData[string, e.EnvData[[]byte]], pending ...string) (v.ValidateEnvOutput, error) {
+	output := v.NewValidateEnvOutput()
+	if field == env.EnvSchemaEnvMapField {
+		for schemaField, envData := range envMap.EnvInfos {
+			if _, err := decodeEnvSchema(schemaField, envData); err != nil {
+				output.AppendError(err, "EnvMapData", "EnvInfos", "["+schemaField+"]")
+			}
+		}
+		return output.Finalize(), nil
+	}
+
+	//aktif olmayan schema dry run raporuna eklenir.
+	schema, ok, err := getEnvMapSchema(field)
+	if env.IsFuncError(err, env.InvalidEnvSchema) {
+		output.AppendError(err, "EnvSchema")
+		return output.Finalize(), nil
+	}
+	if err != nil || !ok {
+		return output.Finalize(), err
+	}
+	schema, envMap = withoutPendingEnvKeys(schema, envMap, pending)
+	return v.ValidateEnvMapSchema(schema, envMap), nil
+}
@@ -0,0 +1,221 @@
+package config
+
+import (
+	"time"
+	a "web_server/domain/abstractions"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+	u "web_server/utils"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+/*
+- store üzerindeki secret key ring getirilir ve system pub key ile doğrulanır. Key ring yoksa false döner.
+- imzası doğrulanamayan key ring ile secret açılmaz.
+*/
+func GetEnvSecretKeyRing() (e.EnvSecretKeyRingInfoData, bool, error) {
+	exists, err := fileExists(env.MainPathEnvsPathKey, env.EnvSecretKeyRingField)
+	if err != nil || !exists {
+		return e.EnvSecretKeyRingInfoData{}, false, err
+	}
+
+	var keyRingEng *FileEngine[e.EnvSecretKeyRingData] = &FileEngine[e.EnvSecretKeyRingData]{Owner: env.System}
+	keyRing := &e.EnvSecretKeyRingData{}
+	if err := keyRingEng.IFGet(e.GetInput[e.EnvSecretKeyRingData]{
+		PathKey:    env.MainPathEnvsPathKey,
+		PathFields: []string{env.EnvSecretKeyRingField},
+		Data:       keyRing,
+	}); err != nil {
+		return e.EnvSecretKeyRingInfoData{}, false, err
+	}
+
+	sysPubKeyData, err := env.GetPubKey(env.SystemKey)
+	if err != nil {
+		return e.EnvSecretKeyRingInfoData{}, false, err
+	}
+	encodedKeyRing, err := u.MarshalCanonicalCBOR(keyRing.KeyRingInfos)
+	if err != nil {
+		return e.EnvSecretKeyRingInfoData{}, false, err
+	}
+	if err := u.VerifySignED25519(e.VerifySignED25519Input{
+		PublicKey: sysPubKeyData.PubKey,
+		Signed:    keyRing.SignatureInfos.Signature,
+		Data:      encodedKeyRing,
+	}); err != nil {
+		return e.EnvSecretKeyRingInfoData{}, false, err
+	}
+	return keyRing.KeyRingInfos, true, nil
+}
+
+// key ring system signer ile imzalanarak store üzerine yazılır.
+func putEnvSecretKeyRing(signer a.ISigner, keyRingInfos e.EnvSecretKeyRingInfoData) error {
+	encodedKeyRing, err := u.MarshalCanonicalCBOR(keyRingInfos)
+	if err != nil {
+		return err
+	}
+	signatureInfos, err := signer.IFSign(encodedKeyRing)
+	if err != nil {
+		return err
+	}
+
+	var keyRingEng *FileEngine[e.EnvSecretKeyRingData] = &FileEngine[e.EnvSecretKeyRingData]{Owner: env.System}
+	return keyRingEng.IFPut(e.PutInput[e.EnvSecretKeyRingData]{
+		PathKey:    env.MainPathEnvsPathKey,
+		PathFields: []string{env.EnvSecretKeyRingField},
+		Data:       &e.EnvSecretKeyRingData{KeyRingInfos: keyRingInfos, SignatureInfos: signatureInfos},
+	})
+}
+
+/*
+- yeni data key version üretilir ve system x25519 key üzerine mühürlenir. Sonraki secrets yeni key ile şifrelenir.
+- önceki versions daha önce şifrelenmiş secrets açılabilsin diye saklanır, env maps tekrar imzalanmaz.
+*/
+func RotateEnvSecretKey(signer a.ISigner, opener a.ISealedKeyOpener) (e.EnvSecretKeyData, error) {
+	keyRing, _, err := GetEnvSecretKeyRing()
+	if err != nil {
+		return e.EnvSecretKeyData{}, err
+	}
+
+	version := uint32(1)
+	if len(keyRing.KeyInfos) > 0 {
+		version = keyRing.KeyInfos[len(keyRing.KeyInfos)-1].Version + 1
+	}
+
+	dataKey, err := u.GenerateDataKey()
+	if err != nil {
+		return e.EnvSecretKeyData{}, err
+	}
+	defer clear(dataKey)
+
+	sealedDataKey, err := u.SealToX25519(opener.IFX25519PubKey(), dataKey)
+	if err != nil {
+		return e.EnvSecretKeyData{}, err
+	}
+
+	now := time.Now().Unix()
+	secretKey := e.EnvSecretKeyData{
+		Version:       version,
+		SealedDataKey: sealedDataKey,
+		X25519PubKey:  opener.IFX25519PubKey(),
+		StatusInfo:    e.StatusData{Status: true, CreatedAt: now, ActiveAt: now, UpdatedAt: now},
+	}
+	keyRing.KeyInfos = append(keyRing.KeyInfos, secretKey)
+	if err := putEnvSecretKeyRing(signer, keyRing); err != nil {
+		return e.EnvSecretKeyData{}, err
+	}
+	return secretKey, nil
+}
+
+/*
+- key encryption key değişimi. Bütün data key versions opener ile açılır ve recipientX25519PubKey üzerine tekrar mühürlenir.
+- data keys değişmediği için secret values ve env map imzaları değişmez, sadece key ring tekrar imzalanır.
+*/
+func RewrapEnvSecretKeys(signer a.ISigner, opener a.ISealedKeyOpener, recipientX25519PubKey []byte) error {
+	keyRing, exists, err := GetEnvSecretKeyRing()
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return env.GetFuncError(env.EnvSecretKeyNotFound, nil, 0)
+	}
+
+	now := time.Now().Unix()
+	for i, secretKey := range keyRing.KeyInfos {
+		dataKey, err := opener.IFOpenSealed(secretKey.SealedDataKey)
+		if err != nil {
+			return err
+		}
+		sealedDataKey, err := u.SealToX25519(recipientX25519PubKey, dataKey)
+		clear(dataKey)
+		if err != nil {
+			return err
+		}
+		keyRing.KeyInfos[i].SealedDataKey = sealedDataKey
+		keyRing.KeyInfos[i].X25519PubKey = recipientX25519PubKey
+		keyRing.KeyInfos[i].StatusInfo.UpdatedAt = now
+	}
+	return putEnvSecretKeyRing(signer, keyRing)
+}
+
+// key version için mühürlenmiş data key açılır.
+func openEnvSecretDataKey(keyRing e.EnvSecretKeyRingInfoData, keyVersion uint32, opener a.ISealedKeyOpener) ([]byte, error) {
+	for _, secretKey := range keyRing.KeyInfos {
+		if secretKey.Version == keyVersion {
+			return opener.IFOpenSealed(secretKey.SealedDataKey)
+		}
+	}
+	return nil, env.GetFuncError(env.EnvSecretKeyNotFound, nil, keyVersion)
+}
+
+/*
+- value aktif data key ile şifrelenir. Dönen EnvData imzalanacak env map içerisine envMapKey ve key altında eklenir.
+- ciphertext env map ve key bilgisine bağlıdır, başka bir key altına taşınırsa açılmaz.
+*/
+func SealEnvSecretValue(opener a.ISealedKeyOpener, envMapKey string, key string, value any, statusInfo e.StatusData) (e.EnvData[[]byte], error) {
+	keyRing, exists, err := GetEnvSecretKeyRing()
+	if err != nil {
+		return e.EnvData[[]byte]{}, err
+	}
+	if !exists || len(keyRing.KeyInfos) == 0 {
+		return e.EnvData[[]byte]{}, env.GetFuncError(env.EnvSecretKeyNotFound, nil, 0)
+	}
+	activeKey := keyRing.KeyInfos[len(keyRing.KeyInfos)-1]
+
+	dataKey, err := openEnvSecretDataKey(keyRing, activeKey.Version, opener)
+	if err != nil {
+		return e.EnvData[[]byte]{}, err
+	}
+	defer clear(dataKey)
+
+	sealedValue, err := u.SealEnvSecret(dataKey, activeKey.Version, envMapKey, key, value)
+	if err != nil {
+		return e.EnvData[[]byte]{}, err
+	}
+	return e.EnvData[[]byte]{Value: sealedValue, StatusInfo: statusInfo, Kind: env.EnvValueKindSecret}, nil
+}
+
+/*
+- secret value status kontrolünden sonra bellekte açılır ve T türüne çevrilir. Plaintext ve data key kullanıldıktan sonra temizlenir.
+- açılan values cache edilmez, her erişimde key ring doğrulanır.
+*/
+func GetEnvSecretValue[T any](opener a.ISealedKeyOpener, envMapKey string, key string) (T, error) {
+	var value T
+	envData, err := env.GetEnv[string, e.EnvData[[]byte]](envMapKey, key)
+	if err != nil {
+		return value, err
+	}
+	if err := u.CheckDataStatusInfos(&e.CheckDataStatusInfosInput{
+		Status:      envData.StatusInfo.Status,
+		ActiveAt:    envData.StatusInfo.ActiveAt,
+		ExpiresAt:   envData.StatusInfo.ExpiresAt,
+		Description: envData.StatusInfo.Description,
+	}); err != nil {
+		return value, env.GetFuncError(env.InvalidMapValue, err, key)
+	}
+
+	secret, err := u.DecodeEnvSecret(key, envData)
+	if err != nil {
+		return value, err
+	}
+	keyRing, _, err := GetEnvSecretKeyRing()
+	if err != nil {
+		return value, err
+	}
+	dataKey, err := openEnvSecretDataKey(keyRing, secret.KeyVersion, opener)
+	if err != nil {
+		return value, err
+	}
+	defer clear(dataKey)
+
+	plaintext, err := u.OpenEnvSecret(dataKey, envMapKey, key, secret)
+	if err != nil {
+		return value, err
+	}
+	defer clear(plaintext)
+
+	if err := cbor.Unmarshal(plaintext, &value); err != nil {
+		return value, env.GetFuncError(env.InvalidMapValue, err, key)
+	}
+	return value, nil
+}
@@ -0,0 +1,267 @@
+package config
+
+import (
+	"maps"
+	"slices"
+	"time"
+	a "web_server/domain/abstractions"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+	u "web_server/utils"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// store bundle içerisindeki env map datası field üzerinden kayıtlı türe çevrilir ve transaction üzerine eklenir.
+type envStoreMapType struct {
+	statusInfos func(data any) (e.StatusData, bool)
+	redact      func(data any) (any, error) //nil ise env map olduğu gibi dışa aktarılır.
+	decode      func(encoded []byte) (any, error)
+	stage       func(tx *env.EnvTx, envMapKey string, data any)
+}
+
+func newEnvStoreMapType[V any]() envStoreMapType {
+	return envStoreMapType{
+		statusInfos: func(data any) (e.StatusData, bool) {
+			envMap, ok := data.(e.EnvMapData[string, V])
+			return envMap.StatusInfos, ok
+		},
+		decode: func(encoded []byte) (any, error) {
+			envMap := e.EnvMapData[string, V]{}
+			if err := cbor.Unmarshal(encoded, &envMap); err != nil {
+				return nil, err
+			}
+			return envMap, nil
+		},
+		stage: func(tx *env.EnvTx, envMapKey string, data any) {
+			env.TxSetNewEnvMap(tx, envMapKey, data.(e.EnvMapData[string, V]))
+		},
+	}
+}
+
+// EnvData[[]byte] env maps içerisindeki secret values dışa aktarılırken redact edilir.
+func newEnvDataStoreMapType() envStoreMapType {
+	mapType := newEnvStoreMapType[e.EnvData[[]byte]]()
+	mapType.redact = func(data any) (any, error) {
+		return u.RedactEnvSecrets(data.(e.EnvMapData[string, e.EnvData[[]byte]]))
+	}
+	return mapType
+}
+
+// store bundle type registry. Dışa aktarılabilen env maps bu registry üzerinde tanımlı olmak zorundadır.
+var envStoreMapTypes = newEnvStoreMapTypes()
+
+// external env maps ve layered env maps için base env map, keys kaynakları ve references da registry üzerine eklenir.
+func newEnvStoreMapTypes() map[string]envStoreMapType {
+	mapTypes := map[string]envStoreMapType{
+		env.EnvSchemaEnvMapField:        newEnvDataStoreMapType(),
+		env.MainEnvMapField:             newEnvDataStoreMapType(),
+		env.KafkaTopicPolicyEnvMapField: newEnvDataStoreMapType(),
+		env.FuncErrorEnvMapField:        newEnvDataStoreMapType(),
+		env.WhitelistEnvMapField:        newEnvStoreMapType[e.WhitelistOwnerData](),
+	}
+	for _, field := range env.ExternalEnvMapFieldSlice {
+		mapTypes[field] = newEnvDataStoreMapType()
+		mapTypes[env.EnvRefKey(field)] = newEnvDataStoreMapType()
+	}
+	for _, field := range env.LayeredEnvMapFieldSlice {
+		mapTypes[env.EnvLayerBaseKey(field)] = newEnvDataStoreMapType()
+		mapTypes[env.EnvLayerSourcesKey(field)] = newEnvStoreMapType[e.EnvLayerSourceData]()
+		mapTypes[env.EnvRefKey(field)] = newEnvDataStoreMapType()
+	}
+	return mapTypes
+}
+
+/*
+- çalışan instance üzerinde yüklü olan bütün env maps ve pub keys tek bir store snapshot üzerinden okunur.
+- env maps canonical cbor formatında revision ve status bilgisi ile bundle içerisine eklenir. Secret values redact edilir.
+- bundle cid bilgisi eklenir ve system signer ile imzalanır. Signer yüklü system pub key ile eşleşmek zorundadır.
+*/
+func ExportEnvStore(signer a.ISigner) (e.EnvStoreBundleData, error) {
+	if signer == nil {
+		return e.EnvStoreBundleData{}, env.GetFuncError(env.SignerNotConfigured, nil, "env store bundle signer")
+	}
+
+	view := env.LoadEnvView()
+	sysPubKeyData, err := view.PubKey(env.SystemKey)
+	if err != nil {
+		return e.EnvStoreBundleData{}, err
+	}
+	if !u.ComparisonHash(sysPubKeyData.PubKey, signer.IFPubKey()) {
+		return e.EnvStoreBundleData{}, env.GetFuncError(env.InvalidEnvStoreBundle, nil, "signer does not match system pub key")
+	}
+
+	now := time.Now().Unix()
+	storeInfos := e.EnvStoreData{
+		EnvMapInfos: map[string]e.EnvStoreMapData{},
+		PubKeyInfos: map[string]e.PubKeyData{},
+		StatusInfos: e.StatusData{
+			Status:      true,
+			CreatedAt:   now,
+			ActiveAt:    now,
+			UpdatedAt:   now,
+			Description: env.EnvStoreBundleField,
+		},
+	}
+
+	for _, envMapKey := range view.EnvMapKeys() {
+		data, revision, _ := view.EnvMapRaw(envMapKey)
+		mapType, ok := envStoreMapTypes[envMapKey]
+		if !ok {
+			return e.EnvStoreBundleData{}, env.GetFuncError(env.InvalidEnvStoreBundle, nil, "unregistered env map field "+envMapKey)
+		}
+		statusInfos, ok := mapType.statusInfos(data)
+		if !ok {
+			return e.EnvStoreBundleData{}, env.GetFuncError(env.EnvMapTypeMismatch, nil)
+		}
+
+		//secret values bundle içerisine sadece key version bilgisi ile eklenir.
+		if mapType.redact != nil {
+			if data, err = mapType.redact(data); err != nil {
+				return e.EnvStoreBundleData{}, err
+			}
+		}
+
+		encodedEnvMap, err := u.MarshalCanonicalCBOR(data)
+		if err != nil {
+			return e.EnvStoreBundleData{}, err
+		}
+		storeInfos.EnvMapInfos[envMapKey] = e.EnvStoreMapData{
+			EnvMapInfo:  encodedEnvMap,
+			Revision:    revision,
+			StatusInfos: statusInfos,
+		}
+	}
+
+	for _, ownerKey := range view.PubKeyKeys() {
+		pubKeyData, err := view.PubKey(ownerKey)
+		if err != nil {
+			return e.EnvStoreBundleData{}, err
+		}
+		storeInfos.PubKeyInfos[ownerKey] = *pubKeyData
+	}
+
+	encodedStore, err := u.MarshalCanonicalCBOR(storeInfos)
+	if err != nil {
+		return e.EnvStoreBundleData{}, err
+	}
+	storeCID, err := u.DatatoCIDv1Byte(encodedStore)
+	if err != nil {
+		return e.EnvStoreBundleData{}, err
+	}
+	signatureInfos, err := signer.IFSign(encodedStore)
+	if err != nil {
+		return e.EnvStoreBundleData{}, err
+	}
+
+	return e.EnvStoreBundleData{
+		StoreInfos:     storeInfos,
+		CID:            storeCID,
+		SignatureInfos: signatureInfos,
+	}, nil
+}
+
+/*
+- bundle içerisindeki system pub key güvenilir kaynak ile doğrulanır. Kaynak sırası: trustedPubKey, store üzerindeki system pub key, trust kaydı.
+- güvenilir kaynak yoksa bundle yüklenmez. Bundle cid ve imzası doğrulanan system pub key ile kontrol edilir.
+- env maps type registry üzerinden çözülür ve pub keys ile birlikte tek transaction ile boş store üzerine yüklenir.
+- store üzerinde yüklü env map ya da pub key varsa bundle yüklenmez. Revisions yüklenirken yeniden verilir.
+- redact edilmiş secret values yüklenir fakat açılamaz, secrets imzalı env map files üzerinden yüklenmelidir.
+*/
+func ImportEnvStore(bundle e.EnvStoreBundleData, trustedPubKey *e.PubKeyData) error {
+	storeInfos := bundle.StoreInfos
+	sysPubKeyData, ok := storeInfos.PubKeyInfos[env.SystemKey]
+	if !ok {
+		return env.GetFuncError(env.InvalidEnvStoreBundle, nil, "system pub key missing")
+	}
+	if err := checkTrustedSystemPubKey(&sysPubKeyData, trustedPubKey); err != nil {
+		return err
+	}
+
+	encodedStore, err := u.MarshalCanonicalCBOR(storeInfos)
+	if err != nil {
+		return err
+	}
+	storeCID, err := u.DatatoCIDv1Byte(encodedStore)
+	if err != nil {
+		return err
+	}
+	if !u.ComparisonHash(bundle.CID, storeCID) {
+		return env.GetFuncError(env.CIDMismatch, nil)
+	}
+
+	if err := u.VerifySignED25519(e.VerifySignED25519Input{
+		PublicKey: sysPubKeyData.PubKey,
+		Signed:    bundle.SignatureInfos.Signature,
+		Data:      encodedStore,
+	}); err != nil {
+		return err
+	}
+
+	tx := env.NewEnvTx()
+	tx.RequireEmptyStore()
+	for _, ownerKey := range slices.Sorted(maps.Keys(storeInfos.PubKeyInfos)) {
+		tx.SetNewPubKey(ownerKey, storeInfos.PubKeyInfos[ownerKey])
+	}
+
+	var catalogs map[string]e.FuncMessageCatalogData
+	for _, envMapKey := range slices.Sorted(maps.Keys(storeInfos.EnvMapInfos)) {
+		mapType, ok := envStoreMapTypes[envMapKey]
+		if !ok {
+			return env.GetFuncError(env.InvalidEnvStoreBundle, nil, "unregistered env map field "+envMapKey)
+		}
+		data, err := mapType.decode(storeInfos.EnvMapInfos[envMapKey].EnvMapInfo)
+		if err != nil {
+			return env.GetFuncError(env.InvalidEnvStoreBundle, err, envMapKey)
+		}
+
+		//func error env yüklenirken message catalogs da aktif edilir, catalogs commit öncesi doğrulanır.
+		if envMapKey == env.FuncErrorEnvMapField {
+			catalogs, err = decodeFuncMessageCatalogs(data.(e.EnvMapData[string, e.EnvData[[]byte]]))
+			if err != nil {
+				return err
+			}
+		}
+		mapType.stage(tx, envMapKey, data)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	if catalogs != nil {
+		return env.SetFuncMessageCatalogs(catalogs)
+	}
+	return nil
+}
+
+// store bundle main store üzerine yazılır ve bundle cid döner.
+func ExportEnvStoreFile(signer a.ISigner) ([]byte, error) {
+	bundle, err := ExportEnvStore(signer)
+	if err != nil {
+		return nil, err
+	}
+
+	var bundleEng *FileEngine[e.EnvStoreBundleData] = &FileEngine[e.EnvStoreBundleData]{Owner: env.System}
+	if err := bundleEng.IFPut(e.PutInput[e.EnvStoreBundleData]{
+		PathKey:    env.MainPathEnvsPathKey,
+		PathFields: []string{env.EnvStoreBundleField},
+		Data:       &bundle,
+	}); err != nil {
+		return nil, err
+	}
+	return bundle.CID, nil
+}
+
+// main store üzerindeki store bundle okunur ve boş store üzerine yüklenir. trustedPubKey nil ise store üzerindeki trust bilgisi kullanılır.
+func ImportEnvStoreFile(trustedPubKey *e.PubKeyData) error {
+	var bundleEng *FileEngine[e.EnvStoreBundleData] = &FileEngine[e.EnvStoreBundleData]{Owner: env.System}
+	bundle := &e.EnvStoreBundleData{}
+	if err := bundleEng.IFGet(e.GetInput[e.EnvStoreBundleData]{
+		PathKey:    env.MainPathEnvsPathKey,
+		PathFields: []string{env.EnvStoreBundleField},
+		Data:       bundle,
+	}); err != nil {
+		return err
+	}
+	return ImportEnvStore(*bundle, trustedPubKey)
+}
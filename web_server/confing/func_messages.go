@@ -0,0 +1,79 @@
+package config
+
+import (
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+	u "web_server/utils"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+/*
+- func error env file varsa system pub key ile doğrulanır ve locale message catalogs aktif edilir.
+- file yoksa mesajlar varsayılan formats ile üretilir.
+- FuncErrorEnvKeyRefSlice içerisindeki locales env map üzerinde bulunmak zorundadır.
+*/
+func incFuncErrorEnv(sysPubKeyData *e.PubKeyData) error {
+	exists, err := fileExists(env.MainPathEnvsPathKey, env.FuncErrorEnvMapField)
+	if err != nil || !exists {
+		return err
+	}
+
+	var funcErrorEnvEng *FileEngine[e.EnvFileData[string, e.EnvData[[]byte]]] = &FileEngine[e.EnvFileData[string, e.EnvData[[]byte]]]{Owner: env.System}
+	funcErrorEnvFileData := &e.EnvFileData[string, e.EnvData[[]byte]]{}
+	if err := funcErrorEnvEng.IFGet(e.GetInput[e.EnvFileData[string, e.EnvData[[]byte]]]{
+		PathKey:    env.MainPathEnvsPathKey,
+		PathFields: []string{env.FuncErrorEnvMapField},
+		Data:       funcErrorEnvFileData,
+	}); err != nil {
+		return err
+	}
+
+	if err := checkMainEnv(sysPubKeyData, funcErrorEnvFileData); err != nil {
+		return err
+	}
+
+	//message catalogs overlay uygulanmış effective env map üzerinden çözülür.
+	layers, err := resolveEnvMapLayers(sysPubKeyData, env.FuncErrorEnvMapField, funcErrorEnvFileData.EnvMapInfos)
+	if err != nil {
+		return err
+	}
+
+	catalogs, err := decodeFuncMessageCatalogs(layers.effective)
+	if err != nil {
+		return err
+	}
+
+	if err := commitEnvMapLayers(env.FuncErrorEnvMapField, layers, true); err != nil {
+		return err
+	}
+	return env.SetFuncMessageCatalogs(catalogs)
+}
+
+// env map içerisindeki aktif locale values message catalog olarak çözülür. Pasif locales yüklenmez.
+func decodeFuncMessageCatalogs(funcErrorEnvMap e.EnvMapData[string, e.EnvData[[]byte]]) (map[string]e.FuncMessageCatalogData, error) {
+	for _, locale := range env.FuncErrorEnvKeyRefSlice {
+		if _, ok := funcErrorEnvMap.EnvInfos[locale]; !ok {
+			return nil, env.GetFuncError(env.InvalidEnvMapKeySlice, nil, env.FuncErrorEnvTag)
+		}
+	}
+
+	catalogs := map[string]e.FuncMessageCatalogData{}
+	for locale, envData := range funcErrorEnvMap.EnvInfos {
+		if err := u.CheckDataStatusInfos(&e.CheckDataStatusInfosInput{
+			Status:      envData.StatusInfo.Status,
+			ActiveAt:    envData.StatusInfo.ActiveAt,
+			ExpiresAt:   envData.StatusInfo.ExpiresAt,
+			Description: envData.StatusInfo.Description,
+		}); err != nil {
+			continue
+		}
+
+		catalog := e.FuncMessageCatalogData{}
+		if err := cbor.Unmarshal(envData.Value, &catalog); err != nil {
+			return nil, env.GetFuncError(env.InvalidMapValue, err, locale)
+		}
+		catalogs[locale] = catalog
+	}
+	return catalogs, nil
+}
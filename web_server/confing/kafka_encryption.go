@@ -0,0 +1,250 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"slices"
+	"time"
+	a "web_server/domain/abstractions"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+	kafka "web_server/infrastructure/kafka"
+	u "web_server/utils"
+)
+
+// main env üzerinde şifreli olarak tanımlanan topics için true döner. Key tanımlı değilse hiçbir topic şifrelenmez.
+func IsKafkaTopicEncrypted(topic string) (bool, error) {
+	encryptedTopics, err := getMainEnvValueOr[[]string](env.KafkaEncryptedTopics, nil)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(encryptedTopics, topic), nil
+}
+
+// topic key ring file path bilgisi
+func topicKeyRingFilePath(topic string) (string, error) {
+	keyPath, err := getMainEnvValue[string](env.KafkaTopicKeyPath)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(keyPath, topic+env.Cbor), nil
+}
+
+/*
+- store üzerindeki topic key ring getirilir ve system pub key ile doğrulanır. Key ring yoksa false döner.
+- imzası doğrulanamayan ya da başka bir topic için üretilmiş key ring kullanılmaz.
+*/
+func GetKafkaTopicKeyRing(topic string) (e.KafkaTopicKeyRingInfoData, bool, error) {
+	keyRingFilePath, err := topicKeyRingFilePath(topic)
+	if err != nil {
+		return e.KafkaTopicKeyRingInfoData{}, false, err
+	}
+
+	exists, err := fileExists(env.SpecificPathKey, keyRingFilePath)
+	if err != nil || !exists {
+		return e.KafkaTopicKeyRingInfoData{}, false, err
+	}
+
+	var keyRingEng *FileEngine[e.KafkaTopicKeyRingData] = &FileEngine[e.KafkaTopicKeyRingData]{Owner: env.System}
+	keyRing := e.KafkaTopicKeyRingData{}
+	if err := keyRingEng.IFGet(e.GetInput[e.KafkaTopicKeyRingData]{
+		PathKey:    env.SpecificPathKey,
+		PathFields: []string{keyRingFilePath},
+		Data:       &keyRing,
+	}); err != nil {
+		return e.KafkaTopicKeyRingInfoData{}, false, err
+	}
+
+	sysPubKeyData, err := env.GetPubKey(env.SystemKey)
+	if err != nil {
+		return e.KafkaTopicKeyRingInfoData{}, false, err
+	}
+	if err := kafka.VerifyTopicKeyRing(sysPubKeyData.PubKey, keyRing); err != nil {
+		return e.KafkaTopicKeyRingInfoData{}, false, err
+	}
+	if keyRing.KeyRingInfos.Topic != topic {
+		return e.KafkaTopicKeyRingInfoData{}, false, env.GetFuncError(env.InvalidKafkaEncryptedRecord, errors.New("key ring topic mismatch"))
+	}
+	return keyRing.KeyRingInfos, true, nil
+}
+
+/*
+- topic data key alıcıları: system ve topic üzerinde Read yetkisine sahip aktif whitelist owner'ları.
+- owner x25519 key main env üzerinde kayıtlı ise kayıtlı key, değilse owner ed25519 pub key bilgisinden türetilen key kullanılır.
+- system alıcısı rest proxy üzerinde records şifrelenebilmesi için eklenir.
+- owner datasına özgü olmayan hatalarda alıcılar daraltılıp topic key döndürülmesin diye hata döner.
+*/
+func getKafkaTopicKeyRecipients(topic string, opener a.ISealedKeyOpener) (map[string][]byte, error) {
+	whitelist, err := env.GetEnvMap[string, e.WhitelistOwnerData](env.WhitelistEnvMapField)
+	if err != nil {
+		return nil, err
+	}
+
+	registeredKeys, err := getMainEnvValueOr[map[string][]byte](env.KafkaOwnerX25519PubKeys, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	recipients := map[string][]byte{env.System: opener.IFX25519PubKey()}
+	for whitelistKey, whitelistOwnerData := range whitelist.EnvInfos {
+		if err := u.CheckDataStatusInfos(&e.CheckDataStatusInfosInput{
+			Status:      whitelistOwnerData.StatusInfos.Status,
+			ActiveAt:    whitelistOwnerData.StatusInfos.ActiveAt,
+			ExpiresAt:   whitelistOwnerData.StatusInfos.ExpiresAt,
+			Description: whitelistOwnerData.StatusInfos.Description,
+		}); err != nil {
+			continue
+		}
+
+		accessData, err := getOwnerAccessData(whitelistOwnerData)
+		if err != nil {
+			if isOwnerRejection(err) {
+				continue
+			}
+			return nil, err
+		}
+		if err := kafka.CheckTopicPerm(accessData.AuthnInfos.PermInfos, topic, env.Read); err != nil {
+			continue
+		}
+
+		if registeredKey, ok := registeredKeys[whitelistKey]; ok {
+			recipients[whitelistKey] = registeredKey
+			continue
+		}
+		ownerPubKeyData, err := getPubKey(env.SpecificPathKey, whitelistOwnerData.PubKeyDataURI)
+		if err != nil {
+			if isOwnerRejection(err) {
+				continue
+			}
+			return nil, err
+		}
+		if recipients[whitelistKey], err = u.Ed25519PubKeyToX25519(ownerPubKeyData.PubKey); err != nil {
+			return nil, err
+		}
+	}
+	return recipients, nil
+}
+
+/*
+- topic için yeni data key version üretilir ve güncel alıcılara mühürlenir. Önceki versions eski records okunabilsin diye saklanır.
+- key ring system signer ile imzalanarak store üzerine yazılır. Sonraki records yeni key ile şifrelenir.
+*/
+func RotateKafkaTopicKey(topic string, signer a.ISigner, opener a.ISealedKeyOpener) (e.KafkaTopicKeyData, error) {
+	keyRing, _, err := GetKafkaTopicKeyRing(topic)
+	if err != nil {
+		return e.KafkaTopicKeyData{}, err
+	}
+	keyRing.Topic = topic
+
+	recipients, err := getKafkaTopicKeyRecipients(topic, opener)
+	if err != nil {
+		return e.KafkaTopicKeyData{}, err
+	}
+
+	version := uint32(1)
+	if activeKey, ok := kafka.ActiveTopicKey(keyRing); ok {
+		version = activeKey.Version + 1
+	}
+	dataKey, topicKey, err := kafka.NewTopicKey(version, recipients)
+	if err != nil {
+		return e.KafkaTopicKeyData{}, err
+	}
+	//plaintext data key bellekte tutulmaz, proxy aktif key'i system alıcısı üzerinden açar.
+	for i := range dataKey {
+		dataKey[i] = 0
+	}
+	keyRing.KeyInfos = append(keyRing.KeyInfos, topicKey)
+
+	signedKeyRing, err := kafka.SignTopicKeyRing(signer, keyRing)
+	if err != nil {
+		return e.KafkaTopicKeyData{}, err
+	}
+
+	keyRingFilePath, err := topicKeyRingFilePath(topic)
+	if err != nil {
+		return e.KafkaTopicKeyData{}, err
+	}
+	var keyRingEng *FileEngine[e.KafkaTopicKeyRingData] = &FileEngine[e.KafkaTopicKeyRingData]{Owner: env.System}
+	if err := keyRingEng.IFPut(e.PutInput[e.KafkaTopicKeyRingData]{
+		PathKey:    env.SpecificPathKey,
+		PathFields: []string{keyRingFilePath},
+		Data:       &signedKeyRing,
+	}); err != nil {
+		return e.KafkaTopicKeyData{}, err
+	}
+	return topicKey, nil
+}
+
+// aktif key alıcıları ve alıcı keys güncel alıcılar ile aynı mı kontrol edilir.
+func sameTopicKeyRecipients(topicKey e.KafkaTopicKeyData, recipients map[string][]byte) bool {
+	if len(topicKey.SealedKeyInfos) != len(recipients) {
+		return false
+	}
+	for _, sealedKey := range topicKey.SealedKeyInfos {
+		if recipientKey, ok := recipients[sealedKey.OwnerKey]; !ok || !bytes.Equal(recipientKey, sealedKey.X25519PubKey) {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+- şifreli topics için key ring yoksa oluşturulur.
+- aktif key rotation süresini doldurduysa ya da topic okuma yetkisi olan owner'lar değiştiyse key döndürülür.
+- yetkisi kaldırılan owner yeni key ile şifrelenen records okuyamaz. Döndürülen topics listesi döner.
+*/
+func SyncKafkaTopicKeys(signer a.ISigner, opener a.ISealedKeyOpener) ([]string, error) {
+	encryptedTopics, err := getMainEnvValueOr[[]string](env.KafkaEncryptedTopics, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rotationSeconds, err := getMainEnvValueOr[int64](env.KafkaTopicKeyRotationSeconds, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var rotated []string
+	now := time.Now().Unix()
+	for _, topic := range encryptedTopics {
+		keyRing, _, err := GetKafkaTopicKeyRing(topic)
+		if err != nil {
+			return rotated, err
+		}
+		recipients, err := getKafkaTopicKeyRecipients(topic, opener)
+		if err != nil {
+			return rotated, err
+		}
+
+		activeKey, ok := kafka.ActiveTopicKey(keyRing)
+		if ok && sameTopicKeyRecipients(activeKey, recipients) &&
+			(rotationSeconds <= 0 || now-activeKey.StatusInfo.CreatedAt < rotationSeconds) {
+			continue
+		}
+
+		if _, err := RotateKafkaTopicKey(topic, signer, opener); err != nil {
+			return rotated, err
+		}
+		rotated = append(rotated, topic)
+	}
+	return rotated, nil
+}
+
+// rest proxy records şifrelerken aktif key version ve system alıcısına mühürlenmiş data key açılır.
+func GetKafkaTopicDataKey(topic string, opener a.ISealedKeyOpener) (uint32, []byte, error) {
+	keyRing, exists, err := GetKafkaTopicKeyRing(topic)
+	if err != nil {
+		return 0, nil, err
+	}
+	activeKey, ok := kafka.ActiveTopicKey(keyRing)
+	if !exists || !ok {
+		return 0, nil, env.GetFuncError(env.KafkaTopicKeyNotFound, nil, topic)
+	}
+
+	dataKey, err := kafka.OpenTopicDataKey(keyRing, activeKey.Version, env.System, opener)
+	if err != nil {
+		return 0, nil, err
+	}
+	return activeKey.Version, dataKey, nil
+}
@@ -0,0 +1,24 @@
+package config
+
+import (
+	a "web_server/domain/abstractions"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+	prom "web_server/infrastructure/prometheus"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// main env üzerinde metrics hedefleri tanımlı değilse boş hedefler döner, bu durumda sadece scrape durumu raporlanır.
+func GetKafkaMetricsTargets() (e.KafkaMetricsTargetData, error) {
+	return getMainEnvValueOr(env.KafkaMetricsTargets, e.KafkaMetricsTargetData{})
+}
+
+// lag collector main env hedefleri ile oluşturulur ve varsayılan prometheus registry üzerine kaydedilir. Metrics mevcut /metrics path üzerinden sunulur.
+func RegisterKafkaLagCollector(admin a.IKafkaMetricsAdmin) (*prom.KafkaLagCollector, error) {
+	collector := prom.NewKafkaLagCollector(admin, GetKafkaMetricsTargets)
+	if err := prometheus.Register(collector); err != nil {
+		return nil, env.GetFuncError(env.KafkaOperationFailed, err, "register lag collector")
+	}
+	return collector, nil
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	a "web_server/domain/abstractions"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+	kafka "web_server/infrastructure/kafka"
+	u "web_server/utils"
+)
+
+// main env içerisinde belirtilen key file ile sistem tarafından üretilen files imzalayacak signer oluşturulur.
+func NewSystemSigner() (*u.Ed25519Signer, error) {
+	keyFilePath, err := getMainEnvValue[string](env.SystemSignerKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	return u.NewEd25519SignerFromFile(keyFilePath, env.System)
+}
+
+// mevcut server.properties file okunur ve cluster broker sayısına göre doğrulanır.
+func CheckKafkaServerPropertiesFile(filePath string, brokerCount int) (e.KafkaServerPropertiesData, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return e.KafkaServerPropertiesData{}, env.GetFuncError(env.FileNotFound, nil, filePath)
+	}
+
+	props, err := kafka.ParseServerProperties(data)
+	if err != nil {
+		return props, err
+	}
+
+	return props, kafka.ValidateServerProperties(e.ValidateKafkaServerPropertiesInput{
+		PropertiesInfo: props,
+		BrokerCount:    brokerCount,
+	})
+}
+
+/*
+- main env üzerindeki ortak ve broker'a özel properties ile her broker için server.properties üretilir.
+- bütün broker'lar doğrulanmadan hiçbir file yazılmaz.
+- files <kafka-server-properties-path>/broker<broker_id>/server.properties olarak imzası ile birlikte yazılır.
+*/
+func RenderKafkaServerProperties(signer a.ISigner) ([]string, error) {
+	commonInfos, err := getMainEnvValue[map[string]string](env.KafkaServerProperties)
+	if err != nil {
+		return nil, err
+	}
+
+	brokerInfos, err := getMainEnvValue[map[int32]map[string]string](env.KafkaBrokerServerProperties)
+	if err != nil {
+		return nil, err
+	}
+
+	outputPath, err := getMainEnvValue[string](env.KafkaServerPropertiesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	brokerIDs := make([]int32, 0, len(brokerInfos))
+	for brokerID := range brokerInfos {
+		brokerIDs = append(brokerIDs, brokerID)
+	}
+	sort.Slice(brokerIDs, func(i, j int) bool { return brokerIDs[i] < brokerIDs[j] })
+
+	brokerProps := make([]e.KafkaServerPropertiesData, 0, len(brokerIDs))
+	for _, brokerID := range brokerIDs {
+		props, err := kafka.BuildServerProperties(e.BuildKafkaServerPropertiesInput{
+			BrokerID:    brokerID,
+			CommonInfos: commonInfos,
+			BrokerInfos: brokerInfos[brokerID],
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if err := kafka.ValidateServerProperties(e.ValidateKafkaServerPropertiesInput{
+			PropertiesInfo: props,
+			BrokerCount:    len(brokerIDs),
+		}); err != nil {
+			return nil, err
+		}
+		brokerProps = append(brokerProps, props)
+	}
+
+	var fileEng *FileEngine[struct{}] = &FileEngine[struct{}]{Owner: env.System}
+	writtenFiles := make([]string, 0, len(brokerProps))
+	for _, props := range brokerProps {
+		brokerDir := filepath.Join(outputPath, env.KafkaBrokerDirPrefix+strconv.FormatInt(int64(props.BrokerID), 10))
+		filePath, err := fileEng.IFGetRootFilePath(env.SpecificPathKey, filepath.Join(brokerDir, env.KafkaServerPropertiesFile))
+		if err != nil {
+			return writtenFiles, err
+		}
+
+		if err := os.MkdirAll(filepath.Dir(filePath), 0o750); err != nil {
+			return writtenFiles, env.GetFuncError(env.UnexpectedError, err)
+		}
+
+		if err := kafka.WriteSignedServerProperties(filePath, props, signer); err != nil {
+			return writtenFiles, err
+		}
+		writtenFiles = append(writtenFiles, filePath)
+	}
+	return writtenFiles, nil
+}
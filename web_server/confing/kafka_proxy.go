@@ -0,0 +1,38 @@
+package config
+
+import (
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+	kafka "web_server/infrastructure/kafka"
+)
+
+// main env içerisindeki seed broker bilgileri ile rest proxy client oluşturulur.
+func NewKafkaProxyClient() (*kafka.KafkaProxyClient, error) {
+	seedBrokers, err := getMainEnvValue[[]string](env.KafkaSeedBrokers)
+	if err != nil {
+		return nil, err
+	}
+	return kafka.NewKafkaProxyClient(seedBrokers)
+}
+
+/*
+- owner için tanımlı quota varsa owner quota, yoksa main env varsayılan quota döner.
+- main env üzerinde quota tanımlı değilse sistem varsayılan limitleri kullanılır.
+*/
+func GetKafkaProxyQuota(ownerKey string) (e.KafkaProxyQuotaData, error) {
+	ownerQuotas, err := getMainEnvValueOr[map[string]e.KafkaProxyQuotaData](env.KafkaProxyOwnerQuotas, nil)
+	if err != nil {
+		return e.KafkaProxyQuotaData{}, err
+	}
+	if quota, ok := ownerQuotas[ownerKey]; ok {
+		return quota, nil
+	}
+
+	return getMainEnvValueOr(env.KafkaProxyQuota, e.KafkaProxyQuotaData{
+		MaxBatchRecords:    env.KafkaProxyDefaultMaxBatchRecords,
+		MaxBatchBytes:      env.KafkaProxyDefaultMaxBatchBytes,
+		ProduceBytesPerSec: env.KafkaProxyDefaultProduceBytesPerSec,
+		MaxConsumers:       env.KafkaProxyDefaultMaxConsumers,
+		MaxPollRecords:     env.KafkaProxyDefaultMaxPollRecords,
+	})
+}
@@ -0,0 +1,323 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+	a "web_server/domain/abstractions"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+	kafka "web_server/infrastructure/kafka"
+	u "web_server/utils"
+)
+
+// main env içerisindeki seed broker bilgileri ile kafka admin client oluşturulur.
+func NewKafkaAdmin() (*kafka.KafkaAdmin, error) {
+	seedBrokers, err := getMainEnvValue[[]string](env.KafkaSeedBrokers)
+	if err != nil {
+		return nil, err
+	}
+	return kafka.NewKafkaAdmin(seedBrokers)
+}
+
+/*
+- whitelist üzerindeki aktif owner'ların doğrulanmış AuthnData bilgileri getirilir.
+- status bilgisi geçersiz ya da access datası doğrulanamayan owner dahil edilmez, bu owner'lara ait yetkiler broker üzerinden kaldırılır.
+- owner datasına özgü olmayan hatalarda bütün owner'lar kaldırılmasın diye hata döner.
+*/
+func getActiveOwnerAuthnInfos() (map[string]e.AuthnData, error) {
+	whitelist, err := env.GetEnvMap[string, e.WhitelistOwnerData](env.WhitelistEnvMapField)
+	if err != nil {
+		return nil, err
+	}
+
+	ownerAuthnInfos := make(map[string]e.AuthnData, len(whitelist.EnvInfos))
+	for whitelistKey, whitelistOwnerData := range whitelist.EnvInfos {
+		if err := u.CheckDataStatusInfos(&e.CheckDataStatusInfosInput{
+			Status:      whitelistOwnerData.StatusInfos.Status,
+			ActiveAt:    whitelistOwnerData.StatusInfos.ActiveAt,
+			ExpiresAt:   whitelistOwnerData.StatusInfos.ExpiresAt,
+			Description: whitelistOwnerData.StatusInfos.Description,
+		}); err != nil {
+			continue
+		}
+
+		accessData, err := getOwnerAccessData(whitelistOwnerData)
+		if err != nil {
+			if isOwnerRejection(err) {
+				continue
+			}
+			return nil, err
+		}
+		ownerAuthnInfos[whitelistKey] = accessData.AuthnInfos
+	}
+	return ownerAuthnInfos, nil
+}
+
+// owner datasına özgü red hataları. Path, I/O ve permission gibi diğer hatalar bütün owner'ları etkiler.
+var ownerRejectionErrorCodes = []int{
+	env.CIDMismatch,
+	env.InvalidCID,
+	env.InvalidSignatureComponents,
+	env.InvalidSignType,
+	env.InactiveDataStatus,
+	env.DataExpiresAt,
+	env.DataActiveAt,
+	env.RequiredDataStatusDescription,
+}
+
+// hata owner datasına özgü bir red ise true döner. Bu durumda owner atlanır, diğer hatalarda işlem durdurulur.
+func isOwnerRejection(err error) bool {
+	return slices.ContainsFunc(ownerRejectionErrorCodes, func(code int) bool {
+		return env.IsFuncError(err, code)
+	})
+}
+
+// whitelist owner yetkileri kafka acl kayıtlarına eşitlenir. dryRun durumunda sadece plan yazdırılır.
+func SyncKafkaACLs(ctx context.Context, admin a.IKafkaAdmin, dryRun bool) (e.KafkaACLPlanData, error) {
+	ownerAuthnInfos, err := getActiveOwnerAuthnInfos()
+	if err != nil {
+		return e.KafkaACLPlanData{}, err
+	}
+
+	plan, err := kafka.SyncACLs(ctx, admin, e.SyncKafkaACLInput{
+		OwnerAuthnInfos: ownerAuthnInfos,
+		DryRun:          dryRun,
+	})
+	if err != nil {
+		return plan, err
+	}
+
+	if dryRun {
+		fmt.Print(kafka.FormatACLPlan(plan))
+	}
+	return plan, nil
+}
+
+// owner scram credential file path bilgisi
+func scramCredentialFilePath(userName string) (string, error) {
+	credentialPath, err := getMainEnvValue[string](env.KafkaScramCredentialPath)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(credentialPath, userName+env.Cbor), nil
+}
+
+// store üzerindeki owner scram credential getirilir. Credential yoksa false döner.
+func getScramCredential(userName string) (e.KafkaScramCredentialData, bool, error) {
+	credentialFilePath, err := scramCredentialFilePath(userName)
+	if err != nil {
+		return e.KafkaScramCredentialData{}, false, err
+	}
+
+	exists, err := fileExists(env.SpecificPathKey, credentialFilePath)
+	if err != nil || !exists {
+		return e.KafkaScramCredentialData{}, false, err
+	}
+
+	var credentialEng *FileEngine[e.KafkaScramCredentialData] = &FileEngine[e.KafkaScramCredentialData]{Owner: env.System}
+	credential := e.KafkaScramCredentialData{}
+	if err := credentialEng.IFGet(e.GetInput[e.KafkaScramCredentialData]{
+		PathKey:    env.SpecificPathKey,
+		PathFields: []string{credentialFilePath},
+		Data:       &credential,
+	}); err != nil {
+		return e.KafkaScramCredentialData{}, false, err
+	}
+	return credential, true, nil
+}
+
+/*
+- owner için yeni scram parola üretilir, broker üzerine sadece salt ve salted password yazılır.
+- parola owner ed25519 pub key bilgisinden türetilen x25519 key ile mühürlenerek store üzerine yazılır.
+- mühürlenen parola broker güncellenmeden önce yazılır, broker üzerindeki parola her zaman owner tarafından okunabilir.
+*/
+func issueScramCredential(ctx context.Context, admin a.IKafkaAdmin, whitelistOwnerData e.WhitelistOwnerData, userName string, expiresAt int64) error {
+	ownerPubKeyData, err := getPubKey(env.SpecificPathKey, whitelistOwnerData.PubKeyDataURI)
+	if err != nil {
+		return err
+	}
+
+	ownerX25519PubKey, err := u.Ed25519PubKeyToX25519(ownerPubKeyData.PubKey)
+	if err != nil {
+		return err
+	}
+
+	password, secretInfo, err := u.GenerateScramSHA512Credential(env.KafkaScramIterations)
+	if err != nil {
+		return err
+	}
+
+	sealedPassword, err := u.SealToX25519(ownerX25519PubKey, password)
+	//plaintext parola bellekte tutulmaz.
+	for i := range password {
+		password[i] = 0
+	}
+	if err != nil {
+		return err
+	}
+
+	credentialFilePath, err := scramCredentialFilePath(userName)
+	if err != nil {
+		return err
+	}
+	pendingFilePath := credentialFilePath + env.KafkaScramPendingSuffix
+
+	//mühürlenen parola broker güncellenmeden önce pending file üzerine yazılır. Broker güncellenemezse owner eski credential ile devam eder.
+	now := time.Now().Unix()
+	var credentialEng *FileEngine[e.KafkaScramCredentialData] = &FileEngine[e.KafkaScramCredentialData]{Owner: env.System}
+	if err := credentialEng.IFPut(e.PutInput[e.KafkaScramCredentialData]{
+		PathKey:    env.SpecificPathKey,
+		PathFields: []string{pendingFilePath},
+		Data: &e.KafkaScramCredentialData{
+			UserName:       userName,
+			Mechanism:      env.KafkaScramMechanismSHA512,
+			Iterations:     secretInfo.Iterations,
+			SealedPassword: sealedPassword,
+			StatusInfo: e.StatusData{
+				Status:      true,
+				CreatedAt:   now,
+				ActiveAt:    now,
+				ExpiresAt:   expiresAt,
+				UpdatedAt:   now,
+				Description: env.KafkaScramMechanismSHA512,
+			},
+		},
+	}); err != nil {
+		return err
+	}
+
+	if err := admin.IFUpsertScramCredentials(ctx, []e.UpsertKafkaScramInput{{
+		UserName:   userName,
+		Mechanism:  env.KafkaScramMechanismSHA512,
+		SecretInfo: secretInfo,
+	}}); err != nil {
+		return errors.Join(err, credentialEng.IFSecureRemove(env.SpecificPathKey, pendingFilePath))
+	}
+
+	//pending file credential file yerine taşınır. Taşınamazsa broker credential kaldırılır, sonraki sync yeni credential üretir.
+	if err := renameScramCredentialFile(pendingFilePath, credentialFilePath); err != nil {
+		return errors.Join(err, admin.IFDeleteScramCredentials(ctx, []e.KafkaScramUserData{{
+			UserName:  userName,
+			Mechanism: env.KafkaScramMechanismSHA512,
+		}}), credentialEng.IFSecureRemove(env.SpecificPathKey, pendingFilePath))
+	}
+	return nil
+}
+
+// pending credential file aynı dizindeki credential file üzerine atomik olarak taşınır.
+func renameScramCredentialFile(pendingFilePath string, credentialFilePath string) error {
+	var credentialEng *FileEngine[e.KafkaScramCredentialData] = &FileEngine[e.KafkaScramCredentialData]{Owner: env.System}
+	pendingFullPath, err := credentialEng.IFGetRootFilePath(env.SpecificPathKey, pendingFilePath)
+	if err != nil {
+		return err
+	}
+	credentialFullPath, err := credentialEng.IFGetRootFilePath(env.SpecificPathKey, credentialFilePath)
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(pendingFullPath, credentialFullPath); err != nil {
+		return env.GetFuncError(env.UnexpectedError, err)
+	}
+	return nil
+}
+
+// owner scram credential broker ve store üzerinden kaldırılır.
+func revokeScramCredential(ctx context.Context, admin a.IKafkaAdmin, userName string) error {
+	if err := admin.IFDeleteScramCredentials(ctx, []e.KafkaScramUserData{{
+		UserName:  userName,
+		Mechanism: env.KafkaScramMechanismSHA512,
+	}}); err != nil {
+		return err
+	}
+
+	credentialFilePath, err := scramCredentialFilePath(userName)
+	if err != nil {
+		return err
+	}
+
+	exists, err := fileExists(env.SpecificPathKey, credentialFilePath)
+	if err != nil || !exists {
+		return err
+	}
+
+	var credentialEng *FileEngine[e.KafkaScramCredentialData] = &FileEngine[e.KafkaScramCredentialData]{Owner: env.System}
+	return credentialEng.IFSecureRemove(env.SpecificPathKey, credentialFilePath)
+}
+
+/*
+- whitelist owner status bilgisine göre kafka scram credential üretilir, yenilenir ya da kaldırılır.
+- süresi dolan ya da pasif olan owner credential otomatik olarak kaldırılır.
+*/
+func SyncKafkaScramCredentials(ctx context.Context, admin a.IKafkaAdmin) (e.KafkaScramPlanData, error) {
+	whitelist, err := env.GetEnvMap[string, e.WhitelistOwnerData](env.WhitelistEnvMapField)
+	if err != nil {
+		return e.KafkaScramPlanData{}, err
+	}
+
+	//rotation süresi tanımlı değilse varsayılan süre kullanılır, tanımlı değer pozitif olmak zorundadır.
+	rotationSeconds, err := getMainEnvValueOr(env.KafkaScramRotationSeconds, env.KafkaScramDefaultRotation)
+	if err != nil {
+		return e.KafkaScramPlanData{}, err
+	}
+	if rotationSeconds <= 0 {
+		return e.KafkaScramPlanData{}, env.GetFuncError(env.InvalidValue, nil, env.KafkaScramRotationSeconds)
+	}
+
+	brokerUsers, err := admin.IFDescribeScramUsers(ctx)
+	if err != nil {
+		return e.KafkaScramPlanData{}, err
+	}
+
+	planInput := e.PlanKafkaScramInput{
+		CredentialInfos: map[string]e.KafkaScramCredentialData{},
+		BrokerUserInfos: brokerUsers,
+		CheckUnixTime:   time.Now().Unix(),
+	}
+	ownerInfos := map[string]e.WhitelistOwnerData{}
+	for whitelistKey, whitelistOwnerData := range whitelist.EnvInfos {
+		userName := kafka.KafkaUserName(whitelistKey)
+		ownerInfos[userName] = whitelistOwnerData
+
+		statusErr := u.CheckDataStatusInfos(&e.CheckDataStatusInfosInput{
+			Status:      whitelistOwnerData.StatusInfos.Status,
+			ActiveAt:    whitelistOwnerData.StatusInfos.ActiveAt,
+			ExpiresAt:   whitelistOwnerData.StatusInfos.ExpiresAt,
+			Description: whitelistOwnerData.StatusInfos.Description,
+		})
+		planInput.OwnerInfos = append(planInput.OwnerInfos, e.KafkaScramOwnerData{
+			UserName:  userName,
+			Active:    statusErr == nil,
+			ExpiresAt: whitelistOwnerData.StatusInfos.ExpiresAt,
+		})
+
+		credential, exists, err := getScramCredential(userName)
+		if err != nil {
+			return e.KafkaScramPlanData{}, err
+		}
+		if exists {
+			planInput.CredentialInfos[userName] = credential
+		}
+	}
+
+	plan := kafka.PlanScramCredentials(planInput)
+
+	for _, userName := range append(append([]string{}, plan.Issue...), plan.Rotate...) {
+		whitelistOwnerData := ownerInfos[userName]
+		expiresAt := kafka.ScramCredentialExpiresAt(planInput.CheckUnixTime, rotationSeconds, whitelistOwnerData.StatusInfos.ExpiresAt)
+		if err := issueScramCredential(ctx, admin, whitelistOwnerData, userName, expiresAt); err != nil {
+			return plan, err
+		}
+	}
+
+	for _, userName := range plan.Revoke {
+		if err := revokeScramCredential(ctx, admin, userName); err != nil {
+			return plan, err
+		}
+	}
+	return plan, nil
+}
@@ -0,0 +1,134 @@
+package config
+
+import (
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+	"web_server/infrastructure/audit"
+	u "web_server/utils"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+/*
+- owner isteği ile gönderilen WhitelistAccessData doğrulanır, access data getirilir.
+- doğrulama sonrası owner whitelist key ve doğrulanmış AuthnData döner. Task yetkileri AuthnData.PermInfos üzerinden kontrol edilir.
+*/
+func AuthenticateOwner(accessInfos e.WhitelistAccessData) (string, e.AuthnData, error) {
+	//owner tarafından gönderilen access key status bilgisi kontrol edilir.
+	if err := u.CheckDataStatusInfos(&e.CheckDataStatusInfosInput{
+		Status:      accessInfos.AccessKeyInfos.StatusInfos.Status,
+		ActiveAt:    accessInfos.AccessKeyInfos.StatusInfos.ActiveAt,
+		ExpiresAt:   accessInfos.AccessKeyInfos.StatusInfos.ExpiresAt,
+		Description: accessInfos.AccessKeyInfos.StatusInfos.Description,
+	}); err != nil {
+		return "", e.AuthnData{}, err
+	}
+
+	var accessEng *FileEngine[e.AccessData] = &FileEngine[e.AccessData]{Owner: accessInfos.AccessKeyInfos.WhitelistKey}
+	if err := accessEng.IFAccessOperation(accessInfos); err != nil {
+		return "", e.AuthnData{}, err
+	}
+
+	whitelistOwnerData, err := getWhitelistOwnerData(accessInfos.AccessKeyInfos.WhitelistKey)
+	if err != nil {
+		return "", e.AuthnData{}, err
+	}
+
+	accessData, err := getOwnerAccessData(whitelistOwnerData)
+	if err != nil {
+		return "", e.AuthnData{}, err
+	}
+	return accessInfos.AccessKeyInfos.WhitelistKey, accessData.AuthnInfos, nil
+}
+
+/*
+- kafka topic policy env map file mevcut ise okunur. Policy file yoksa topic api varsayılan kafka kuralları ile çalışır.
+- file main env ile aynı formatta ve system tarafından imzalanmış olmak zorundadır.
+*/
+func incKafkaTopicPolicyEnv(sysPubKeyData *e.PubKeyData) error {
+	exists, err := fileExists(env.MainPathEnvsPathKey, env.KafkaTopicPolicyEnvMapField)
+	if err != nil || !exists {
+		return err
+	}
+
+	var policyEnvEng *FileEngine[e.EnvFileData[string, e.EnvData[[]byte]]] = &FileEngine[e.EnvFileData[string, e.EnvData[[]byte]]]{Owner: env.System}
+	policyEnvFileData := &e.EnvFileData[string, e.EnvData[[]byte]]{}
+	if err := policyEnvEng.IFGet(e.GetInput[e.EnvFileData[string, e.EnvData[[]byte]]]{
+		PathKey:    env.MainPathEnvsPathKey,
+		PathFields: []string{env.KafkaTopicPolicyEnvMapField},
+		Data:       policyEnvFileData,
+	}); err != nil {
+		return err
+	}
+
+	if err := checkMainEnv(sysPubKeyData, policyEnvFileData); err != nil {
+		return err
+	}
+
+	layers, err := resolveEnvMapLayers(sysPubKeyData, env.KafkaTopicPolicyEnvMapField, policyEnvFileData.EnvMapInfos)
+	if err != nil {
+		return err
+	}
+	return commitEnvMapLayers(env.KafkaTopicPolicyEnvMapField, layers, true)
+}
+
+// policy env map içerisindeki değer status kontrolünden sonra okunur. Key tanımlı değilse ya da aktif değilse value değişmez.
+func getKafkaTopicPolicyValue[T any](policyEnvMap e.EnvMapData[string, e.EnvData[[]byte]], key string, value *T) error {
+	envData, ok := policyEnvMap.EnvInfos[key]
+	if !ok {
+		return nil
+	}
+
+	if err := u.CheckDataStatusInfos(&e.CheckDataStatusInfosInput{
+		Status:      envData.StatusInfo.Status,
+		ActiveAt:    envData.StatusInfo.ActiveAt,
+		ExpiresAt:   envData.StatusInfo.ExpiresAt,
+		Description: envData.StatusInfo.Description,
+	}); err != nil {
+		return nil
+	}
+
+	if err := cbor.Unmarshal(envData.Value, value); err != nil {
+		return env.GetFuncError(env.InvalidMapValue, err, key)
+	}
+	return nil
+}
+
+/*
+- policy env map yüklenmemiş ise boş policy döner, bu durumda sadece kafka isimlendirme kuralları uygulanır ve configs değiştirilemez.
+- type mismatch gibi diğer hatalarda policy uygulanmadan işlem yapılmasın diye hata döner.
+*/
+func GetKafkaTopicPolicy() (e.KafkaTopicPolicyData, error) {
+	policy := e.KafkaTopicPolicyData{}
+	policyEnvMap, err := env.GetEnvMap[string, e.EnvData[[]byte]](env.KafkaTopicPolicyEnvMapField)
+	if err != nil {
+		if env.IsFuncError(err, env.EnvMapKeyNotFound) {
+			return policy, nil
+		}
+		return policy, err
+	}
+
+	for _, err := range []error{
+		getKafkaTopicPolicyValue(policyEnvMap, env.KafkaTopicNamePattern, &policy.NamePattern),
+		getKafkaTopicPolicyValue(policyEnvMap, env.KafkaTopicReservedPrefixes, &policy.ReservedPrefixes),
+		getKafkaTopicPolicyValue(policyEnvMap, env.KafkaTopicMinPartitions, &policy.MinPartitions),
+		getKafkaTopicPolicyValue(policyEnvMap, env.KafkaTopicMaxPartitions, &policy.MaxPartitions),
+		getKafkaTopicPolicyValue(policyEnvMap, env.KafkaTopicMinReplicationFactor, &policy.MinReplicationFactor),
+		getKafkaTopicPolicyValue(policyEnvMap, env.KafkaTopicMaxReplicationFactor, &policy.MaxReplicationFactor),
+		getKafkaTopicPolicyValue(policyEnvMap, env.KafkaTopicAllowedConfigs, &policy.AllowedConfigs),
+	} {
+		if err != nil {
+			return policy, err
+		}
+	}
+	return policy, nil
+}
+
+// main env içerisinde belirtilen file üzerine yazan audit trail oluşturulur.
+func NewAuditLog() (*audit.FileAuditLog, error) {
+	auditLogPath, err := getMainEnvValue[string](env.AuditLogPath)
+	if err != nil {
+		return nil, err
+	}
+	return audit.NewFileAuditLog(auditLogPath)
+}
@@ -1,12 +1,16 @@
 package config
 
-import "time"
+import "log"
 
 // config sürecinde çalıştırılması gereken funcs toparlandığı base func
 func Run() {
 	go func() {
-		ticker := time.NewTicker(2* time.Hour)
-		InitConfig()
-		log.Fatal(err)
-	} ()
+		if err := InitConfig(); err != nil {
+			log.Fatal(err)
+		}
+		//env maps yüklendikten sonra env distribution topic üzerindeki güncellemeler uygulanır.
+		if err := startEnvDistribution(); err != nil {
+			log.Fatal(err)
+		}
+	}()
 }
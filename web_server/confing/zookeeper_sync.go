@@ -0,0 +1,123 @@
+package config
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	a "web_server/domain/abstractions"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+	zookeeper "web_server/infrastructure/zookeeper"
+)
+
+/*
+- main env üzerindeki ensemble ve static properties ile her üye için zoo.cfg ve dynamic-config.cfg üretilir.
+- files <zookeeper-config-path>/zookeeper<member_id>/ altına imzası ile birlikte yazılır.
+*/
+func RenderZookeeperConfigs(signer a.ISigner) ([]string, error) {
+	ensemble, err := getMainEnvValue[e.ZookeeperEnsembleData](env.ZookeeperEnsemble)
+	if err != nil {
+		return nil, err
+	}
+	if err := zookeeper.ValidateEnsemble(ensemble); err != nil {
+		return nil, err
+	}
+
+	propertyInfos, err := getMainEnvValue[map[string]string](env.ZookeeperConfigProperties)
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicConfigFile, err := getMainEnvValue[string](env.ZookeeperDynamicConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	outputPath, err := getMainEnvValue[string](env.ZookeeperConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	zooConfig, err := zookeeper.RenderZooConfig(e.RenderZooConfigInput{
+		DynamicConfigFile: dynamicConfigFile,
+		PropertyInfos:     propertyInfos,
+	})
+	if err != nil {
+		return nil, err
+	}
+	dynamicConfig := zookeeper.RenderDynamicConfig(ensemble)
+
+	var fileEng *FileEngine[struct{}] = &FileEngine[struct{}]{Owner: env.System}
+	var writtenFiles []string
+	for _, member := range ensemble.MemberInfos {
+		memberDir, err := fileEng.IFGetRootFilePath(env.SpecificPathKey,
+			filepath.Join(outputPath, env.ZookeeperMemberDirPrefix+strconv.FormatInt(int64(member.ID), 10)))
+		if err != nil {
+			return writtenFiles, err
+		}
+
+		if err := os.MkdirAll(memberDir, 0o750); err != nil {
+			return writtenFiles, env.GetFuncError(env.UnexpectedError, err)
+		}
+
+		for _, configFile := range []struct {
+			name string
+			data []byte
+		}{
+			{env.ZookeeperConfigFile, zooConfig},
+			{env.ZookeeperDynamicConfigFile, dynamicConfig},
+		} {
+			filePath := filepath.Join(memberDir, configFile.name)
+			if err := zookeeper.WriteSignedConfig(filePath, configFile.data, signer); err != nil {
+				return writtenFiles, err
+			}
+			writtenFiles = append(writtenFiles, filePath)
+		}
+	}
+	return writtenFiles, nil
+}
+
+// main env üzerindeki ensemble bilgisi çalışan ensemble ile karşılaştırılır.
+func VerifyZookeeperEnsemble(ctx context.Context, dialer a.IZookeeperDialer) (e.ZookeeperVerifyReportData, error) {
+	ensemble, err := getMainEnvValue[e.ZookeeperEnsembleData](env.ZookeeperEnsemble)
+	if err != nil {
+		return e.ZookeeperVerifyReportData{}, err
+	}
+	return zookeeper.VerifyEnsemble(ctx, dialer, ensemble)
+}
+
+/*
+- çalışan ensemble main env üzerindeki ensemble haline getirmek için gereken adımlar planlanır.
+- ilk adım çalışan ensemble durumuna göre çoğunluk açısından kontrol edilir.
+- adımlar tek tek uygulanmalı, her adımdan sonra plan tekrar oluşturulmalıdır.
+*/
+func PlanZookeeperEnsemble(ctx context.Context, dialer a.IZookeeperDialer) ([]e.ZookeeperReconfigStepData, error) {
+	desired, err := getMainEnvValue[e.ZookeeperEnsembleData](env.ZookeeperEnsemble)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]string, 0, len(desired.MemberInfos))
+	for _, member := range desired.MemberInfos {
+		addresses = append(addresses, net.JoinHostPort(member.Host, strconv.Itoa(member.ClientPort)))
+	}
+
+	current, err := zookeeper.DescribeRunningEnsemble(ctx, dialer, addresses)
+	if err != nil {
+		return nil, err
+	}
+
+	steps, err := zookeeper.PlanEnsembleChange(current, desired)
+	if err != nil || len(steps) == 0 {
+		return steps, err
+	}
+
+	//çalışan ensemble durumu rapor üzerinden alınır, config eşleşmemesi burada beklenen durumdur.
+	report, _ := zookeeper.VerifyEnsemble(ctx, dialer, current)
+	if err := zookeeper.CheckStepQuorum(report, current, steps[0]); err != nil {
+		return steps, err
+	}
+	return steps, nil
+}
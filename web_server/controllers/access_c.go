@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"net/http"
+	config "web_server/confing"
+	env "web_server/environments/processors"
+	u "web_server/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// cevap Accept header bilgisine göre json ya da cbor olarak yazılır.
+func respond(c *gin.Context, status int, data any) {
+	contentType, body, err := u.EncodeResponseBody(c.GetHeader("Accept"), data)
+	if err != nil {
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	c.Data(status, contentType, body)
+}
+
+// http status ve hata cevabı err zinciri içerisindeki func error bilgisinden, mesaj Accept-Language ile seçilen locale ile üretilir.
+func respondError(c *gin.Context, err error) {
+	locale := u.ResponseLocale(c.GetHeader(env.AcceptLanguageHeader))
+	respond(c, env.FuncErrorHTTPStatus(err), env.FuncErrorResponse(err, locale))
+	c.Abort()
+}
+
+/*
+- owner access header doğrulanır, doğrulanamayan istekler 401 döner.
+- owner PermInfos içerisinde taskKey için permType yetkisi yoksa 403 döner.
+- doğrulanan owner whitelist key ve AuthnData sonraki handlers için context üzerine yazılır.
+*/
+func AuthorizeOwner(taskKey string, permType uint8) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		accessInfos, err := u.DecodeOwnerAccessHeader(c.GetHeader(env.OwnerAccessHeader))
+		if err != nil {
+			respondError(c, env.GetFuncError(env.OwnerAuthenticationFailed, err))
+			return
+		}
+
+		ownerKey, authnInfos, err := config.AuthenticateOwner(accessInfos)
+		if err != nil {
+			respondError(c, env.GetFuncError(env.OwnerAuthenticationFailed, err))
+			return
+		}
+
+		if err := u.CheckPermInfos(authnInfos.PermInfos, map[string]uint8{taskKey: permType}); err != nil {
+			respondError(c, err)
+			return
+		}
+
+		c.Set(env.CtxOwnerKey, ownerKey)
+		c.Set(env.CtxOwnerAuthn, authnInfos)
+		c.Next()
+	}
+}
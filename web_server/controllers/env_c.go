@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"net/http"
+	config "web_server/confing"
+	a "web_server/domain/abstractions"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+	u "web_server/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// karantina kayıtlarını silen istekler audit trail üzerine yazılır.
+type EnvController struct {
+	auditLog a.IAuditLog
+}
+
+func NewEnvController(auditLog a.IAuditLog) *EnvController {
+	return &EnvController{auditLog: auditLog}
+}
+
+// candidate file geçersiz olsa dahi rapor ile birlikte 200 döner. Dry run yapılamazsa hata döner.
+func (ec *EnvController) DryRun(c *gin.Context) {
+	input, _ := c.MustGet(env.CtxRequestInput).(e.EnvDryRunInput)
+	result, err := config.DryRunEnvFile(input, u.ResponseLocale(c.GetHeader(env.AcceptLanguageHeader)))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respond(c, http.StatusOK, result)
+}
+
+// karantina kayıtları file içerikleri olmadan döner.
+func (ec *EnvController) ListQuarantine(c *gin.Context) {
+	quarantineInfos, err := config.ListEnvQuarantine()
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respond(c, http.StatusOK, quarantineInfos)
+}
+
+// karantina kaydı reddedilen file içeriği ile birlikte döner.
+func (ec *EnvController) GetQuarantine(c *gin.Context) {
+	quarantineFileData, err := config.GetEnvQuarantine(c.Param(env.EnvQuarantineParam))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respond(c, http.StatusOK, quarantineFileData)
+}
+
+// karantina kaydı silinir. Silme işlemi başarısız olsa dahi audit trail üzerine yazılır.
+func (ec *EnvController) PurgeQuarantine(c *gin.Context) {
+	id := c.Param(env.EnvQuarantineParam)
+	entry := e.AuditEntryData{
+		Action:   env.AuditEnvQuarantinePurge,
+		Resource: id,
+		Owner:    c.GetString(env.CtxOwnerKey),
+		Result:   env.AuditResultSuccess,
+	}
+
+	//kayıt bilgileri silindikten sonra bulunamayacağı için audit details üzerine yazılır.
+	quarantineFileData, err := config.GetEnvQuarantine(id)
+	if err == nil {
+		entry.Details = map[string]string{
+			"source": quarantineFileData.QuarantineInfos.Source,
+			"field":  quarantineFileData.QuarantineInfos.Field,
+			"reason": quarantineFileData.QuarantineInfos.Reason,
+		}
+		err = config.PurgeEnvQuarantine(id)
+	}
+	if err != nil {
+		entry.Result = env.AuditResultFailure
+		entry.Error = err.Error()
+	}
+
+	if _, auditErr := ec.auditLog.IFAppend(entry); auditErr != nil {
+		respondError(c, auditErr)
+		return
+	}
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// diff format=text ile okunabilir metin, varsayılan olarak Accept header bilgisine göre json ya da cbor döner.
+func (ec *EnvController) DiffEnvMap(c *gin.Context) {
+	input, _ := c.MustGet(env.CtxRequestInput).(e.EnvMapDiffInput)
+	diff, err := config.DiffEnvMap(input)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if c.Query(env.EnvDiffFormatQuery) == env.EnvDiffFormatText {
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(config.RenderEnvMapDiff(diff)))
+		return
+	}
+	respond(c, http.StatusOK, diff)
+}
+
+// env map için diff ile kullanılabilecek revisions eskiden yeniye sıralı döner.
+func (ec *EnvController) EnvMapRevisions(c *gin.Context) {
+	respond(c, http.StatusOK, config.GetEnvMapRevisions(c.Param(env.EnvFieldParam)))
+}
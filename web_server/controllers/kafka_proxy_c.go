@@ -0,0 +1,274 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+	config "web_server/confing"
+	a "web_server/domain/abstractions"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+	kafka "web_server/infrastructure/kafka"
+
+	"github.com/gin-gonic/gin"
+)
+
+// signer nil ise imzalı envelope, opener nil ise şifreli topic produce istekleri reddedilir.
+type KafkaProxyController struct {
+	client    a.IKafkaProxyClient
+	consumers *kafka.ProxyConsumerRegistry
+	limiter   *kafka.ProxyQuotaLimiter
+	signer    a.ISigner
+	opener    a.ISealedKeyOpener
+}
+
+func NewKafkaProxyController(client a.IKafkaProxyClient, consumers *kafka.ProxyConsumerRegistry, limiter *kafka.ProxyQuotaLimiter, signer a.ISigner, opener a.ISealedKeyOpener) *KafkaProxyController {
+	return &KafkaProxyController{client: client, consumers: consumers, limiter: limiter, signer: signer, opener: opener}
+}
+
+/*
+- owner topic üzerinde Write yetkisine sahip olmak zorundadır.
+- batch record ve byte limitleri ile owner byte/saniye limiti kontrol edilir, aşılırsa 429 döner.
+- topic şifreli ise records aktif topic data key ile şifrelenir. Sign true ise envelope şifreli value üzerinden oluşturulur.
+- Sign true ise records system signer ile imzalı envelope içerisine alınır.
+*/
+func (pc *KafkaProxyController) Produce(c *gin.Context) {
+	input, _ := c.MustGet(env.CtxRequestInput).(e.ProduceKafkaRecordsInput)
+	ownerKey := c.GetString(env.CtxOwnerKey)
+
+	if err := checkTopicPerm(c, input.Topic, env.Write); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	quota, err := config.GetKafkaProxyQuota(ownerKey)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	batchBytes, err := kafka.CheckProduceBatch(ownerKey, quota, input.Records)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if err := pc.limiter.AllowBytes(ownerKey, quota, batchBytes); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	records, err := pc.encryptRecords(input.Topic, input.Records)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	if input.Sign {
+		if pc.signer == nil {
+			respondError(c, env.GetFuncError(env.SignerNotConfigured, nil, "record envelope signer"))
+			return
+		}
+		unsignedRecords := records
+		records = make([]e.KafkaProduceRecordData, 0, len(unsignedRecords))
+		for _, record := range unsignedRecords {
+			sealedRecord, err := kafka.SealRecordEnvelope(pc.signer, ownerKey, input.Topic, record)
+			if err != nil {
+				respondError(c, err)
+				return
+			}
+			records = append(records, sealedRecord)
+		}
+	}
+
+	offsets, err := pc.client.IFProduce(c.Request.Context(), input.Topic, records)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respond(c, http.StatusOK, offsets)
+}
+
+// topic şifreli değilse records değişmeden döner.
+func (pc *KafkaProxyController) encryptRecords(topic string, records []e.KafkaProduceRecordData) ([]e.KafkaProduceRecordData, error) {
+	encrypted, err := config.IsKafkaTopicEncrypted(topic)
+	if err != nil {
+		return nil, err
+	}
+	if !encrypted {
+		return records, nil
+	}
+	if pc.opener == nil {
+		return nil, env.GetFuncError(env.SignerNotConfigured, nil, "topic key opener")
+	}
+
+	keyVersion, dataKey, err := config.GetKafkaTopicDataKey(topic, pc.opener)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for i := range dataKey {
+			dataKey[i] = 0
+		}
+	}()
+
+	encryptedRecords := make([]e.KafkaProduceRecordData, 0, len(records))
+	for _, record := range records {
+		encryptedRecord, err := kafka.EncryptRecord(topic, keyVersion, dataKey, record)
+		if err != nil {
+			return nil, err
+		}
+		encryptedRecords = append(encryptedRecords, encryptedRecord)
+	}
+	return encryptedRecords, nil
+}
+
+/*
+- owner'a mühürlenmiş topic data keys döner, consumer şifreli records bu keys ile kendi tarafında çözer.
+- owner topic üzerinde Read yetkisine sahip olmak zorundadır. Owner alıcı olmadığı key versions sonuçta yer almaz.
+*/
+func (pc *KafkaProxyController) TopicKeys(c *gin.Context) {
+	topic := c.Param(env.KafkaTopicParam)
+	if err := checkTopicPerm(c, topic, env.Read); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	keyRing, exists, err := config.GetKafkaTopicKeyRing(topic)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	filtered := kafka.FilterTopicKeyRing(keyRing, c.GetString(env.CtxOwnerKey))
+	if !exists || len(filtered.KeyInfos) == 0 {
+		respondError(c, env.GetFuncError(env.KafkaTopicKeyNotFound, nil, topic))
+		return
+	}
+	respond(c, http.StatusOK, filtered)
+}
+
+// owner group ve bütün topics üzerinde Read ve Bridge yetkisine sahip olmak zorundadır.
+func checkConsumerPerm(c *gin.Context, group string, topics []string) error {
+	authnInfos, _ := c.MustGet(env.CtxOwnerAuthn).(e.AuthnData)
+	if err := kafka.CheckGroupPerm(authnInfos.PermInfos, group, env.Read|env.Bridge); err != nil {
+		return err
+	}
+	for _, topic := range topics {
+		if err := checkTopicPerm(c, topic, env.Read|env.Bridge); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+- consumer instance her kullanımda owner güncel yetkileri ile kontrol edilir.
+- yetki kaldırılmışsa instance kapatılır ve yetki hatası döner.
+*/
+func (pc *KafkaProxyController) useConsumer(c *gin.Context, instanceID string, fn func(instanceInfos e.KafkaConsumerInstanceData, consumer a.IKafkaConsumer) error) error {
+	ownerKey := c.GetString(env.CtxOwnerKey)
+	var permErr error
+	err := pc.consumers.Use(ownerKey, instanceID, func(instanceInfos e.KafkaConsumerInstanceData, consumer a.IKafkaConsumer) error {
+		if permErr = checkConsumerPerm(c, instanceInfos.Group, instanceInfos.Topics); permErr != nil {
+			return permErr
+		}
+		return fn(instanceInfos, consumer)
+	})
+	//instance kilidi Use tamamlandıktan sonra bırakılır, instance sonra kaldırılır.
+	if permErr != nil {
+		pc.consumers.Remove(ownerKey, instanceID)
+	}
+	return err
+}
+
+// owner group ve bütün topics üzerinde Read yetkisine sahip olmak zorundadır.
+func (pc *KafkaProxyController) CreateConsumer(c *gin.Context) {
+	input, _ := c.MustGet(env.CtxRequestInput).(e.CreateKafkaConsumerInput)
+	ownerKey := c.GetString(env.CtxOwnerKey)
+
+	if err := checkConsumerPerm(c, input.Group, input.Topics); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	quota, err := config.GetKafkaProxyQuota(ownerKey)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	consumer, err := pc.client.IFNewConsumer(c.Request.Context(), input)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	instanceInfos, err := pc.consumers.Register(ownerKey, quota, input, consumer)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respond(c, http.StatusCreated, instanceInfos)
+}
+
+// max-records owner MaxPollRecords limitini aşamaz. Timeout süresince record gelmezse boş liste döner. Owner yetkisi her poll öncesi kontrol edilir.
+func (pc *KafkaProxyController) Poll(c *gin.Context) {
+	input, _ := c.MustGet(env.CtxRequestInput).(e.PollKafkaConsumerInput)
+	ownerKey := c.GetString(env.CtxOwnerKey)
+
+	quota, err := config.GetKafkaProxyQuota(ownerKey)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	maxRecords := input.MaxRecords
+	if quota.MaxPollRecords > 0 && (maxRecords == 0 || maxRecords > quota.MaxPollRecords) {
+		maxRecords = quota.MaxPollRecords
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(input.TimeoutMs)*time.Millisecond)
+	defer cancel()
+
+	records := []e.KafkaConsumedRecordData{}
+	err = pc.useConsumer(c, input.InstanceID, func(_ e.KafkaConsumerInstanceData, consumer a.IKafkaConsumer) error {
+		polledRecords, err := consumer.IFPoll(ctx, maxRecords)
+		if err != nil {
+			return err
+		}
+		records = append(records, polledRecords...)
+		return nil
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respond(c, http.StatusOK, records)
+}
+
+// sadece instance oluşturulurken belirtilen topics için offset commit edilebilir. Owner yetkisi her commit öncesi kontrol edilir.
+func (pc *KafkaProxyController) Commit(c *gin.Context) {
+	input, _ := c.MustGet(env.CtxRequestInput).(e.CommitKafkaOffsetsInput)
+
+	err := pc.useConsumer(c, input.InstanceID, func(instanceInfos e.KafkaConsumerInstanceData, consumer a.IKafkaConsumer) error {
+		for _, offset := range input.Offsets {
+			if !slices.Contains(instanceInfos.Topics, offset.Topic) {
+				return env.GetFuncError(env.InvalidRequestBody, errors.New("topic not subscribed "+offset.Topic))
+			}
+		}
+
+		return consumer.IFCommit(c.Request.Context(), input.Offsets)
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (pc *KafkaProxyController) DeleteConsumer(c *gin.Context) {
+	if err := pc.consumers.Remove(c.GetString(env.CtxOwnerKey), c.Param(env.KafkaConsumerInstanceParam)); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
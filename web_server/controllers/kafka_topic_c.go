@@ -0,0 +1,236 @@
+package controllers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	config "web_server/confing"
+	a "web_server/domain/abstractions"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+	kafka "web_server/infrastructure/kafka"
+
+	"github.com/gin-gonic/gin"
+)
+
+// signer ya da opener nil ise topic key rotation istekleri reddedilir.
+type KafkaTopicController struct {
+	admin    a.IKafkaTopicAdmin
+	auditLog a.IAuditLog
+	signer   a.ISigner
+	opener   a.ISealedKeyOpener
+}
+
+func NewKafkaTopicController(admin a.IKafkaTopicAdmin, auditLog a.IAuditLog, signer a.ISigner, opener a.ISealedKeyOpener) *KafkaTopicController {
+	return &KafkaTopicController{admin: admin, auditLog: auditLog, signer: signer, opener: opener}
+}
+
+/*
+- değişiklik yapan her istek sonucu ile birlikte audit trail üzerine yazılır.
+- audit kaydı yazılamazsa broker üzerindeki işlem tamamlanmış olsa dahi 500 döner.
+- hata durumunda http status func error bilgisinden üretilir, okStatus sadece başarılı cevap için kullanılır.
+*/
+func (tc *KafkaTopicController) auditAndRespond(c *gin.Context, entry e.AuditEntryData, okStatus int, data any, err error) {
+	entry.Owner = c.GetString(env.CtxOwnerKey)
+	entry.Result = env.AuditResultSuccess
+	if err != nil {
+		entry.Result = env.AuditResultFailure
+		entry.Error = err.Error()
+	}
+
+	if _, auditErr := tc.auditLog.IFAppend(entry); auditErr != nil {
+		respondError(c, auditErr)
+		return
+	}
+
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respond(c, okStatus, data)
+}
+
+func (tc *KafkaTopicController) auditAndFail(c *gin.Context, entry e.AuditEntryData, err error) {
+	tc.auditAndRespond(c, entry, http.StatusOK, nil, err)
+}
+
+// topic üzerinde kafka-topic:<topic> ya da eşleşen kafka-topic-prefix:<prefix> yetkisi aranır.
+func checkTopicPerm(c *gin.Context, topic string, permType uint8) error {
+	authnInfos, _ := c.MustGet(env.CtxOwnerAuthn).(e.AuthnData)
+	return kafka.CheckTopicPerm(authnInfos.PermInfos, topic, permType)
+}
+
+func configDetails(prefix string, configs map[string]string, details map[string]string) {
+	for key, value := range configs {
+		details[prefix+key] = value
+	}
+}
+
+// topic mevcut ve ayarları aynı ise 200, oluşturulursa 201, farklı ayarlar ile mevcut ise 409 döner.
+func (tc *KafkaTopicController) CreateTopic(c *gin.Context) {
+	input, _ := c.MustGet(env.CtxRequestInput).(e.CreateKafkaTopicInput)
+	entry := e.AuditEntryData{
+		Action:   env.AuditKafkaTopicCreate,
+		Resource: env.KafkaTopicPermPrefix + input.Name,
+		Details: map[string]string{
+			"partitions":         strconv.FormatInt(int64(input.Partitions), 10),
+			"replication-factor": strconv.FormatInt(int64(input.ReplicationFactor), 10),
+		},
+	}
+	configDetails("config.", input.Configs, entry.Details)
+
+	if err := checkTopicPerm(c, input.Name, env.Write); err != nil {
+		tc.auditAndFail(c, entry, err)
+		return
+	}
+
+	policy, err := config.GetKafkaTopicPolicy()
+	if err != nil {
+		tc.auditAndFail(c, entry, err)
+		return
+	}
+	if err := kafka.CheckCreateTopicPolicy(policy, input); err != nil {
+		tc.auditAndFail(c, entry, err)
+		return
+	}
+
+	result, err := kafka.CreateTopicIfNotExists(c.Request.Context(), tc.admin, input)
+	switch {
+	case err != nil:
+		tc.auditAndFail(c, entry, err)
+	case result.Changed:
+		tc.auditAndRespond(c, entry, http.StatusCreated, result, nil)
+	default:
+		tc.auditAndRespond(c, entry, http.StatusOK, result, nil)
+	}
+}
+
+func (tc *KafkaTopicController) DescribeTopic(c *gin.Context) {
+	name := c.Param(env.KafkaTopicParam)
+	if err := checkTopicPerm(c, name, env.Read|env.Write|env.Bridge); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	topic, exists, err := tc.admin.IFDescribeTopic(c.Request.Context(), name)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if !exists {
+		respondError(c, env.GetFuncError(env.KafkaTopicNotFound, nil, name))
+		return
+	}
+	respond(c, http.StatusOK, topic)
+}
+
+// istenen configs zaten uygulanmış ise broker üzerinde değişiklik yapılmaz ve Changed false döner.
+func (tc *KafkaTopicController) AlterTopicConfig(c *gin.Context) {
+	input, _ := c.MustGet(env.CtxRequestInput).(e.AlterKafkaTopicConfigInput)
+	deleteConfigs := append([]string(nil), input.DeleteConfigs...)
+	sort.Strings(deleteConfigs)
+	entry := e.AuditEntryData{
+		Action:   env.AuditKafkaTopicAlterConfig,
+		Resource: env.KafkaTopicPermPrefix + input.Name,
+		Details:  map[string]string{"delete": strings.Join(deleteConfigs, ",")},
+	}
+	configDetails("set.", input.SetConfigs, entry.Details)
+
+	if err := checkTopicPerm(c, input.Name, env.Write); err != nil {
+		tc.auditAndFail(c, entry, err)
+		return
+	}
+
+	policy, err := config.GetKafkaTopicPolicy()
+	if err != nil {
+		tc.auditAndFail(c, entry, err)
+		return
+	}
+	if err := kafka.CheckAlterTopicConfigPolicy(policy, input); err != nil {
+		tc.auditAndFail(c, entry, err)
+		return
+	}
+
+	_, exists, err := tc.admin.IFDescribeTopic(c.Request.Context(), input.Name)
+	if err != nil {
+		tc.auditAndFail(c, entry, err)
+		return
+	}
+	if !exists {
+		tc.auditAndFail(c, entry, env.GetFuncError(env.KafkaTopicNotFound, nil, input.Name))
+		return
+	}
+
+	result, err := kafka.AlterTopicConfigs(c.Request.Context(), tc.admin, input)
+	tc.auditAndRespond(c, entry, http.StatusOK, result, err)
+}
+
+// topic yoksa hata dönmez, Changed false döner.
+func (tc *KafkaTopicController) DeleteTopic(c *gin.Context) {
+	name := c.Param(env.KafkaTopicParam)
+	entry := e.AuditEntryData{
+		Action:   env.AuditKafkaTopicDelete,
+		Resource: env.KafkaTopicPermPrefix + name,
+	}
+
+	if err := checkTopicPerm(c, name, env.Write); err != nil {
+		tc.auditAndFail(c, entry, err)
+		return
+	}
+
+	//rezerve prefix ile başlayan topic api üzerinden silinemez.
+	policy, err := config.GetKafkaTopicPolicy()
+	if err != nil {
+		tc.auditAndFail(c, entry, err)
+		return
+	}
+	if err := kafka.CheckTopicNamePolicy(policy, name); err != nil {
+		tc.auditAndFail(c, entry, err)
+		return
+	}
+
+	result, err := kafka.DeleteTopicIfExists(c.Request.Context(), tc.admin, name)
+	tc.auditAndRespond(c, entry, http.StatusOK, result, err)
+}
+
+/*
+- şifreli topic için yeni data key version üretilir ve güncel okuma yetkisine sahip owner'lara mühürlenir.
+- topic main env üzerinde şifreli olarak tanımlı değilse 400, key signer tanımlı değilse 503 döner.
+*/
+func (tc *KafkaTopicController) RotateTopicKey(c *gin.Context) {
+	name := c.Param(env.KafkaTopicParam)
+	entry := e.AuditEntryData{
+		Action:   env.AuditKafkaTopicRotateKey,
+		Resource: env.KafkaTopicPermPrefix + name,
+		Details:  map[string]string{},
+	}
+
+	if err := checkTopicPerm(c, name, env.Write); err != nil {
+		tc.auditAndFail(c, entry, err)
+		return
+	}
+	if tc.signer == nil || tc.opener == nil {
+		tc.auditAndFail(c, entry, env.GetFuncError(env.SignerNotConfigured, nil, "topic key signer"))
+		return
+	}
+
+	encrypted, err := config.IsKafkaTopicEncrypted(name)
+	if err != nil {
+		tc.auditAndFail(c, entry, err)
+		return
+	}
+	if !encrypted {
+		tc.auditAndFail(c, entry, env.GetFuncError(env.InvalidKafkaTopic, nil, name, "topic is not encrypted"))
+		return
+	}
+
+	topicKey, err := config.RotateKafkaTopicKey(name, tc.signer, tc.opener)
+	if err != nil {
+		tc.auditAndFail(c, entry, err)
+		return
+	}
+	entry.Details["key-version"] = strconv.FormatUint(uint64(topicKey.Version), 10)
+	entry.Details["recipients"] = strconv.Itoa(len(topicKey.SealedKeyInfos))
+	tc.auditAndRespond(c, entry, http.StatusCreated, topicKey, nil)
+}
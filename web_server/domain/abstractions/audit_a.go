@@ -0,0 +1,7 @@
+package abstractions
+
+import e "web_server/domain/entities"
+
+type IAuditLog interface {
+	IFAppend(entry e.AuditEntryData) (e.AuditEntryData, error)
+}
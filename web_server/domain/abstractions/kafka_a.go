@@ -0,0 +1,42 @@
+package abstractions
+
+import (
+	"context"
+	e "web_server/domain/entities"
+)
+
+type IKafkaAdmin interface {
+	IFDescribeACLs(ctx context.Context) ([]e.KafkaACLData, error)
+	IFCreateACLs(ctx context.Context, acls []e.KafkaACLData) error
+	IFDeleteACLs(ctx context.Context, acls []e.KafkaACLData) error
+	IFDescribeScramUsers(ctx context.Context) ([]e.KafkaScramUserData, error)
+	IFUpsertScramCredentials(ctx context.Context, inputs []e.UpsertKafkaScramInput) error
+	IFDeleteScramCredentials(ctx context.Context, users []e.KafkaScramUserData) error
+}
+
+// topic işlemleri idempotent çalışır. Mevcut olmayan topic için describe false döner.
+type IKafkaTopicAdmin interface {
+	IFDescribeTopic(ctx context.Context, name string) (e.KafkaTopicData, bool, error)
+	IFCreateTopic(ctx context.Context, input e.CreateKafkaTopicInput) (bool, error)
+	IFAlterTopicConfigs(ctx context.Context, input e.AlterKafkaTopicConfigInput) error
+	IFDeleteTopic(ctx context.Context, name string) (bool, error)
+}
+
+// rest proxy tarafından kullanılan producer ve consumer client.
+type IKafkaProxyClient interface {
+	IFProduce(ctx context.Context, topic string, records []e.KafkaProduceRecordData) ([]e.KafkaRecordOffsetData, error)
+	IFNewConsumer(ctx context.Context, input e.CreateKafkaConsumerInput) (IKafkaConsumer, error)
+}
+
+// consumer group üyesi olarak çalışan tek bir consumer instance. Offsets sadece commit ile kaydedilir.
+type IKafkaConsumer interface {
+	IFPoll(ctx context.Context, maxRecords int) ([]e.KafkaConsumedRecordData, error)
+	IFCommit(ctx context.Context, offsets []e.KafkaRecordOffsetData) error
+	IFClose()
+}
+
+// lag metrics için sadece okuma yapan admin client. Mevcut olmayan topics sonuç içerisinde yer almaz.
+type IKafkaMetricsAdmin interface {
+	IFDescribeTopicMetrics(ctx context.Context, topics []string) ([]e.KafkaTopicMetricsData, error)
+	IFFetchGroupOffsets(ctx context.Context, group string) ([]e.KafkaRecordOffsetData, error)
+}
@@ -0,0 +1,14 @@
+package abstractions
+
+import e "web_server/domain/entities"
+
+type ISigner interface {
+	IFSign(data []byte) (e.SignatureData, error)
+	IFPubKey() []byte
+}
+
+// signer key çiftinden türetilen x25519 key ile kendisine mühürlenmiş data açılır.
+type ISealedKeyOpener interface {
+	IFX25519PubKey() []byte
+	IFOpenSealed(sealed []byte) ([]byte, error)
+}
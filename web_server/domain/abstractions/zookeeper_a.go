@@ -0,0 +1,11 @@
+package abstractions
+
+import (
+	"context"
+	"net"
+)
+
+// four letter words komutları için ensemble üyesine bağlantı açar.
+type IZookeeperDialer interface {
+	IFDial(ctx context.Context, address string) (net.Conn, error)
+}
@@ -0,0 +1,21 @@
+package entities
+
+// ********audit trail********
+/*
+- sistem üzerinde yapılan her değişiklik audit trail üzerine yazılır.
+- her kayıt bir önceki kaydın hash bilgisini barındırır, araya kayıt eklenmesi ya da silinmesi zinciri bozar.
+*/
+type AuditEntryData struct {
+	Sequence  uint64            `cbor:"1,keyasint" json:"sequence"`
+	Owner     string            `cbor:"2,keyasint" json:"owner"`  //işlemi yapan owner whitelist key
+	Action    string            `cbor:"3,keyasint" json:"action"` //Ex: kafka-topic-create
+	Resource  string            `cbor:"4,keyasint" json:"resource"`
+	Details   map[string]string `cbor:"5,keyasint" json:"details,omitempty"`
+	Result    string            `cbor:"6,keyasint" json:"result"` //success, failure
+	Error     string            `cbor:"7,keyasint" json:"error,omitempty"`
+	CreatedAt int64             `cbor:"8,keyasint" json:"created-at"`
+	PrevHash  []byte            `cbor:"9,keyasint" json:"prev-hash"`
+	Hash      []byte            `cbor:"10,keyasint" json:"hash"` //Hash alanı boş iken kaydın sha2 bilgisi
+}
+
+// ********audit trail********
@@ -6,15 +6,21 @@ type GetInput[T any] struct {
 	Data       *T       `cbor:"3,keyasint"`
 }
 
-type EnvMapChainData struct {
-	NextEnvMap     string
+type PutInput[T any] struct {
+	PathKey    int      `cbor:"1,keyasint"`
+	PathFields []string `cbor:"2,keyasint"`
+	Data       *T       `cbor:"3,keyasint"`
+}
+
+// env map bağımlılıkları. DependsOn içerisindeki env maps yüklenmeden env map yüklenmez.
+type EnvMapDependencyData struct {
+	DependsOn      []string
 	EnvKeyRefSlice []string
 }
 
 type IncludeExternalEnvInput struct {
 	OwnerWhitelistKeyInfo OwnerWhitelistData
-	StartEnvMapField      string
-	EnvMapChainInfos      map[string]EnvMapChainData
+	EnvMapDependencyInfos map[string]EnvMapDependencyData
 	// IncludeEnvMapInfos map[string]IncludeEnvData
 
 }
@@ -31,6 +37,7 @@ type EnvData[V any] struct {
 	Value        V            `cbor:"1,keyasint"`
 	SpecificInfo SpecificData `cbor:"2,keyasint"`
 	StatusInfo   StatusData   `cbor:"3,keyasint"`
+	Kind         string       `cbor:"4,keyasint,omitempty"` //boş ise plain value, secret ise Value cbor(EnvSecretData) formatındadır.
 }
 
 type EnvMapData[K comparable, V any] struct {
@@ -46,6 +53,68 @@ type EnvFileData[K comparable, V any] struct {
 
 // *******genel env file formatı*******
 
+// *******func messages*******
+
+// func error env içerisinde locale başına tutulan message catalog. Keys func error ve func status isimleridir.
+type FuncMessageCatalogData struct {
+	ErrorInfos  map[string]string `cbor:"1,keyasint"`
+	StatusInfos map[string]string `cbor:"2,keyasint"`
+}
+
+// *******func messages*******
+
+// *******validation report*******
+
+// doğrulama hatası. Path hatanın bulunduğu tam field path bilgisidir. Ex: EnvMapData.EnvInfos[key].Value
+type ValidationViolationData struct {
+	Path     string `cbor:"1,keyasint" json:"path"`
+	Code     int    `cbor:"2,keyasint" json:"code"`
+	Name     string `cbor:"3,keyasint" json:"name"`
+	Severity string `cbor:"4,keyasint" json:"severity"`
+	Message  string `cbor:"5,keyasint" json:"message"`
+}
+
+/*
+- imzalama araçları ve api tarafından kullanılan doğrulama raporu.
+- Violations path ve code sırasına göre sıralıdır. Total bulunan bütün hataların sayısıdır, sınır aşılırsa Truncated true olur.
+*/
+type ValidationReportData struct {
+	Valid      bool                      `cbor:"1,keyasint" json:"valid"`
+	Total      int                       `cbor:"2,keyasint" json:"total"`
+	Truncated  bool                      `cbor:"3,keyasint" json:"truncated"`
+	Violations []ValidationViolationData `cbor:"4,keyasint" json:"violations"`
+}
+
+// *******validation report*******
+
+// *******env schema*******
+
+/*
+- env value için beklenen tür ve kısıtlar. Type: string, int, bool, duration, url, host-port, enum, list, map
+- Min/Max int için değer, duration için milisaniye, string/list/map için uzunluk sınırıdır.
+- map için Fields ile tanımlanan keys dışındaki keys Values ile doğrulanır, Values yoksa kabul edilmez.
+*/
+type EnvSchemaFieldData struct {
+	Type     string                        `cbor:"1,keyasint"`
+	Required bool                          `cbor:"2,keyasint"`
+	Min      *int64                        `cbor:"3,keyasint,omitempty"`
+	Max      *int64                        `cbor:"4,keyasint,omitempty"`
+	Pattern  string                        `cbor:"5,keyasint,omitempty"` //string için regexp
+	Enum     []string                      `cbor:"6,keyasint,omitempty"`
+	Schemes  []string                      `cbor:"7,keyasint,omitempty"` //url için izin verilen schemes
+	Items    *EnvSchemaFieldData           `cbor:"8,keyasint,omitempty"` //list elemanları
+	Fields   map[string]EnvSchemaFieldData `cbor:"9,keyasint,omitempty"`
+	Values   *EnvSchemaFieldData           `cbor:"10,keyasint,omitempty"`
+}
+
+// env map schema. AllowUnknown false ise schema üzerinde tanımlı olmayan keys kabul edilmez.
+type EnvSchemaData struct {
+	Fields       map[string]EnvSchemaFieldData `cbor:"1,keyasint"`
+	AllowUnknown bool                          `cbor:"2,keyasint"`
+}
+
+// *******env schema*******
+
 type ValidateEnvMapInput[V any] struct {
 	ReferenceEnvTag   string
 	ReferenceEnvSlice []string
@@ -104,6 +173,220 @@ type SystemWhiteListData[K comparable, V any] struct {
 
 //*******Whitelist*******
 
+//*******Bootstrap*******
+
+// bundle içerisinde sistemin ilk açılışında trust kurulması için gereken datalar barınır.
+type BootstrapData struct {
+	SystemPubKeyInfo PubKeyData                                      `cbor:"1,keyasint"`
+	WhitelistInfos   SystemWhiteListData[string, WhitelistOwnerData] `cbor:"2,keyasint"`
+	MainEnvInfos     EnvFileData[string, EnvData[[]byte]]            `cbor:"3,keyasint"`
+	StatusInfos      StatusData                                      `cbor:"4,keyasint"`
+}
+
+/*
+- tek seferlik okunan ve okunduktan sonra silinen imzalı bootstrap file formatı
+- SignatureInfos: BootstrapInfos datasının, bundle içerisindeki system pub key ile oluşturulan imzası
+*/
+type BootstrapBundleData struct {
+	BootstrapInfos BootstrapData `cbor:"1,keyasint"`
+	SignatureInfos SignatureData `cbor:"2,keyasint"`
+}
+
+// trust kurulduktan sonra store üzerinde tutulur. Var olduğu sürece bundle ile tekrar açılış yapılamaz.
+type TrustStateData struct {
+	SystemPubKeyCID []byte     `cbor:"1,keyasint"` //trust kurulan system pub key cid bilgisi
+	BundleCID       []byte     `cbor:"2,keyasint"` //trust kurulurken kullanılan bundle cid bilgisi
+	StatusInfos     StatusData `cbor:"3,keyasint"`
+}
+
+// trust kaydı cid bilgisi sadece key material üzerinden üretilir. Status alanları cid dışında tutulur.
+type TrustPubKeyData struct {
+	SignType int    `cbor:"1,keyasint"`
+	PubKey   []byte `cbor:"2,keyasint"`
+}
+
+//*******Bootstrap*******
+
+//*******env secret*******
+
+/*
+- secret env value. EnvData.Value içerisinde cbor olarak tutulur, plaintext value cbor formatındadır ve sadece erişim anında bellekte açılır.
+- Ciphertext KeyVersion ile belirtilen data key ile şifrelenir. Env map field, key ve KeyVersion additional data olarak doğrulanır.
+*/
+type EnvSecretData struct {
+	KeyVersion uint32 `cbor:"1,keyasint"`
+	Nonce      []byte `cbor:"2,keyasint,omitempty"`
+	Ciphertext []byte `cbor:"3,keyasint,omitempty"` //redact edilmiş secret value için boştur.
+}
+
+// secret data key version. Data key system x25519 key üzerine mühürlenir, key encryption key değiştiğinde sadece SealedDataKey yenilenir.
+type EnvSecretKeyData struct {
+	Version       uint32     `cbor:"1,keyasint"`
+	SealedDataKey []byte     `cbor:"2,keyasint"`
+	X25519PubKey  []byte     `cbor:"3,keyasint"` //data key mühürlenirken kullanılan key encryption key
+	StatusInfo    StatusData `cbor:"4,keyasint"`
+}
+
+// KeyInfos version sırasına göre tutulur, son key aktif key'dir. Eski keys daha önce şifrelenmiş secrets için saklanır.
+type EnvSecretKeyRingInfoData struct {
+	KeyInfos []EnvSecretKeyData `cbor:"1,keyasint"`
+}
+
+// key ring system signer ile imzalanır, imzası doğrulanamayan key ring ile secret açılmaz.
+type EnvSecretKeyRingData struct {
+	KeyRingInfos   EnvSecretKeyRingInfoData `cbor:"1,keyasint"`
+	SignatureInfos SignatureData            `cbor:"2,keyasint"`
+}
+
+//*******env secret*******
+
+//*******env layers*******
+
+// env layer ayarları. SystemTag overlay, ReplicaID replica override layer seçer. Boş ise layer uygulanmaz.
+type EnvLayerData struct {
+	SystemTag string `cbor:"1,keyasint" json:"system-tag"`
+	ReplicaID string `cbor:"2,keyasint" json:"replica-id"`
+}
+
+// effective env value kaynağı. Overrides değerin üzerine yazdığı alt layer files sırası ile tutulur.
+type EnvLayerSourceData struct {
+	Layer     string   `cbor:"1,keyasint" json:"layer"` //base, tag, replica
+	Name      string   `cbor:"2,keyasint" json:"name"`  //tag ya da replica id, base için boştur.
+	File      string   `cbor:"3,keyasint" json:"file"`
+	Overrides []string `cbor:"4,keyasint,omitempty" json:"overrides,omitempty"`
+}
+
+// effective env value ve geldiği layer. Secret values redact edilmiş olarak döner.
+type EffectiveEnvValueData struct {
+	Key        string             `cbor:"1,keyasint" json:"key"`
+	Value      []byte             `cbor:"2,keyasint" json:"value"`
+	Kind       string             `cbor:"3,keyasint,omitempty" json:"kind,omitempty"`
+	StatusInfo StatusData         `cbor:"4,keyasint" json:"status"`
+	SourceInfo EnvLayerSourceData `cbor:"5,keyasint" json:"source"`
+	Template   []byte             `cbor:"6,keyasint,omitempty" json:"template,omitempty"` //references çözülmemiş cbor value
+}
+
+//*******env layers*******
+
+//*******env map diff*******
+
+type EnvMapKeyDiffData struct {
+	Key    string `cbor:"1,keyasint" json:"key"`
+	Change string `cbor:"2,keyasint" json:"change"` //added, removed, modified
+}
+
+// field değişikliği. Path değişen field bilgisidir, Ex: EnvInfos[broker-port].Value. Secret values redact edilir.
+type EnvMapChangeData struct {
+	Path   string `cbor:"1,keyasint" json:"path"`
+	Change string `cbor:"2,keyasint" json:"change"` //added, removed, modified
+	From   string `cbor:"3,keyasint" json:"from,omitempty"`
+	To     string `cbor:"4,keyasint" json:"to,omitempty"`
+}
+
+/*
+- iki env map arasındaki fark. KeyInfos keys, ChangeInfos decode edilmiş values, StatusData ve SpecificInfo dahil field değişiklikleridir.
+- From ve To karşılaştırılan env maps kaynağıdır. Ex: live, revision:12, candidate
+*/
+type EnvMapDiffData struct {
+	Field       string              `cbor:"1,keyasint" json:"field"`
+	From        string              `cbor:"2,keyasint" json:"from"`
+	To          string              `cbor:"3,keyasint" json:"to"`
+	KeyInfos    []EnvMapKeyDiffData `cbor:"4,keyasint" json:"keys"`
+	ChangeInfos []EnvMapChangeData  `cbor:"5,keyasint" json:"changes"`
+}
+
+/*
+- File verilirse yüklü env map candidate file ile, verilmezse ToRevision ile karşılaştırılır. FromRevision 0 ise yüklü env map kullanılır.
+- layered env maps için base env map revisions kullanılır.
+*/
+type EnvMapDiffInput struct {
+	Field        string `cbor:"1,keyasint" json:"field"`
+	FromRevision uint64 `cbor:"2,keyasint" json:"from-revision,omitempty"`
+	ToRevision   uint64 `cbor:"3,keyasint" json:"to-revision,omitempty"`
+	File         []byte `cbor:"4,keyasint" json:"file,omitempty"`
+}
+
+//*******env map diff*******
+
+//*******env dry run*******
+
+/*
+- dry run ile doğrulanacak candidate file. File candidate file cbor datasıdır.
+- Field sadece env-map, WhitelistKey sadece access-data için kullanılır.
+*/
+type EnvDryRunInput struct {
+	Kind         string `cbor:"1,keyasint" json:"kind"` //env-map, whitelist, access-data
+	Field        string `cbor:"2,keyasint" json:"field,omitempty"`
+	WhitelistKey string `cbor:"3,keyasint" json:"whitelist-key,omitempty"`
+	File         []byte `cbor:"4,keyasint" json:"file"`
+}
+
+// dry run sonucu. Diff yüklü data ile candidate file arasındaki farktır, yüklü data yoksa bütün keys added döner.
+type EnvDryRunResultData struct {
+	Kind        string               `cbor:"1,keyasint" json:"kind"`
+	Field       string               `cbor:"2,keyasint" json:"field,omitempty"`
+	ReportInfos ValidationReportData `cbor:"3,keyasint" json:"report"`
+	DiffInfos   EnvMapDiffData       `cbor:"4,keyasint" json:"diff"`
+}
+
+//*******env dry run*******
+
+//*******env quarantine*******
+
+/*
+- reddedilen file karantina bilgisi. ID file içeriğinin cid v1 bilgisidir, aynı file tekrar reddedilirse kayıt güncellenir.
+- ExpectedCID yüklü data ya da whitelist üzerinde beklenen, ActualCID reddedilen data cid bilgisidir. Decode edilemeyen file için boş kalır.
+*/
+type EnvQuarantineData struct {
+	ID            string `cbor:"1,keyasint" json:"id"`
+	Source        string `cbor:"2,keyasint" json:"source"`
+	Field         string `cbor:"3,keyasint" json:"field"`
+	Reason        string `cbor:"4,keyasint" json:"reason"`
+	ErrorName     string `cbor:"5,keyasint" json:"error-name,omitempty"`
+	ExpectedCID   string `cbor:"6,keyasint" json:"expected-cid,omitempty"`
+	ActualCID     string `cbor:"7,keyasint" json:"actual-cid,omitempty"`
+	SignedBy      string `cbor:"8,keyasint" json:"signed-by,omitempty"`
+	QuarantinedAt int64  `cbor:"9,keyasint" json:"quarantined-at"`
+	Size          int    `cbor:"10,keyasint" json:"size"`
+}
+
+// karantina file. File reddedilen file içeriğini değiştirilmeden barındırır.
+type EnvQuarantineFileData struct {
+	QuarantineInfos EnvQuarantineData `cbor:"1,keyasint" json:"quarantine"`
+	File            []byte            `cbor:"2,keyasint" json:"file"`
+}
+
+//*******env quarantine*******
+
+//*******env store bundle*******
+
+// store üzerindeki env map. EnvMapInfo env map datasının canonical cbor formatıdır, env map türü bundle type registry üzerinden bulunur.
+type EnvStoreMapData struct {
+	EnvMapInfo  []byte     `cbor:"1,keyasint"`
+	Revision    uint64     `cbor:"2,keyasint"` //dışa aktarıldığı instance üzerindeki revision, içeri aktarılırken yeni revision verilir.
+	StatusInfos StatusData `cbor:"3,keyasint"` //env map status bilgisi, env map çözülmeden incelenebilmesi için tutulur.
+}
+
+// çalışan instance üzerinde yüklü olan bütün env maps ve pub keys
+type EnvStoreData struct {
+	EnvMapInfos map[string]EnvStoreMapData `cbor:"1,keyasint"`
+	PubKeyInfos map[string]PubKeyData      `cbor:"2,keyasint"`
+	StatusInfos StatusData                 `cbor:"3,keyasint"` //CreatedAt dışa aktarım zamanıdır.
+}
+
+/*
+- system signer ile imzalanan store bundle file formatı
+- CID: StoreInfos canonical cbor cid v1 bilgisi
+- SignatureInfos: StoreInfos canonical cbor datasının system signer ile oluşturulan imzası
+*/
+type EnvStoreBundleData struct {
+	StoreInfos     EnvStoreData  `cbor:"1,keyasint"`
+	CID            []byte        `cbor:"2,keyasint"`
+	SignatureInfos SignatureData `cbor:"3,keyasint"`
+}
+
+//*******env store bundle*******
+
 // sistem setup config
 type SetupConfigData struct {
 	SystemTag         string          `cbor:"1,keyasint" yaml:"system-tag"`
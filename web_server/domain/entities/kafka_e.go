@@ -0,0 +1,301 @@
+package entities
+
+// ********kafka acl********
+// kafka acl tanımı kafka isimlendirmesi ile tutulur. Ex: ResourceType: TOPIC, Operation: READ
+type KafkaACLData struct {
+	Principal    string `cbor:"1,keyasint" json:"principal"`
+	Host         string `cbor:"2,keyasint" json:"host"`
+	ResourceType string `cbor:"3,keyasint" json:"resource-type"`
+	ResourceName string `cbor:"4,keyasint" json:"resource-name"`
+	PatternType  string `cbor:"5,keyasint" json:"pattern-type"`
+	Operation    string `cbor:"6,keyasint" json:"operation"`
+	Permission   string `cbor:"7,keyasint" json:"permission"`
+}
+
+// broker üzerindeki acl ile istenen acl arasındaki fark
+type KafkaACLPlanData struct {
+	Create []KafkaACLData `cbor:"1,keyasint" json:"create"`
+	Delete []KafkaACLData `cbor:"2,keyasint" json:"delete"`
+}
+
+type SyncKafkaACLInput struct {
+	OwnerAuthnInfos map[string]AuthnData //map[whitelist_key] => owner AuthnData
+	DryRun          bool
+}
+
+// ********kafka acl********
+
+// ********kafka scram********
+type KafkaScramSecretData struct {
+	Iterations     int32
+	Salt           []byte
+	SaltedPassword []byte
+}
+
+// broker üzerinde scram credential bulunan kullanıcı bilgisi
+type KafkaScramUserData struct {
+	UserName   string
+	Mechanism  string
+	Iterations int32
+}
+
+type UpsertKafkaScramInput struct {
+	UserName   string
+	Mechanism  string
+	SecretInfo KafkaScramSecretData
+}
+
+// owner için mühürlenmiş credential store üzerinde tutulur. plaintext parola hiçbir yerde tutulmaz.
+type KafkaScramCredentialData struct {
+	UserName       string     `cbor:"1,keyasint"`
+	Mechanism      string     `cbor:"2,keyasint"`
+	Iterations     int32      `cbor:"3,keyasint"`
+	SealedPassword []byte     `cbor:"4,keyasint"` //owner x25519 key ile mühürlenmiş parola
+	StatusInfo     StatusData `cbor:"5,keyasint"` //ExpiresAt: credential rotation zamanı
+}
+
+// whitelist owner bilgisinin scram planlaması için gereken kısmı
+type KafkaScramOwnerData struct {
+	UserName  string
+	Active    bool
+	ExpiresAt int64
+}
+
+type PlanKafkaScramInput struct {
+	OwnerInfos      []KafkaScramOwnerData
+	CredentialInfos map[string]KafkaScramCredentialData //map[user_name] => store üzerindeki credential
+	BrokerUserInfos []KafkaScramUserData
+	CheckUnixTime   int64
+}
+
+type KafkaScramPlanData struct {
+	Issue  []string `cbor:"1,keyasint" json:"issue"`
+	Rotate []string `cbor:"2,keyasint" json:"rotate"`
+	Revoke []string `cbor:"3,keyasint" json:"revoke"`
+}
+
+// ********kafka scram********
+
+// ********kafka server properties********
+// broker server.properties içerisinde sistem tarafından yönetilen alanlar. Modellenmeyen alanlar ExtraInfos içerisinde tutulur.
+type KafkaServerPropertiesData struct {
+	BrokerID                             int32             `cbor:"1,keyasint" json:"broker-id"`
+	Listeners                            []string          `cbor:"2,keyasint" json:"listeners"`            //PLAINTEXT://0.0.0.0:9092
+	AdvertisedListeners                  []string          `cbor:"3,keyasint" json:"advertised-listeners"` //PLAINTEXT://kafka-broker1:9092
+	ListenerSecurityProtocolMap          map[string]string `cbor:"4,keyasint" json:"listener-security-protocol-map"`
+	InterBrokerListenerName              string            `cbor:"5,keyasint" json:"inter-broker-listener-name"`
+	LogDirs                              []string          `cbor:"6,keyasint" json:"log-dirs"`
+	NumPartitions                        int32             `cbor:"7,keyasint" json:"num-partitions"`
+	DefaultReplicationFactor             int32             `cbor:"8,keyasint" json:"default-replication-factor"`
+	OffsetsTopicReplicationFactor        int32             `cbor:"9,keyasint" json:"offsets-topic-replication-factor"`
+	TransactionStateLogReplicationFactor int32             `cbor:"10,keyasint" json:"transaction-state-log-replication-factor"`
+	TransactionStateLogMinISR            int32             `cbor:"11,keyasint" json:"transaction-state-log-min-isr"`
+	SaslEnabledMechanisms                []string          `cbor:"12,keyasint" json:"sasl-enabled-mechanisms"`
+	SaslMechanismInterBrokerProtocol     string            `cbor:"13,keyasint" json:"sasl-mechanism-inter-broker-protocol"`
+	ZookeeperConnect                     []string          `cbor:"14,keyasint" json:"zookeeper-connect"` //zookeeper1:2181
+	ZookeeperConnectionTimeoutMs         int32             `cbor:"15,keyasint" json:"zookeeper-connection-timeout-ms"`
+	ExtraInfos                           map[string]string `cbor:"16,keyasint" json:"extra"` //map[property_key] => value
+}
+
+type ValidateKafkaServerPropertiesInput struct {
+	PropertiesInfo KafkaServerPropertiesData
+	BrokerCount    int //cluster içerisindeki broker sayısı, replication factor bu sayıyı aşamaz.
+}
+
+/*
+- env map üzerinden broker server.properties üretilir.
+- CommonInfos bütün broker'lar için ortak alanlar, BrokerInfos broker'a özel alanlar. Aynı key için BrokerInfos geçerlidir.
+*/
+type BuildKafkaServerPropertiesInput struct {
+	BrokerID    int32
+	CommonInfos map[string]string
+	BrokerInfos map[string]string
+}
+
+// ********kafka server properties********
+
+// ********kafka topic********
+type KafkaTopicData struct {
+	Name              string            `cbor:"1,keyasint" json:"name"`
+	Partitions        int32             `cbor:"2,keyasint" json:"partitions"`
+	ReplicationFactor int16             `cbor:"3,keyasint" json:"replication-factor"`
+	Configs           map[string]string `cbor:"4,keyasint" json:"configs,omitempty"` //sadece topic üzerinde tanımlanan configs
+}
+
+// topic oluşturma isteği. Topic mevcut ve ayarları aynı ise değişiklik yapılmaz.
+type CreateKafkaTopicInput struct {
+	Name              string            `cbor:"1,keyasint" json:"name"`
+	Partitions        int32             `cbor:"2,keyasint" json:"partitions"`
+	ReplicationFactor int16             `cbor:"3,keyasint" json:"replication-factor"`
+	Configs           map[string]string `cbor:"4,keyasint" json:"configs,omitempty"`
+}
+
+type AlterKafkaTopicConfigInput struct {
+	Name          string            `cbor:"1,keyasint" json:"-"` //path üzerinden alınır
+	SetConfigs    map[string]string `cbor:"2,keyasint" json:"set,omitempty"`
+	DeleteConfigs []string          `cbor:"3,keyasint" json:"delete,omitempty"`
+}
+
+// topic isimlendirme ve partition politikası, sistem tarafından imzalanan env map üzerinden okunur.
+type KafkaTopicPolicyData struct {
+	NamePattern          string
+	ReservedPrefixes     []string
+	MinPartitions        int32
+	MaxPartitions        int32
+	MinReplicationFactor int16
+	MaxReplicationFactor int16
+	AllowedConfigs       []string
+}
+
+// Changed: işlem sonucunda broker üzerinde değişiklik oldu mu
+type KafkaTopicResultData struct {
+	TopicInfo KafkaTopicData `cbor:"1,keyasint" json:"topic"`
+	Changed   bool           `cbor:"2,keyasint" json:"changed"`
+}
+
+// ********kafka topic********
+
+// ********kafka rest proxy********
+type KafkaRecordHeaderData struct {
+	Key   string `cbor:"1,keyasint" json:"key"`
+	Value []byte `cbor:"2,keyasint" json:"value"`
+}
+
+// json formatında Key ve Value base64 olarak gönderilir.
+type KafkaProduceRecordData struct {
+	Key     []byte                  `cbor:"1,keyasint" json:"key,omitempty"`
+	Value   []byte                  `cbor:"2,keyasint" json:"value"`
+	Headers []KafkaRecordHeaderData `cbor:"3,keyasint" json:"headers,omitempty"`
+}
+
+// Sign true ise her record value system tarafından imzalanan envelope içerisine alınır.
+type ProduceKafkaRecordsInput struct {
+	Topic   string                   `cbor:"1,keyasint" json:"-"` //path üzerinden alınır
+	Records []KafkaProduceRecordData `cbor:"2,keyasint" json:"records"`
+	Sign    bool                     `cbor:"3,keyasint" json:"sign,omitempty"`
+}
+
+type KafkaRecordOffsetData struct {
+	Topic     string `cbor:"1,keyasint" json:"topic"`
+	Partition int32  `cbor:"2,keyasint" json:"partition"`
+	Offset    int64  `cbor:"3,keyasint" json:"offset"`
+}
+
+type KafkaConsumedRecordData struct {
+	Topic     string                  `cbor:"1,keyasint" json:"topic"`
+	Partition int32                   `cbor:"2,keyasint" json:"partition"`
+	Offset    int64                   `cbor:"3,keyasint" json:"offset"`
+	Key       []byte                  `cbor:"4,keyasint" json:"key,omitempty"`
+	Value     []byte                  `cbor:"5,keyasint" json:"value"`
+	Headers   []KafkaRecordHeaderData `cbor:"6,keyasint" json:"headers,omitempty"`
+	Timestamp int64                   `cbor:"7,keyasint" json:"timestamp"` //unix milli
+}
+
+// OffsetReset: earliest, latest. Group üzerinde commit edilmiş offset yoksa kullanılır.
+type CreateKafkaConsumerInput struct {
+	Group       string   `cbor:"1,keyasint" json:"group"`
+	Topics      []string `cbor:"2,keyasint" json:"topics"`
+	OffsetReset string   `cbor:"3,keyasint" json:"offset-reset,omitempty"`
+}
+
+type KafkaConsumerInstanceData struct {
+	InstanceID string   `cbor:"1,keyasint" json:"instance-id"`
+	Group      string   `cbor:"2,keyasint" json:"group"`
+	Topics     []string `cbor:"3,keyasint" json:"topics"`
+}
+
+type PollKafkaConsumerInput struct {
+	InstanceID string `cbor:"1,keyasint" json:"-"`
+	MaxRecords int    `cbor:"2,keyasint" json:"max-records"`
+	TimeoutMs  int64  `cbor:"3,keyasint" json:"timeout-ms"`
+}
+
+// Offsets boş ise son poll ile alınan bütün records commit edilir.
+type CommitKafkaOffsetsInput struct {
+	InstanceID string                  `cbor:"1,keyasint" json:"-"`
+	Offsets    []KafkaRecordOffsetData `cbor:"2,keyasint" json:"offsets,omitempty"`
+}
+
+// owner bazlı rest proxy limitleri. 0 değeri limit uygulanmayacağı anlamına gelir.
+type KafkaProxyQuotaData struct {
+	MaxBatchRecords    int   `cbor:"1,keyasint"`
+	MaxBatchBytes      int   `cbor:"2,keyasint"`
+	ProduceBytesPerSec int64 `cbor:"3,keyasint"`
+	MaxConsumers       int   `cbor:"4,keyasint"`
+	MaxPollRecords     int   `cbor:"5,keyasint"`
+}
+
+/*
+- imzalı record envelope. Record value cbor(KafkaRecordEnvelopeData) olarak yazılır.
+- imza EnvelopeInfos canonical cbor karşılığı üzerinden system signer ile üretilir.
+*/
+type KafkaEnvelopeInfoData struct {
+	Producer  string `cbor:"1,keyasint" json:"producer"` //record üreten owner whitelist key
+	Topic     string `cbor:"2,keyasint" json:"topic"`
+	Key       []byte `cbor:"3,keyasint" json:"key,omitempty"`
+	Value     []byte `cbor:"4,keyasint" json:"value"`
+	CreatedAt int64  `cbor:"5,keyasint" json:"created-at"`
+}
+
+type KafkaRecordEnvelopeData struct {
+	EnvelopeInfos  KafkaEnvelopeInfoData `cbor:"1,keyasint" json:"envelope"`
+	SignatureInfos SignatureData         `cbor:"2,keyasint" json:"signature"`
+}
+
+// ********kafka rest proxy********
+
+// ********kafka metrics********
+// main env üzerinden okunan metrics hedefleri. Groups içerisindeki consumer group'ların commit ettiği topics ayrıca eklenir.
+type KafkaMetricsTargetData struct {
+	Groups []string `cbor:"1,keyasint"`
+	Topics []string `cbor:"2,keyasint"`
+}
+
+type KafkaPartitionMetricsData struct {
+	Partition      int32 `cbor:"1,keyasint"`
+	Replicas       int   `cbor:"2,keyasint"`
+	InSyncReplicas int   `cbor:"3,keyasint"`
+	EndOffset      int64 `cbor:"4,keyasint"` //log end offset, bilinmiyorsa -1
+}
+
+type KafkaTopicMetricsData struct {
+	Name           string                      `cbor:"1,keyasint"`
+	PartitionInfos []KafkaPartitionMetricsData `cbor:"2,keyasint"`
+}
+
+// ********kafka metrics********
+
+// ********kafka topic encryption********
+// topic data key alıcının x25519 pub key bilgisi ile mühürlenir.
+type KafkaSealedDataKeyData struct {
+	OwnerKey      string `cbor:"1,keyasint" json:"owner"`
+	SealedDataKey []byte `cbor:"2,keyasint" json:"sealed-data-key"`
+	X25519PubKey  []byte `cbor:"3,keyasint" json:"x25519-pub-key"` //data key mühürlenirken kullanılan alıcı key
+}
+
+type KafkaTopicKeyData struct {
+	Version        uint32                   `cbor:"1,keyasint" json:"version"`
+	SealedKeyInfos []KafkaSealedDataKeyData `cbor:"2,keyasint" json:"sealed-keys"`
+	StatusInfo     StatusData               `cbor:"3,keyasint" json:"status"`
+}
+
+// KeyInfos version sırasına göre tutulur, son key aktif key'dir. Eski keys daha önce yazılmış records için saklanır.
+type KafkaTopicKeyRingInfoData struct {
+	Topic    string              `cbor:"1,keyasint" json:"topic"`
+	KeyInfos []KafkaTopicKeyData `cbor:"2,keyasint" json:"keys"`
+}
+
+// key ring system signer ile imzalanır, imzası doğrulanamayan key ring ile şifreleme yapılmaz.
+type KafkaTopicKeyRingData struct {
+	KeyRingInfos   KafkaTopicKeyRingInfoData `cbor:"1,keyasint"`
+	SignatureInfos SignatureData             `cbor:"2,keyasint"`
+}
+
+// şifrelenmiş record value. Topic, key version ve record key bilgisi additional data olarak doğrulanır.
+type KafkaEncryptedRecordData struct {
+	KeyVersion uint32 `cbor:"1,keyasint" json:"key-version"`
+	Nonce      []byte `cbor:"2,keyasint" json:"nonce"`
+	Ciphertext []byte `cbor:"3,keyasint" json:"ciphertext"`
+}
+
+// ********kafka topic encryption********
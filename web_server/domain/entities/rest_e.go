@@ -0,0 +1,18 @@
+package entities
+
+// ********rest********
+/*
+- api hata cevabı, Accept header bilgisine göre json ya da cbor olarak döner.
+- Code ve Name sabittir, client tarafında hata ayrımı mesaj yerine bu fields üzerinden yapılır.
+- cause bir func error ise Cause üzerinde iç içe yer alır.
+*/
+type ErrorResponseData struct {
+	Error    string             `cbor:"1,keyasint" json:"error"`
+	Code     int                `cbor:"2,keyasint" json:"code"`
+	Name     string             `cbor:"3,keyasint" json:"name"`
+	Severity string             `cbor:"4,keyasint" json:"severity"`
+	Fields   []string           `cbor:"5,keyasint,omitempty" json:"fields,omitempty"`
+	Cause    *ErrorResponseData `cbor:"6,keyasint,omitempty" json:"cause,omitempty"`
+}
+
+// ********rest********
@@ -0,0 +1,51 @@
+package entities
+
+// ********zookeeper ensemble********
+// ensemble üyesi dynamic config formatı: server.<id>=<host>:<quorum_port>:<election_port>:<role>;<client_address>:<client_port>
+type ZookeeperMemberData struct {
+	ID            int32  `cbor:"1,keyasint" json:"id"`
+	Host          string `cbor:"2,keyasint" json:"host"`
+	QuorumPort    int    `cbor:"3,keyasint" json:"quorum-port"`
+	ElectionPort  int    `cbor:"4,keyasint" json:"election-port"`
+	Role          string `cbor:"5,keyasint" json:"role"` //participant, observer
+	ClientAddress string `cbor:"6,keyasint" json:"client-address"`
+	ClientPort    int    `cbor:"7,keyasint" json:"client-port"`
+}
+
+type ZookeeperEnsembleData struct {
+	MemberInfos []ZookeeperMemberData `cbor:"1,keyasint" json:"members"`
+	Version     int64                 `cbor:"2,keyasint" json:"version"` //dynamic config version, 0 ise yazılmaz.
+}
+
+// zoo.cfg static alanları. Ensemble üyeleri dynamic config file içerisinde barınır.
+type RenderZooConfigInput struct {
+	DynamicConfigFile string            //container içerisindeki dynamic config file path
+	PropertyInfos     map[string]string //map[property_key] => value
+}
+
+// tek adımda ensemble üzerinde yapılacak üyelik değişikliği
+type ZookeeperReconfigStepData struct {
+	Action       string              `cbor:"1,keyasint" json:"action"` //add, remove, update
+	MemberInfo   ZookeeperMemberData `cbor:"2,keyasint" json:"member"`
+	VotersBefore int                 `cbor:"3,keyasint" json:"voters-before"`
+	VotersAfter  int                 `cbor:"4,keyasint" json:"voters-after"`
+}
+
+// çalışan ensemble üyesinin four letter words üzerinden alınan durumu
+type ZookeeperMemberStatusData struct {
+	ID              int32  `cbor:"1,keyasint" json:"id"`
+	Address         string `cbor:"2,keyasint" json:"address"`
+	Mode            string `cbor:"3,keyasint" json:"mode"` //leader, follower, observer, standalone
+	Zxid            string `cbor:"4,keyasint" json:"zxid"`
+	SyncedFollowers int    `cbor:"5,keyasint" json:"synced-followers"` //sadece leader için
+	ConfigMatches   bool   `cbor:"6,keyasint" json:"config-matches"`
+	Error           string `cbor:"7,keyasint" json:"error,omitempty"`
+}
+
+type ZookeeperVerifyReportData struct {
+	MemberStatusInfos []ZookeeperMemberStatusData `cbor:"1,keyasint" json:"members"`
+	LeaderID          int32                       `cbor:"2,keyasint" json:"leader-id"`
+	Healthy           bool                        `cbor:"3,keyasint" json:"healthy"`
+}
+
+// ********zookeeper ensemble********
@@ -0,0 +1,18 @@
+package processors
+
+// internal-env-keys
+const (
+	AuditEnvTag = `audit-env-tag`
+
+	AuditResultSuccess = `success`
+	AuditResultFailure = `failure`
+
+	AuditKafkaTopicCreate      = `kafka-topic-create`
+	AuditKafkaTopicAlterConfig = `kafka-topic-alter-config`
+	AuditKafkaTopicDelete      = `kafka-topic-delete`
+	AuditKafkaTopicRotateKey   = `kafka-topic-rotate-key`
+
+	AuditEnvQuarantinePurge = `env-quarantine-purge`
+)
+
+// internal-env-keys
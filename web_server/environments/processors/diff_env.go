@@ -0,0 +1,20 @@
+package processors
+
+// internal-env-keys
+const (
+	//env maps arasındaki key ve field değişiklikleri
+	EnvMapKeyAdded    = `added`
+	EnvMapKeyRemoved  = `removed`
+	EnvMapKeyModified = `modified`
+
+	//karşılaştırılan env maps kaynakları. Ex: revision:12
+	EnvDiffSourceLive           = `live`
+	EnvDiffSourceCandidate      = `candidate`
+	EnvDiffSourceRevisionPrefix = `revision:`
+
+	//diff cevabı format=text ile okunabilir metin olarak döner, varsayılan Accept header bilgisine göre json ya da cbor formatıdır.
+	EnvDiffFormatQuery = `format`
+	EnvDiffFormatText  = `text`
+)
+
+// internal-env-keys
@@ -0,0 +1,28 @@
+package processors
+
+import "slices"
+
+// internal-env-keys
+const (
+	//dry run ile doğrulanabilecek file türleri
+	EnvDryRunKindEnvMap     = `env-map`
+	EnvDryRunKindWhitelist  = `whitelist`
+	EnvDryRunKindAccessData = `access-data`
+)
+
+// dry run ile doğrulanabilecek system tarafından imzalanan env map fields
+var DryRunEnvMapFieldSlice []string = []string{
+	EnvSchemaEnvMapField,
+	MainEnvMapField,
+	KafkaTopicPolicyEnvMapField,
+	FuncErrorEnvMapField,
+}
+
+// env map içerisinde bulunabilecek keys. Main env kafka ve zookeeper keys de barındırır.
+var EnvMapKeyRefSliceInfos map[string][]string = map[string][]string{
+	MainEnvMapField:             slices.Concat(MainEnvKeyRefSlice, KafkaEnvKeyRefSlice, ZookeeperEnvKeyRefSlice),
+	KafkaTopicPolicyEnvMapField: KafkaTopicPolicyEnvKeyRefSlice,
+	FuncErrorEnvMapField:        FuncErrorEnvKeyRefSlice,
+}
+
+// internal-env-keys
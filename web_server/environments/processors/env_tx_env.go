@@ -0,0 +1,156 @@
+package processors
+
+import (
+	"maps"
+	e "web_server/domain/entities"
+)
+
+/*
+- birden fazla env map ve pub key üzerindeki değişiklikler EnvTx üzerinde sırayla biriktirilir.
+- Commit ile değişiklikler yeni store snapshot üzerine uygulanır. Herhangi bir değişiklik hata verirse hiçbir değişiklik yayınlanmaz.
+- okuyanlar ya bütün değişikliklerden önceki ya da sonraki store snapshot görür, yarım uygulanmış durum görülmez.
+- aynı transaction içerisindeki değişiklikler önceki değişikliklerin sonucunu görür. Ex: TxSetNewEnvMap sonrası TxUpdateEnvMap
+*/
+type EnvTx struct {
+	ops []func(next *envStoreSnapshot) error
+}
+
+func NewEnvTx() *EnvTx {
+	return &EnvTx{}
+}
+
+// env map input map kopyalanarak eklenir, çağıranın map üzerindeki sonraki değişiklikleri transaction etkilemez.
+func newEnvMapData[K comparable, V any](input e.EnvMapData[K, V]) e.EnvMapData[K, V] {
+	input.EnvInfos = maps.Clone(input.EnvInfos)
+	return input
+}
+
+// TxSetNewEnvMap env map mevcut değilse eklenmesi için transaction üzerine kaydedilir.
+func TxSetNewEnvMap[K comparable, V any](tx *EnvTx, envMapKey string, input e.EnvMapData[K, V]) {
+	data := newEnvMapData(input)
+	tx.ops = append(tx.ops, func(next *envStoreSnapshot) error {
+		if _, exists := next.envMaps[envMapKey]; exists {
+			return GetFuncError(EnvMapKeyAlreadyExists, nil, envMapKey)
+		}
+		next.setEnvMap(envMapKey, data)
+		return nil
+	})
+}
+
+// TxUpdateEnvMap varolan env map aynı tür ile güncellenmesi için transaction üzerine kaydedilir.
+func TxUpdateEnvMap[K comparable, V any](tx *EnvTx, envMapKey string, input e.EnvMapData[K, V]) {
+	data := newEnvMapData(input)
+	tx.ops = append(tx.ops, func(next *envStoreSnapshot) error {
+		current, exists := next.envMaps[envMapKey]
+		if !exists {
+			return GetFuncError(EnvMapKeyNotFound, nil, envMapKey)
+		}
+		if _, valid := current.data.(e.EnvMapData[K, V]); !valid {
+			return GetFuncError(EnvMapTypeMismatch, nil)
+		}
+		next.setEnvMap(envMapKey, data)
+		return nil
+	})
+}
+
+// TxPutEnvMap env map yoksa eklenmesi, varsa aynı tür ile güncellenmesi için transaction üzerine kaydedilir.
+func TxPutEnvMap[K comparable, V any](tx *EnvTx, envMapKey string, input e.EnvMapData[K, V]) {
+	data := newEnvMapData(input)
+	tx.ops = append(tx.ops, func(next *envStoreSnapshot) error {
+		if current, exists := next.envMaps[envMapKey]; exists {
+			if _, valid := current.data.(e.EnvMapData[K, V]); !valid {
+				return GetFuncError(EnvMapTypeMismatch, nil)
+			}
+		}
+		next.setEnvMap(envMapKey, data)
+		return nil
+	})
+}
+
+// DeleteEnvMap env map silinmesi için transaction üzerine kaydedilir. Env map yoksa işlem yapılmaz.
+func (tx *EnvTx) DeleteEnvMap(envMapKey string) {
+	tx.ops = append(tx.ops, func(next *envStoreSnapshot) error {
+		delete(next.envMaps, envMapKey)
+		return nil
+	})
+}
+
+// SetNewPubKey pub key mevcut değilse eklenmesi için transaction üzerine kaydedilir.
+func (tx *EnvTx) SetNewPubKey(ownerKey string, data e.PubKeyData) {
+	newData := clonePubKeyData(data)
+	tx.ops = append(tx.ops, func(next *envStoreSnapshot) error {
+		if _, exists := next.pubKeys[ownerKey]; exists {
+			return GetFuncError(OwnerKeyAlreadyExists, nil, ownerKey)
+		}
+		next.pubKeys[ownerKey] = newData
+		return nil
+	})
+}
+
+// UpdatePubKey varolan pub key güncellenmesi için transaction üzerine kaydedilir.
+func (tx *EnvTx) UpdatePubKey(ownerKey string, data e.PubKeyData) {
+	newData := clonePubKeyData(data)
+	tx.ops = append(tx.ops, func(next *envStoreSnapshot) error {
+		if _, exists := next.pubKeys[ownerKey]; !exists {
+			return GetFuncError(InvalidOwnerKey, nil, ownerKey)
+		}
+		next.pubKeys[ownerKey] = newData
+		return nil
+	})
+}
+
+// DeletePubKey pub key silinmesi için transaction üzerine kaydedilir.
+func (tx *EnvTx) DeletePubKey(ownerKey string) {
+	tx.ops = append(tx.ops, func(next *envStoreSnapshot) error {
+		delete(next.pubKeys, ownerKey)
+		return nil
+	})
+}
+
+// store üzerinde env map ya da pub key varsa transaction hata verir. Store içeri aktarımı gibi boş instance gerektiren işlemler için kullanılır.
+func (tx *EnvTx) RequireEmptyStore() {
+	tx.ops = append(tx.ops, func(next *envStoreSnapshot) error {
+		if len(next.envMaps) > 0 || len(next.pubKeys) > 0 {
+			return GetFuncError(EnvStoreNotEmpty, nil)
+		}
+		return nil
+	})
+}
+
+/*
+- biriken değişiklikler mevcut store kopyası üzerine sırayla uygulanır ve yeni store tek seferde yayınlanır.
+- hata durumunda mevcut store değişmez. Commit sonrası transaction boşaltılır.
+*/
+func (tx *EnvTx) Commit() error {
+	ops := tx.ops
+	tx.ops = nil
+	if len(ops) == 0 {
+		return nil
+	}
+
+	envStoreWriteLock.Lock()
+	defer envStoreWriteLock.Unlock()
+
+	current := loadEnvStore()
+	next := &envStoreSnapshot{
+		envMaps: make(map[string]*envMapSnapshot, len(current.envMaps)+1),
+		pubKeys: make(map[string]*e.PubKeyData, len(current.pubKeys)+1),
+	}
+	maps.Copy(next.envMaps, current.envMaps)
+	maps.Copy(next.pubKeys, current.pubKeys)
+
+	for _, op := range ops {
+		if err := op(next); err != nil {
+			return err
+		}
+	}
+	next.history = nextEnvMapHistory(current, next)
+
+	envStore.Store(next)
+	return nil
+}
+
+// env map yeni revision ile store üzerine yazılır. Sadece Commit içerisinde çağrılır.
+func (next *envStoreSnapshot) setEnvMap(envMapKey string, data any) {
+	next.envMaps[envMapKey] = &envMapSnapshot{data: data, revision: envMapRevision.Add(1)}
+}
@@ -20,7 +20,6 @@ const (
 	DefaultSHALength = -1 //default sha uzunluğunda üretim sağlar 32byte
 
 	Exit                      = `ex`
-	EndEnvMapField            = `end-env-map-field`
 	RandStringKeySpecialChars = `!@#$%^&*()-_=+[]{}|;:'\",.<>?/`
 	RandStringKeyChars        = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 	RandInputMaxCount         = 33
@@ -46,19 +45,35 @@ var FuncEnvKeyRefSlice []string = []string{}
 
 // external-env-keys
 
+// func status tanımı. Mesaj icon ve format ile üretilir, fields varsa boşluk ile ayrılarak sona eklenir.
+type funcStatusDef struct {
+	name   string
+	icon   string
+	format string
+}
+
+var funcStatusDefs = map[int]funcStatusDef{
+	OK:              {name: `success`, icon: `🟢 `, format: `success`},
+	SpecificOK:      {name: `specific-success`, icon: `🟢 `, format: `success`},
+	NotOK:           {name: `unsuccessful`, icon: `⚫ `, format: `unsuccessful`},
+	SpecificNotOK:   {name: `specific-unsuccessful`, icon: `⚫ `, format: `unsuccessful`},
+	SuccessIncluded: {name: `success-included`, icon: `🟢 `, format: `successfully included:`},
+}
+
 func GetFuncStatus(code int, fields ...string) string {
-	switch code {
-	case OK:
-		return `🟢 success`
-	case SpecificOK:
-		return `🟢 success ` + strings.Join(fields, " ")
-	case NotOK:
-		return `⚫ unsuccessful`
-	case SpecificNotOK:
-		return `⚫ unsuccessful ` + strings.Join(fields, " ")
-	case SuccessIncluded:
-		return `🟢 successfully included: ` + fields[0]
-	default:
-		return GetFuncError(InvalidFuncStatusCode, nil).Error()
+	return GetLocalizedFuncStatus(DefaultLocale, code, fields...)
+}
+
+// status mesajı locale catalog üzerindeki format ile üretilir. Catalog ya da locale yoksa varsayılan format kullanılır.
+func GetLocalizedFuncStatus(locale string, code int, fields ...string) string {
+	def, ok := funcStatusDefs[code]
+	if !ok {
+		return GetFuncError(InvalidFuncStatusCode, nil).(*FuncError).Message(locale)
+	}
+
+	message := def.icon + funcStatusFormat(locale, def.name, def.format)
+	if len(fields) > 0 {
+		message += ` ` + strings.Join(fields, " ")
 	}
+	return message
 }
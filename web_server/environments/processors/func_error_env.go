@@ -3,6 +3,8 @@ package processors
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	e "web_server/domain/entities"
 )
 
 // internal-env-keys
@@ -52,6 +54,54 @@ const (
 	UnSupportedDataType
 	AllFieldsRequired
 	AllFieldsRequiredWithInvalidKey
+	TrustAlreadyEstablished
+	TrustStateMismatch
+	KafkaOperationFailed
+	InvalidKafkaACL
+	InvalidX25519Key
+	InvalidSealedData
+	InvalidScramMechanism
+	InvalidSignerKey
+	InvalidServerProperties
+	InvalidZookeeperEnsemble
+	UnsafeZookeeperReconfig
+	ZookeeperCommandFailed
+	ZookeeperEnsembleMismatch
+	KafkaTopicNotFound
+	KafkaTopicConflict
+	InvalidKafkaTopic
+	InvalidRequestBody
+	MissingOwnerAccess
+	AuditWriteFailed
+	KafkaQuotaExceeded
+	KafkaConsumerNotFound
+	InvalidKafkaEnvelope
+	UnsupportedEnvMapField
+	StaleEnvMapUpdate
+	KafkaTopicKeyNotFound
+	InvalidKafkaEncryptedRecord
+	OwnerAuthenticationFailed
+	SignerNotConfigured
+	EnvValidationFailed
+	InvalidEnvSchema
+	EnvSchemaViolation
+	InvalidEnvStoreBundle
+	EnvStoreNotEmpty
+	EnvSecretAccessDenied
+	EnvSecretKeyNotFound
+	InvalidEnvLayer
+	EnvMapDependencyCycle
+	EnvMapDependencyNotFound
+	InvalidEnvReference
+	EnvReferenceCycle
+	UnsupportedEnvDryRun
+	UnknownEnvMapKey
+	EnvQuarantineNotFound
+	InvalidEnvQuarantineID
+	EnvMapRevisionNotFound
+	InvalidEnvMapDiff
+	SystemPubKeyMismatch
+	TrustedSystemPubKeyNotFound
 )
 
 // internal-env-keys
@@ -60,103 +110,298 @@ const (
 const ()
 
 // func error env external olarak içeri aktarılacak env map içerisinde barınan keys doğrulamak için reference alınacak slice
-var FuncErrorEnvKeyRefSlice []string = []string{}
+// keys locale bilgisidir, her locale value cbor(FuncMessageCatalogData) olarak tutulur.
+var FuncErrorEnvKeyRefSlice []string = []string{
+	LocaleEN,
+	LocaleTR,
+}
 
 // external-env-keys
 
-func GetFuncError(code int, err error, fields ...any) error {
+// hata önem derecesi. Mesaj önüne 🟡 (warning) ya da 🔴 (critical) olarak eklenir.
+type Severity uint8
+
+const (
+	SeverityWarning Severity = iota + 1
+	SeverityCritical
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return `warning`
+	case SeverityCritical:
+		return `critical`
+	default:
+		return `unknown`
+	}
+}
 
-	switch code {
-	//***dinamic errors***
-
-	//***dinamic errors***
-
-	//*******static errors*******
-	case SystemPathEnvNotFound:
-		return fmt.Errorf("🔴 system path env file not found")
-	case InvalidPathKey:
-		return fmt.Errorf("🟡 invalid path key")
-	case FileNotFound:
-		return fmt.Errorf("🟡 file does not exist at path: %s", fields[0])
-	case PermissionDenied:
-		return fmt.Errorf("🟡 permission denied for path: %s", fields[0])
-	case InvalidNewDataStatus:
-		return errors.New("🟡 the status activity information of newly added data cannot be passive")
-	case InactiveDataStatus:
-		return errors.New("🟡 data status is inactive")
-	case InvalidNewDataExpiresAt:
-		return errors.New("🟡 expiresAt of newly added data cannot be smaller than now and must be assigned a value of 0 to make it unlimited")
-	case DataExpiresAt:
-		return errors.New("🟡 data has expired")
-	case InvalidNewDataActiveAt:
-		return errors.New("🟡 the ActiveAt field for newly created data cannot be in the past or empty")
-	case DataActiveAt:
-		return errors.New("🟡 data is not yet active")
-	case InvalidNewDataStatusDescription:
-		return errors.New("🟡 the status Description field for newly created data cannot be empty or invalid")
-	case RequiredDataStatusDescription:
-		return errors.New("🟡 data status description is required")
-	case GetAllFieldsRequired:
-		return errors.New("🟡 all fields are required in the system environment data")
-	case InvalidFuncStatusCode:
-		return errors.New(`🔴 invalid func status code`)
-	case InvalidSignatureComponents:
-		return errors.New(`🔴 invalid signature components`)
-	case InvalidAccessData:
-		return errors.New(`🔴 invalid access data`)
-	case InvalidTaskAuthn:
-		return fmt.Errorf("🔴 invalid task authn: %s", fields[0])
-	case InvalidSliceElement:
-		return fmt.Errorf("invalid slice element index: %d, error: %v", fields[0], err)
-	case InvalidEnvMapKeySlice:
-		return fmt.Errorf(`reference env map key slice and input env map key slice slice do not match, 
-		env map tag: %s`, fields[0])
-	case NilValueNotAllowed:
-		return fmt.Errorf("the slice variable cannot be nil: %v", fields[0])
-	case UnSupportedDataType:
-		return fmt.Errorf("🟡 unsupported data type: %T", fields[0])
-	case AllFieldsRequired:
-		return errors.New("🟡 all fields are required")
-	case AllFieldsRequiredWithInvalidKey:
-		return fmt.Errorf("🟡 all fields are required, invalid key/index: %v", fields[0])
-	case OwnerKeyAlreadyExists:
-		return fmt.Errorf("🟡 public key owner key already exists: %s", fields[0])
-	case InvalidOwnerKey:
-		return fmt.Errorf("🟡 invalid owner key: %s", fields[0])
-	case InvalidDataType:
-		return fmt.Errorf("🟡 invalid data type: (type=%T)", fields[0])
-	case EnvMapKeyAlreadyExists:
-		return fmt.Errorf("🟡 environment map key already exists: %s", fields[0])
-	case EnvMapKeyNotFound:
-		return fmt.Errorf("🟡 environment map key not found: %s", fields[0])
-	case EnvMapTypeMismatch:
-		return errors.New("env map type mismatch detected")
-	case EnvKeyNotFound:
-		return fmt.Errorf("🟡 env key not found in environment map: key=%v (type=%T)", fields[0], fields[0])
-	case InvalidValue:
-		return fmt.Errorf("🟡 key containing unsupported value: %v", fields[0])
-	case InvalidEnvMap:
-		return fmt.Errorf("🟡 invalid env map: %v", fields[0])
-	case InvalidEnvMapKey:
-		return fmt.Errorf("🟡 invalid env map key: %v", fields[0])
-	case InvalidMapType:
-		return fmt.Errorf("🟡 invalid map type: %v", fields[0])
-	case InvalidMapValueType:
-		return fmt.Errorf("🟡 invalid or unsupported map value type: %v", fields[0])
-	case InvalidMapValue:
-		return fmt.Errorf("🟡 invalid map value: %v, error: %v", fields[0], err)
-	case InvalidSignType:
-		return errors.New("invalid singnature type")
-	case MissingStatusInfo:
-		return fmt.Errorf("🟡 missing status info: %v", fields[0])
-	case InvalidCID:
-		return errors.New("Invalid CID: Must be CID v1 with a SHA2-256 multihash. Please ensure compliance with CID standards.")
-	case InvalidCIDType:
-		return errors.New("invalid CID type")
-	case CIDMismatch:
-		return errors.New("CID Incompatibility: The provided CIDs do not match.")
+func (s Severity) icon() string {
+	switch s {
+	case SeverityWarning:
+		return `🟡 `
+	case SeverityCritical:
+		return `🔴 `
 	default:
-		return errors.New(`🔴 invalid func error code`)
-		//*******static errors*******
+		return ``
+	}
+}
+
+/*
+- func error tanımı. format içerisindeki verbs sırası ile fields, withCause true ise son verb cause ile doldurulur.
+- fields eksik gönderilirse eksik değerler "?" ile doldurulur, hata üretimi panic oluşturmaz.
+- typeFields true ise fields değer yerine tür bilgisi olarak raporlanır.
+*/
+type funcErrorDef struct {
+	name       string
+	severity   Severity
+	status     int
+	format     string
+	fieldCount int
+	withCause  bool
+	typeFields bool
+}
+
+var funcErrorDefs = map[int]funcErrorDef{
+	UnexpectedError:                 {name: `unexpected-error`, severity: SeverityCritical, status: http.StatusInternalServerError, format: `unexpected error`},
+	SystemPathEnvNotFound:           {name: `system-path-env-not-found`, severity: SeverityCritical, status: http.StatusInternalServerError, format: `system path env file not found`},
+	GetAllFieldsRequired:            {name: `get-all-fields-required`, severity: SeverityWarning, status: http.StatusInternalServerError, format: `all fields are required in the system environment data`},
+	FileNotFound:                    {name: `file-not-found`, severity: SeverityWarning, status: http.StatusInternalServerError, format: `file does not exist at path: %v`, fieldCount: 1},
+	PermissionDenied:                {name: `permission-denied`, severity: SeverityWarning, status: http.StatusInternalServerError, format: `permission denied for path: %v`, fieldCount: 1},
+	InvalidPathKey:                  {name: `invalid-path-key`, severity: SeverityWarning, status: http.StatusInternalServerError, format: `invalid path key`},
+	InvalidFuncStatusCode:           {name: `invalid-func-status-code`, severity: SeverityCritical, status: http.StatusInternalServerError, format: `invalid func status code`},
+	InvalidEnvStatus:                {name: `invalid-env-status`, severity: SeverityCritical, status: http.StatusInternalServerError, format: `invalid env status`},
+	InvalidNewDataStatus:            {name: `invalid-new-data-status`, severity: SeverityWarning, status: http.StatusBadRequest, format: `the status activity information of newly added data cannot be passive`},
+	InactiveDataStatus:              {name: `inactive-data-status`, severity: SeverityWarning, status: http.StatusForbidden, format: `data status is inactive`},
+	InvalidNewDataExpiresAt:         {name: `invalid-new-data-expires-at`, severity: SeverityWarning, status: http.StatusBadRequest, format: `expiresAt of newly added data cannot be smaller than now and must be assigned a value of 0 to make it unlimited`},
+	DataExpiresAt:                   {name: `data-expired`, severity: SeverityWarning, status: http.StatusForbidden, format: `data has expired`},
+	InvalidNewDataActiveAt:          {name: `invalid-new-data-active-at`, severity: SeverityWarning, status: http.StatusBadRequest, format: `the ActiveAt field for newly created data cannot be in the past or empty`},
+	DataActiveAt:                    {name: `data-not-yet-active`, severity: SeverityWarning, status: http.StatusForbidden, format: `data is not yet active`},
+	InvalidNewDataStatusDescription: {name: `invalid-new-data-status-description`, severity: SeverityWarning, status: http.StatusBadRequest, format: `the status Description field for newly created data cannot be empty or invalid`},
+	RequiredDataStatusDescription:   {name: `required-data-status-description`, severity: SeverityWarning, status: http.StatusBadRequest, format: `data status description is required`},
+	InvalidSignatureComponents:      {name: `invalid-signature`, severity: SeverityCritical, status: http.StatusUnauthorized, format: `invalid signature components`},
+	InvalidTaskAuthn:                {name: `invalid-task-authn`, severity: SeverityCritical, status: http.StatusForbidden, format: `invalid task authn: %v`, fieldCount: 1},
+	InvalidAccessData:               {name: `invalid-access-data`, severity: SeverityCritical, status: http.StatusUnauthorized, format: `invalid access data`},
+	InvalidSliceElement:             {name: `invalid-slice-element`, severity: SeverityWarning, status: http.StatusBadRequest, format: `invalid slice element index: %v, error: %v`, fieldCount: 1, withCause: true},
+	InvalidSignType:                 {name: `invalid-sign-type`, severity: SeverityWarning, status: http.StatusBadRequest, format: `invalid signature type`},
+	InvalidEnvMapKeySlice:           {name: `invalid-env-map-key-slice`, severity: SeverityWarning, status: http.StatusBadRequest, format: `reference env map key slice and input env map key slice do not match, env map tag: %v`, fieldCount: 1},
+	InvalidValue:                    {name: `invalid-value`, severity: SeverityWarning, status: http.StatusBadRequest, format: `key containing unsupported value: %v`, fieldCount: 1},
+	InvalidEnvMap:                   {name: `invalid-env-map`, severity: SeverityWarning, status: http.StatusBadRequest, format: `invalid env map: %v`, fieldCount: 1},
+	InvalidEnvMapKey:                {name: `invalid-env-map-key`, severity: SeverityWarning, status: http.StatusBadRequest, format: `invalid env map key: %v`, fieldCount: 1},
+	InvalidMapType:                  {name: `invalid-map-type`, severity: SeverityWarning, status: http.StatusBadRequest, format: `invalid map type: %v`, fieldCount: 1},
+	InvalidMapValueType:             {name: `invalid-map-value-type`, severity: SeverityWarning, status: http.StatusBadRequest, format: `invalid or unsupported map value type: %v`, fieldCount: 1},
+	InvalidMapValue:                 {name: `invalid-map-value`, severity: SeverityWarning, status: http.StatusBadRequest, format: `invalid map value: %v, error: %v`, fieldCount: 1, withCause: true},
+	MissingStatusInfo:               {name: `missing-status-info`, severity: SeverityWarning, status: http.StatusBadRequest, format: `missing status info: %v`, fieldCount: 1},
+	NilValueNotAllowed:              {name: `nil-value-not-allowed`, severity: SeverityWarning, status: http.StatusBadRequest, format: `the slice variable cannot be nil: %v`, fieldCount: 1},
+	InvalidDataType:                 {name: `invalid-data-type`, severity: SeverityWarning, status: http.StatusBadRequest, format: `invalid data type: (type=%v)`, fieldCount: 1, typeFields: true},
+	InvalidOwnerKey:                 {name: `invalid-owner-key`, severity: SeverityWarning, status: http.StatusUnauthorized, format: `invalid owner key: %v`, fieldCount: 1},
+	InvalidCID:                      {name: `invalid-cid`, severity: SeverityWarning, status: http.StatusBadRequest, format: `invalid CID: must be CID v1 with a SHA2-256 multihash`},
+	InvalidCIDType:                  {name: `invalid-cid-type`, severity: SeverityWarning, status: http.StatusBadRequest, format: `invalid CID type`},
+	CIDMismatch:                     {name: `cid-mismatch`, severity: SeverityCritical, status: http.StatusConflict, format: `CID incompatibility: the provided CIDs do not match`},
+	OwnerKeyAlreadyExists:           {name: `owner-key-already-exists`, severity: SeverityWarning, status: http.StatusConflict, format: `public key owner key already exists: %v`, fieldCount: 1},
+	EnvMapKeyAlreadyExists:          {name: `env-map-key-already-exists`, severity: SeverityWarning, status: http.StatusConflict, format: `environment map key already exists: %v`, fieldCount: 1},
+	EnvMapKeyNotFound:               {name: `env-map-key-not-found`, severity: SeverityWarning, status: http.StatusNotFound, format: `environment map key not found: %v`, fieldCount: 1},
+	EnvMapTypeMismatch:              {name: `env-map-type-mismatch`, severity: SeverityCritical, status: http.StatusInternalServerError, format: `env map type mismatch detected`},
+	EnvKeyNotFound:                  {name: `env-key-not-found`, severity: SeverityWarning, status: http.StatusNotFound, format: `env key not found in environment map: key=%[1]v (type=%[1]T)`, fieldCount: 1},
+	UnSupportedDataType:             {name: `unsupported-data-type`, severity: SeverityWarning, status: http.StatusBadRequest, format: `unsupported data type: %v, kind: %v`, fieldCount: 2},
+	AllFieldsRequired:               {name: `all-fields-required`, severity: SeverityWarning, status: http.StatusBadRequest, format: `all fields are required`},
+	AllFieldsRequiredWithInvalidKey: {name: `all-fields-required-with-invalid-key`, severity: SeverityWarning, status: http.StatusBadRequest, format: `all fields are required, invalid key/index: %v`, fieldCount: 1},
+	TrustAlreadyEstablished:         {name: `trust-already-established`, severity: SeverityCritical, status: http.StatusConflict, format: `trust already established, bootstrap bundle refused: %v`, fieldCount: 1},
+	TrustStateMismatch:              {name: `trust-state-mismatch`, severity: SeverityCritical, status: http.StatusInternalServerError, format: `loaded data does not match the established trust state: %v`, fieldCount: 1},
+	KafkaOperationFailed:            {name: `kafka-operation-failed`, severity: SeverityCritical, status: http.StatusBadGateway, format: `kafka operation failed: %v, error: %v`, fieldCount: 1, withCause: true},
+	InvalidKafkaACL:                 {name: `invalid-kafka-acl`, severity: SeverityWarning, status: http.StatusBadRequest, format: `invalid kafka acl: %+v`, fieldCount: 1},
+	InvalidX25519Key:                {name: `invalid-x25519-key`, severity: SeverityWarning, status: http.StatusBadRequest, format: `invalid x25519 key, length: %v`, fieldCount: 1},
+	InvalidSealedData:               {name: `invalid-sealed-data`, severity: SeverityCritical, status: http.StatusBadRequest, format: `sealed data could not be opened`},
+	InvalidScramMechanism:           {name: `invalid-scram-mechanism`, severity: SeverityWarning, status: http.StatusBadRequest, format: `invalid scram mechanism: %v`, fieldCount: 1},
+	InvalidSignerKey:                {name: `invalid-signer-key`, severity: SeverityCritical, status: http.StatusInternalServerError, format: `invalid signer key: %v`, fieldCount: 1},
+	InvalidServerProperties:         {name: `invalid-server-properties`, severity: SeverityWarning, status: http.StatusBadRequest, format: `invalid server properties: %v, reason: %v`, fieldCount: 2},
+	InvalidZookeeperEnsemble:        {name: `invalid-zookeeper-ensemble`, severity: SeverityWarning, status: http.StatusBadRequest, format: `invalid zookeeper ensemble: %v`, fieldCount: 1},
+	UnsafeZookeeperReconfig:         {name: `unsafe-zookeeper-reconfig`, severity: SeverityCritical, status: http.StatusConflict, format: `unsafe zookeeper reconfig: %v`, fieldCount: 1},
+	ZookeeperCommandFailed:          {name: `zookeeper-command-failed`, severity: SeverityCritical, status: http.StatusBadGateway, format: `zookeeper command failed: %v, error: %v`, fieldCount: 1, withCause: true},
+	ZookeeperEnsembleMismatch:       {name: `zookeeper-ensemble-mismatch`, severity: SeverityCritical, status: http.StatusConflict, format: `running zookeeper ensemble does not match rendered config: %v`, fieldCount: 1},
+	KafkaTopicNotFound:              {name: `kafka-topic-not-found`, severity: SeverityWarning, status: http.StatusNotFound, format: `kafka topic not found: %v`, fieldCount: 1},
+	KafkaTopicConflict:              {name: `kafka-topic-conflict`, severity: SeverityWarning, status: http.StatusConflict, format: `kafka topic already exists with different settings: %v, %v`, fieldCount: 2},
+	InvalidKafkaTopic:               {name: `invalid-kafka-topic`, severity: SeverityWarning, status: http.StatusBadRequest, format: `kafka topic rejected by policy: %v, reason: %v`, fieldCount: 2},
+	InvalidRequestBody:              {name: `invalid-request-body`, severity: SeverityWarning, status: http.StatusBadRequest, format: `invalid request body: %v`, withCause: true},
+	MissingOwnerAccess:              {name: `missing-owner-access`, severity: SeverityWarning, status: http.StatusUnauthorized, format: `owner access data is missing or malformed`},
+	AuditWriteFailed:                {name: `audit-write-failed`, severity: SeverityCritical, status: http.StatusInternalServerError, format: `audit trail could not be written: %v`, withCause: true},
+	KafkaQuotaExceeded:              {name: `kafka-quota-exceeded`, severity: SeverityWarning, status: http.StatusTooManyRequests, format: `kafka proxy quota exceeded for owner: %v, reason: %v`, fieldCount: 2},
+	KafkaConsumerNotFound:           {name: `kafka-consumer-not-found`, severity: SeverityWarning, status: http.StatusNotFound, format: `kafka consumer instance not found: %v`, fieldCount: 1},
+	InvalidKafkaEnvelope:            {name: `invalid-kafka-envelope`, severity: SeverityCritical, status: http.StatusBadRequest, format: `invalid kafka record envelope: %v`, withCause: true},
+	UnsupportedEnvMapField:          {name: `unsupported-env-map-field`, severity: SeverityWarning, status: http.StatusBadRequest, format: `env map field cannot be distributed: %v`, fieldCount: 1},
+	StaleEnvMapUpdate:               {name: `stale-env-map-update`, severity: SeverityCritical, status: http.StatusConflict, format: `stale env map update rejected: %v, reason: %v`, fieldCount: 2},
+	KafkaTopicKeyNotFound:           {name: `kafka-topic-key-not-found`, severity: SeverityWarning, status: http.StatusNotFound, format: `kafka topic data key not found: %v`, fieldCount: 1},
+	InvalidKafkaEncryptedRecord:     {name: `invalid-kafka-encrypted-record`, severity: SeverityCritical, status: http.StatusBadRequest, format: `invalid kafka encrypted record: %v`, withCause: true},
+	OwnerAuthenticationFailed:       {name: `owner-authentication-failed`, severity: SeverityWarning, status: http.StatusUnauthorized, format: `owner authentication failed: %v`, withCause: true},
+	SignerNotConfigured:             {name: `signer-not-configured`, severity: SeverityCritical, status: http.StatusServiceUnavailable, format: `signer not configured: %v`, fieldCount: 1},
+	InvalidEnvSchema:                {name: `invalid-env-schema`, severity: SeverityCritical, status: http.StatusBadRequest, format: `invalid env schema: %v, reason: %v`, fieldCount: 2},
+	EnvSchemaViolation:              {name: `env-schema-violation`, severity: SeverityWarning, status: http.StatusBadRequest, format: `env value does not match schema: %v, reason: %v`, fieldCount: 2},
+	InvalidEnvStoreBundle:           {name: `invalid-env-store-bundle`, severity: SeverityCritical, status: http.StatusBadRequest, format: `invalid env store bundle: %v`, fieldCount: 1},
+	EnvStoreNotEmpty:                {name: `env-store-not-empty`, severity: SeverityCritical, status: http.StatusConflict, format: `env store is not empty, import requires a fresh instance`},
+	EnvSecretAccessDenied:           {name: `env-secret-access-denied`, severity: SeverityCritical, status: http.StatusInternalServerError, format: `env value kind does not match accessor: %v`, fieldCount: 1},
+	EnvSecretKeyNotFound:            {name: `env-secret-key-not-found`, severity: SeverityCritical, status: http.StatusInternalServerError, format: `env secret data key not found, version: %v`, fieldCount: 1},
+	InvalidEnvLayer:                 {name: `invalid-env-layer`, severity: SeverityCritical, status: http.StatusBadRequest, format: `invalid env layer: %v, reason: %v`, fieldCount: 2},
+	EnvMapDependencyCycle:           {name: `env-map-dependency-cycle`, severity: SeverityCritical, status: http.StatusInternalServerError, format: `env map dependency cycle: %v`, fieldCount: 1},
+	EnvMapDependencyNotFound:        {name: `env-map-dependency-not-found`, severity: SeverityCritical, status: http.StatusInternalServerError, format: `env map dependency not found: %v, required by: %v`, fieldCount: 2},
+	InvalidEnvReference:             {name: `invalid-env-reference`, severity: SeverityCritical, status: http.StatusBadRequest, format: `invalid env reference: %v, reason: %v`, fieldCount: 2},
+	EnvReferenceCycle:               {name: `env-reference-cycle`, severity: SeverityCritical, status: http.StatusBadRequest, format: `env reference cycle: %v`, fieldCount: 1},
+	UnsupportedEnvDryRun:            {name: `unsupported-env-dry-run`, severity: SeverityWarning, status: http.StatusBadRequest, format: `env dry run input not supported: %v`, fieldCount: 1},
+	UnknownEnvMapKey:                {name: `unknown-env-map-key`, severity: SeverityWarning, status: http.StatusBadRequest, format: `env map key not declared in reference key slice: %v`, fieldCount: 1},
+	EnvQuarantineNotFound:           {name: `env-quarantine-not-found`, severity: SeverityWarning, status: http.StatusNotFound, format: `quarantined file not found: %v`, fieldCount: 1},
+	InvalidEnvQuarantineID:          {name: `invalid-env-quarantine-id`, severity: SeverityWarning, status: http.StatusBadRequest, format: `invalid quarantine id: %v`, fieldCount: 1},
+	EnvMapRevisionNotFound:          {name: `env-map-revision-not-found`, severity: SeverityWarning, status: http.StatusNotFound, format: `env map revision not found: %v, revision: %v`, fieldCount: 2},
+	InvalidEnvMapDiff:               {name: `invalid-env-map-diff`, severity: SeverityWarning, status: http.StatusBadRequest, format: `invalid env map diff input: %v`, fieldCount: 1},
+	SystemPubKeyMismatch:            {name: `system-pub-key-mismatch`, severity: SeverityCritical, status: http.StatusConflict, format: `system pub key does not match the trusted system pub key: %v`, fieldCount: 1},
+	TrustedSystemPubKeyNotFound:     {name: `trusted-system-pub-key-not-found`, severity: SeverityCritical, status: http.StatusPreconditionFailed, format: `no trusted system pub key available to verify: %v`, fieldCount: 1},
+	EnvValidationFailed:             {name: `env-validation-failed`, severity: SeverityWarning, status: http.StatusBadRequest, format: `env validation failed with %v violations, first at %v: %v`, fieldCount: 2, withCause: true},
+}
+
+// tanımlı olmayan code ile üretilen hata
+var invalidFuncErrorDef = funcErrorDef{name: `invalid-func-error-code`, severity: SeverityCritical, status: http.StatusInternalServerError, format: `invalid func error code: %v`, fieldCount: 1}
+
+/*
+- FuncError, code, severity, fields ve sarmalanan cause bilgisini taşır.
+- errors.Is aynı code ile üretilmiş FuncError ile eşleşir, errors.As ile code ve fields bilgisine ulaşılır.
+- Unwrap cause döner, cause üzerindeki hatalar da errors.Is/As ile kontrol edilebilir.
+*/
+type FuncError struct {
+	Code     int
+	Name     string
+	Severity Severity
+	Fields   []any
+	Cause    error
+	def      funcErrorDef
+}
+
+func (fe *FuncError) Error() string {
+	return fe.Message(DefaultLocale)
+}
+
+// mesaj locale catalog üzerindeki format ile üretilir. Catalog ya da locale yoksa varsayılan format kullanılır.
+func (fe *FuncError) Message(locale string) string {
+	args := make([]any, 0, fe.def.fieldCount+1)
+	for i := 0; i < fe.def.fieldCount; i++ {
+		switch {
+		case i < len(fe.Fields) && fe.def.typeFields:
+			args = append(args, fmt.Sprintf(`%T`, fe.Fields[i]))
+		case i < len(fe.Fields):
+			args = append(args, fe.Fields[i])
+		default:
+			args = append(args, `?`)
+		}
+	}
+	if fe.def.withCause {
+		args = append(args, causeMessage(fe.Cause, locale))
+	}
+
+	message := fe.Severity.icon() + fmt.Sprintf(funcErrorFormat(locale, fe.Name, fe.def.format), args...)
+	//format içerisinde yer almayan cause mesajın sonuna eklenir.
+	if !fe.def.withCause && fe.Cause != nil {
+		message += fmt.Sprintf(`: %v`, causeMessage(fe.Cause, locale))
+	}
+	return message
+}
+
+// cause bir func error ise aynı locale ile çevrilir.
+func causeMessage(cause error, locale string) any {
+	var causeErr *FuncError
+	if errors.As(cause, &causeErr) && causeErr == cause {
+		return causeErr.Message(locale)
+	}
+	return cause
+}
+
+func (fe *FuncError) Unwrap() error {
+	return fe.Cause
+}
+
+func (fe *FuncError) Is(target error) bool {
+	targetErr, ok := target.(*FuncError)
+	return ok && targetErr.Code == fe.Code
+}
+
+func (fe *FuncError) HTTPStatus() int {
+	return fe.def.status
+}
+
+// api hata cevabı locale ile üretilir. Fields string olarak, FuncError cause ise iç içe cevap olarak yazılır.
+func (fe *FuncError) ResponseData(locale string) e.ErrorResponseData {
+	response := e.ErrorResponseData{
+		Error:    fe.Message(locale),
+		Code:     fe.Code,
+		Name:     fe.Name,
+		Severity: fe.Severity.String(),
+	}
+	for _, field := range fe.Fields {
+		if fe.def.typeFields {
+			response.Fields = append(response.Fields, fmt.Sprintf(`%T`, field))
+		} else {
+			response.Fields = append(response.Fields, fmt.Sprintf(`%v`, field))
+		}
+	}
+
+	var causeErr *FuncError
+	if errors.As(fe.Cause, &causeErr) {
+		causeResponse := causeErr.ResponseData(locale)
+		response.Cause = &causeResponse
+	}
+	return response
+}
+
+// code ile eşleşen FuncError errors.Is ile karşılaştırmak için kullanılır. Ex: errors.Is(err, FuncErrorCode(DataExpiresAt))
+func FuncErrorCode(code int) error {
+	return &FuncError{Code: code}
+}
+
+// hata zinciri içerisinde code ile üretilmiş FuncError var mı kontrol edilir.
+func IsFuncError(err error, code int) bool {
+	return errors.Is(err, FuncErrorCode(code))
+}
+
+// hata zinciri içerisindeki ilk FuncError http status bilgisine çevrilir. FuncError bulunamazsa 500 döner.
+func FuncErrorHTTPStatus(err error) int {
+	var funcErr *FuncError
+	if errors.As(err, &funcErr) {
+		return funcErr.HTTPStatus()
+	}
+	return http.StatusInternalServerError
+}
+
+/*
+- hata zinciri içerisindeki ilk FuncError locale ile api hata cevabına çevrilir. FuncError bulunamazsa sadece mesaj yazılır.
+- FuncError başka bir hata ile sarmalanmış ise Error alanı sarmalayan hatanın mesajını taşır.
+*/
+func FuncErrorResponse(err error, locale string) e.ErrorResponseData {
+	var funcErr *FuncError
+	if errors.As(err, &funcErr) {
+		response := funcErr.ResponseData(locale)
+		if funcErr != err {
+			response.Error = err.Error()
+		}
+		return response
+	}
+	return e.ErrorResponseData{
+		Error:    err.Error(),
+		Code:     UnexpectedError,
+		Name:     funcErrorDefs[UnexpectedError].name,
+		Severity: SeverityCritical.String(),
+	}
+}
+
+func GetFuncError(code int, err error, fields ...any) error {
+	def, ok := funcErrorDefs[code]
+	if !ok {
+		def = invalidFuncErrorDef
+		fields = []any{code}
+	}
+
+	return &FuncError{
+		Code:     code,
+		Name:     def.name,
+		Severity: def.severity,
+		Fields:   fields,
+		Cause:    err,
+		def:      def,
 	}
 }
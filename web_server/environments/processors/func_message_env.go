@@ -0,0 +1,105 @@
+package processors
+
+import (
+	"maps"
+	"slices"
+	"sync/atomic"
+	e "web_server/domain/entities"
+)
+
+// internal-env-keys
+const (
+	LocaleEN      = `en`
+	LocaleTR      = `tr`
+	DefaultLocale = LocaleEN
+)
+
+// internal-env-keys
+
+// func error env üzerinden yüklenen message catalogs. Yüklenmemiş ise varsayılan formats kullanılır.
+var funcMessageCatalogs atomic.Pointer[map[string]e.FuncMessageCatalogData]
+
+/*
+- locale catalogs doğrulanarak aktif edilir, önceki catalogs tamamen değiştirilir.
+- catalog içerisindeki her key tanımlı bir func error ya da func status ismi olmak zorundadır.
+- eksik mesajlar için önce varsayılan locale catalog, sonra varsayılan format kullanılır.
+*/
+func SetFuncMessageCatalogs(catalogs map[string]e.FuncMessageCatalogData) error {
+	errorNames := map[string]bool{invalidFuncErrorDef.name: true}
+	for _, def := range funcErrorDefs {
+		errorNames[def.name] = true
+	}
+	statusNames := map[string]bool{}
+	for _, def := range funcStatusDefs {
+		statusNames[def.name] = true
+	}
+
+	activeCatalogs := make(map[string]e.FuncMessageCatalogData, len(catalogs))
+	for locale, catalog := range catalogs {
+		if locale == "" {
+			return GetFuncError(InvalidEnvMapKey, nil, locale)
+		}
+		for name := range catalog.ErrorInfos {
+			if !errorNames[name] {
+				return GetFuncError(InvalidEnvMapKey, nil, locale+"/"+name)
+			}
+		}
+		for name := range catalog.StatusInfos {
+			if !statusNames[name] {
+				return GetFuncError(InvalidEnvMapKey, nil, locale+"/"+name)
+			}
+		}
+		activeCatalogs[locale] = e.FuncMessageCatalogData{
+			ErrorInfos:  maps.Clone(catalog.ErrorInfos),
+			StatusInfos: maps.Clone(catalog.StatusInfos),
+		}
+	}
+
+	funcMessageCatalogs.Store(&activeCatalogs)
+	return nil
+}
+
+// yüklenen catalogs kaldırılır, mesajlar varsayılan formats ile üretilir.
+func ResetFuncMessageCatalogs() {
+	funcMessageCatalogs.Store(nil)
+}
+
+// mesaj üretilebilen locales sıralı olarak döner. Varsayılan locale her zaman yer alır.
+func FuncMessageLocales() []string {
+	locales := []string{DefaultLocale}
+	if catalogs := funcMessageCatalogs.Load(); catalogs != nil {
+		for locale := range *catalogs {
+			locales = append(locales, locale)
+		}
+	}
+	slices.Sort(locales)
+	return slices.Compact(locales)
+}
+
+// locale, varsayılan locale ve varsayılan format sırası ile mesaj formatı seçilir.
+func funcMessageFormat(locale string, name string, defaultFormat string, messages func(e.FuncMessageCatalogData) map[string]string) string {
+	catalogs := funcMessageCatalogs.Load()
+	if catalogs == nil {
+		return defaultFormat
+	}
+	for _, catalogLocale := range []string{locale, DefaultLocale} {
+		if catalog, ok := (*catalogs)[catalogLocale]; ok {
+			if format, ok := messages(catalog)[name]; ok && format != "" {
+				return format
+			}
+		}
+	}
+	return defaultFormat
+}
+
+func funcErrorFormat(locale string, name string, defaultFormat string) string {
+	return funcMessageFormat(locale, name, defaultFormat, func(catalog e.FuncMessageCatalogData) map[string]string {
+		return catalog.ErrorInfos
+	})
+}
+
+func funcStatusFormat(locale string, name string, defaultFormat string) string {
+	return funcMessageFormat(locale, name, defaultFormat, func(catalog e.FuncMessageCatalogData) map[string]string {
+		return catalog.StatusInfos
+	})
+}
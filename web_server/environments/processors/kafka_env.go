@@ -0,0 +1,174 @@
+package processors
+
+// internal-env-keys
+const (
+	KafkaEnvTag = `kafka-env-tag`
+
+	//sistem tarafından yönetilen kafka principal bilgileri bu prefix ile başlar. User:kaftion-<whitelist_key>
+	KafkaPrincipalType = `User:`
+	KafkaUserPrefix    = `kaftion-`
+	KafkaAnyHost       = `*`
+	KafkaClusterName   = `kafka-cluster`
+
+	/*
+		owner AuthnData.PermInfos içerisindeki permission key bilgisi kafka resource tanımına göre oluşturulur.
+		Ex: kafka-topic:orders => orders topic, kafka-group-prefix:orders- => orders- ile başlayan consumer group'lar
+	*/
+	KafkaTopicPermPrefix       = `kafka-topic:`
+	KafkaTopicPrefixPermPrefix = `kafka-topic-prefix:`
+	KafkaGroupPermPrefix       = `kafka-group:`
+	KafkaGroupPrefixPermPrefix = `kafka-group-prefix:`
+
+	//kafka acl bileşenleri kafka isimlendirmesi ile tutulur.
+	KafkaResourceTopic   = `TOPIC`
+	KafkaResourceGroup   = `GROUP`
+	KafkaResourceCluster = `CLUSTER`
+
+	KafkaPatternLiteral  = `LITERAL`
+	KafkaPatternPrefixed = `PREFIXED`
+
+	KafkaOperationRead            = `READ`
+	KafkaOperationWrite           = `WRITE`
+	KafkaOperationDescribe        = `DESCRIBE`
+	KafkaOperationIdempotentWrite = `IDEMPOTENT_WRITE`
+
+	KafkaPermissionAllow = `ALLOW`
+
+	KafkaACLPlanCreate = `+`
+	KafkaACLPlanDelete = `-`
+
+	//sistem tarafından üretilen scram credential ayarları
+	KafkaScramMechanismSHA512       = `SCRAM-SHA-512`
+	KafkaScramIterations      int32 = 8192
+	KafkaScramPasswordLength        = 32
+	KafkaScramSaltLength            = 32
+	KafkaScramDefaultRotation int64 = 30 * 24 * 60 * 60 //saniye
+	KafkaScramPendingSuffix         = `.pending`        //broker güncellenene kadar yeni credential bu suffix ile yazılır.
+
+	//server.properties üzerinde modellenen property keys
+	KafkaPropBrokerID                             = `broker.id`
+	KafkaPropListeners                            = `listeners`
+	KafkaPropAdvertisedListeners                  = `advertised.listeners`
+	KafkaPropListenerSecurityProtocolMap          = `listener.security.protocol.map`
+	KafkaPropInterBrokerListenerName              = `inter.broker.listener.name`
+	KafkaPropLogDirs                              = `log.dirs`
+	KafkaPropNumPartitions                        = `num.partitions`
+	KafkaPropDefaultReplicationFactor             = `default.replication.factor`
+	KafkaPropOffsetsTopicReplicationFactor        = `offsets.topic.replication.factor`
+	KafkaPropTransactionStateLogReplicationFactor = `transaction.state.log.replication.factor`
+	KafkaPropTransactionStateLogMinISR            = `transaction.state.log.min.isr`
+	KafkaPropSaslEnabledMechanisms                = `sasl.enabled.mechanisms`
+	KafkaPropSaslMechanismInterBrokerProtocol     = `sasl.mechanism.inter.broker.protocol`
+	KafkaPropZookeeperConnect                     = `zookeeper.connect`
+	KafkaPropZookeeperConnectionTimeoutMs         = `zookeeper.connection.timeout.ms`
+
+	KafkaServerPropertiesFile = `server.properties`
+	KafkaBrokerDirPrefix      = `broker` //üretilen files <path>/broker<broker_id>/server.properties altına yazılır.
+
+	//rest proxy consumer ayarları
+	KafkaOffsetResetEarliest        = `earliest`
+	KafkaOffsetResetLatest          = `latest`
+	KafkaProxyDefaultPollTimeoutMs  = 1000
+	KafkaProxyMaxPollTimeoutMs      = 30000
+	KafkaProxyConsumerIdleSeconds   = 300 //bu süre boyunca kullanılmayan consumer instance kapatılır.
+	KafkaProxyConsumerInstanceBytes = 16
+
+	//main env üzerinde kafka-proxy-quota tanımlı değilse kullanılan limitler
+	KafkaProxyDefaultMaxBatchRecords    = 500
+	KafkaProxyDefaultMaxBatchBytes      = 1 << 20
+	KafkaProxyDefaultProduceBytesPerSec = 1 << 20
+	KafkaProxyDefaultMaxConsumers       = 4
+	KafkaProxyDefaultMaxPollRecords     = 500
+
+	//sistem tarafından yazılan record headers bu prefix ile başlar, client tarafından gönderilemez.
+	KafkaReservedHeaderPrefix = `kaftion-`
+
+	//imzalı record envelope header bilgisi. Ex: kaftion-envelope: v1
+	KafkaEnvelopeHeader  = `kaftion-envelope`
+	KafkaEnvelopeVersion = `v1`
+
+	//env distribution topic tek partition ve compact cleanup policy ile oluşturulur, her replica kendi group ile baştan okur.
+	KafkaEnvDistributionGroupPrefix = `kaftion-env-`
+	KafkaEnvDistributionPartitions  = 1
+	KafkaTopicConfigCleanupPolicy   = `cleanup.policy`
+	KafkaCleanupPolicyCompact       = `compact`
+
+	KafkaMetricsScrapeTimeoutMs = 5000 //her scrape için admin istekleri bu süre ile sınırlandırılır.
+
+	//şifrelenmiş record header bilgisi. Ex: kaftion-encryption: v1
+	KafkaEncryptionHeader  = `kaftion-encryption`
+	KafkaEncryptionVersion = `v1`
+)
+
+// kafka topic policy env map keys
+const (
+	KafkaTopicNamePattern          = `kafka-topic-name-pattern`           //string => topic isim regex. Ex: ^[a-z0-9][a-z0-9._-]{2,248}$
+	KafkaTopicReservedPrefixes     = `kafka-topic-reserved-prefixes`      //[]string => bu prefix ile başlayan topic oluşturulamaz. Ex: __
+	KafkaTopicMinPartitions        = `kafka-topic-min-partitions`         //int32
+	KafkaTopicMaxPartitions        = `kafka-topic-max-partitions`         //int32
+	KafkaTopicMinReplicationFactor = `kafka-topic-min-replication-factor` //int16
+	KafkaTopicMaxReplicationFactor = `kafka-topic-max-replication-factor` //int16
+	KafkaTopicAllowedConfigs       = `kafka-topic-allowed-configs`        //[]string => api üzerinden değiştirilebilecek topic configs
+)
+
+// kafka topic policy env map içerisinde barınan keys doğrulamak için reference alınacak slice
+var KafkaTopicPolicyEnvKeyRefSlice []string = []string{
+	KafkaTopicNamePattern,
+	KafkaTopicReservedPrefixes,
+	KafkaTopicMinPartitions,
+	KafkaTopicMaxPartitions,
+	KafkaTopicMinReplicationFactor,
+	KafkaTopicMaxReplicationFactor,
+	KafkaTopicAllowedConfigs,
+}
+
+// listener.security.protocol.map belirtilmeden kullanılabilecek listener isimleri
+var KafkaSecurityProtocolSlice []string = []string{`PLAINTEXT`, `SSL`, `SASL_PLAINTEXT`, `SASL_SSL`}
+
+// sasl.enabled.mechanisms içerisinde kabul edilen mechanism isimleri
+var KafkaSaslMechanismSlice []string = []string{`PLAIN`, `SCRAM-SHA-256`, KafkaScramMechanismSHA512, `GSSAPI`, `OAUTHBEARER`}
+
+// internal-env-keys
+
+// external-env-keys
+const (
+	KafkaSeedBrokers          = `kafka-seed-brokers`           //[]string => kafka-broker1:9092
+	KafkaScramCredentialPath  = `kafka-scram-credential-path`  //string => mühürlenmiş owner credential files dizini
+	KafkaScramRotationSeconds = `kafka-scram-rotation-seconds` //int64 => credential rotation süresi
+
+	KafkaServerProperties       = `kafka-server-properties`        //map[string]string => bütün broker'lar için ortak properties
+	KafkaBrokerServerProperties = `kafka-broker-server-properties` //map[int32]map[string]string => broker_id => broker'a özel properties
+	KafkaServerPropertiesPath   = `kafka-server-properties-path`   //string => üretilen server.properties files dizini
+
+	KafkaProxyQuota       = `kafka-proxy-quota`        //KafkaProxyQuotaData => bütün owner'lar için varsayılan rest proxy limitleri
+	KafkaProxyOwnerQuotas = `kafka-proxy-owner-quotas` //map[string]KafkaProxyQuotaData => whitelist key => owner'a özel limitler
+
+	KafkaEnvDistributionTopic = `kafka-env-distribution-topic` //string => imzalı env maps dağıtılan compacted topic
+
+	KafkaMetricsTargets = `kafka-metrics-targets` //KafkaMetricsTargetData => lag metrics toplanacak consumer groups ve topics
+
+	KafkaEncryptedTopics         = `kafka-encrypted-topics`           //[]string => records rest proxy üzerinde şifrelenen topics
+	KafkaTopicKeyPath            = `kafka-topic-key-path`             //string => imzalı topic key ring files dizini
+	KafkaTopicKeyRotationSeconds = `kafka-topic-key-rotation-seconds` //int64 => topic data key rotation süresi, 0 ise sadece alıcılar değiştiğinde döner.
+	KafkaOwnerX25519PubKeys      = `kafka-owner-x25519-pub-keys`      //map[string][]byte => whitelist key => owner'ın kayıtlı x25519 pub key bilgisi. Tanımlı değilse ed25519 pub key üzerinden türetilir.
+)
+
+// kafka env keys main env içerisinde barınır, doğrulamak için reference alınacak slice
+var KafkaEnvKeyRefSlice []string = []string{
+	KafkaSeedBrokers,
+	KafkaScramCredentialPath,
+	KafkaScramRotationSeconds,
+	KafkaServerProperties,
+	KafkaBrokerServerProperties,
+	KafkaServerPropertiesPath,
+	KafkaProxyQuota,
+	KafkaProxyOwnerQuotas,
+	KafkaEnvDistributionTopic,
+	KafkaMetricsTargets,
+	KafkaEncryptedTopics,
+	KafkaTopicKeyPath,
+	KafkaTopicKeyRotationSeconds,
+	KafkaOwnerX25519PubKeys,
+}
+
+// external-env-keys
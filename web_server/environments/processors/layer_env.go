@@ -0,0 +1,71 @@
+package processors
+
+import (
+	"regexp"
+	"sync/atomic"
+	e "web_server/domain/entities"
+)
+
+// internal-env-keys
+const (
+	EnvLayerBase    = `base`
+	EnvLayerTag     = `tag`
+	EnvLayerReplica = `replica`
+
+	//layer files main store altında tutulur. Ex: overlays/production/main-env.cbor, overlays/replicas/replica-1/main-env.cbor
+	EnvOverlaysDir         = `overlays`
+	EnvReplicaOverridesDir = `replicas`
+
+	//layered env maps için store üzerinde effective env map yanında tutulan base env map ve key kaynakları
+	EnvLayerBaseSuffix    = `@base`
+	EnvLayerSourcesSuffix = `@layers`
+)
+
+// tag overlay ve replica override uygulanabilen env map fields
+var LayeredEnvMapFieldSlice []string = []string{
+	MainEnvMapField,
+	KafkaTopicPolicyEnvMapField,
+	FuncErrorEnvMapField,
+}
+
+// internal-env-keys
+
+// layer isimleri path olarak kullanıldığı için sadece küçük harf, rakam, nokta, alt çizgi ve tire kabul edilir.
+var envLayerNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,62}$`)
+
+var envLayerInfos atomic.Pointer[e.EnvLayerData]
+
+/*
+- env maps yüklenmeden önce system tag ve replica id ayarlanır. Boş değerler layer uygulanmaması anlamına gelir.
+- replica overrides dizini ile çakışmaması için replicas tag olarak kullanılamaz.
+*/
+func SetEnvLayerInfos(input e.EnvLayerData) error {
+	if input.SystemTag != "" && (!envLayerNamePattern.MatchString(input.SystemTag) || input.SystemTag == EnvReplicaOverridesDir) {
+		return GetFuncError(InvalidEnvLayer, nil, input.SystemTag, "invalid system tag")
+	}
+	if input.ReplicaID != "" && !envLayerNamePattern.MatchString(input.ReplicaID) {
+		return GetFuncError(InvalidEnvLayer, nil, input.ReplicaID, "invalid replica id")
+	}
+	envLayerInfos.Store(&input)
+	return nil
+}
+
+func GetEnvLayerInfos() e.EnvLayerData {
+	if layerInfos := envLayerInfos.Load(); layerInfos != nil {
+		return *layerInfos
+	}
+	return e.EnvLayerData{}
+}
+
+// layer file imzalı description bilgisi. Overlay sadece bu bilgi ile imzalandığı layer, name ve field için uygulanır. Ex: tag:production:main-env.cbor
+func EnvLayerBinding(layer string, name string, field string) string {
+	return layer + ":" + name + ":" + field
+}
+
+func EnvLayerBaseKey(field string) string {
+	return field + EnvLayerBaseSuffix
+}
+
+func EnvLayerSourcesKey(field string) string {
+	return field + EnvLayerSourcesSuffix
+}
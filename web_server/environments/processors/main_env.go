@@ -1,9 +1,10 @@
 package processors
 
 import (
-	"reflect"
+	"maps"
+	"slices"
 	"sync"
-	"unsafe"
+	"sync/atomic"
 	e "web_server/domain/entities"
 )
 
@@ -25,6 +26,8 @@ const (
 	ExternalEnvBasePath  = `external-env-base-path`
 	FuncPerm             = `func-perm`
 	OwnerWhitelist       = `owner-whitelist`
+	SystemSignerKeyPath  = `system-signer-key-path` //sistem tarafından üretilen files imzalamak için ed25519 key file
+	AuditLogPath         = `audit-log-path`         //değişiklik kayıtlarının yazıldığı audit trail file
 	//system funcs izinleri
 
 )
@@ -37,6 +40,9 @@ var MainEnvKeyRefSlice []string = []string{
 	AccessDataPath,
 
 	ExternalEnvBasePath,
+
+	SystemSignerKeyPath,
+	AuditLogPath,
 }
 
 // external-env-keys
@@ -53,313 +59,187 @@ var MainEnvKeyRefSlice []string = []string{
 // }
 
 // ****general env map operations****
-// Ortam değişkenleri ve kilitler
-var (
-	envMaps     sync.Map // map[string]EnvMapData[K,V] - Ana thread-safe harita
-	envMapLocks sync.Map // map[string]*sync.RWMutex - Her envMapKey için özel kilit
-)
+/*
+- env maps ve pub keys tek bir değiştirilemez store snapshot içerisinde saklanır. Okumalar kilitsiz ve kopyasız yapılır.
+- yazma işlemleri EnvTx üzerinden yeni store snapshot oluşturur ve atomic.Pointer ile tek seferde yayınlar.
+- birden fazla env map okuyan çağıranlar LoadEnvView ile aynı snapshot üzerinden okuma yapabilir.
+- GetEnvMap ile dönen EnvInfos snapshot ile paylaşılır, çağıranlar tarafından değiştirilmemelidir.
+*/
+type envMapSnapshot struct {
+	data     any    // e.EnvMapData[K,V]
+	revision uint64 // snapshot yayınlandığında verilen revision
+}
 
-// getEnvLock belirli bir envMapKey için kilidi atomik olarak alır veya oluşturur
-func getEnvLock(envMapKey string) *sync.RWMutex {
-	lock, _ := envMapLocks.LoadOrStore(envMapKey, &sync.RWMutex{})
-	return lock.(*sync.RWMutex)
+type envStoreSnapshot struct {
+	envMaps map[string]*envMapSnapshot
+	pubKeys map[string]*e.PubKeyData
+	history map[string][]*envMapSnapshot // değişen ya da silinen env maps önceki snapshots, eskiden yeniye sıralı
 }
 
-// cloneMap generic map kopyalama fonksiyonu (Thread-safe değil, caller sorumlu)
-// cloneMap deep copy
-func cloneMap[K comparable, V any](src map[K]V) map[K]V {
-	if src == nil {
-		return nil
-	}
+var (
+	envStore          atomic.Pointer[envStoreSnapshot]
+	envStoreWriteLock sync.Mutex    // yazma işlemleri sıralanır, okumalar kilit almaz
+	envMapRevision    atomic.Uint64 // bütün env maps için artan revision sayacı, silinip tekrar eklenen env map önceki revision almaz
+)
 
-	dst := make(map[K]V, len(src))
-	for k, v := range src {
-		dst[deepCopy(k).(K)] = deepCopy(v).(V)
+// store henüz oluşturulmamış ise boş store döner.
+func loadEnvStore() *envStoreSnapshot {
+	if store := envStore.Load(); store != nil {
+		return store
 	}
-	return dst
+	return &envStoreSnapshot{}
 }
 
-// isPrimitive checks if the kind is a basic type that can be shallow copied
-func isPrimitive(k reflect.Kind) bool {
-	switch k {
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
-		reflect.Float32, reflect.Float64,
-		reflect.Complex64, reflect.Complex128,
-		reflect.String, reflect.Bool:
-		return true
-	default:
-		return false
-	}
+// aynı store snapshot üzerinden tutarlı okuma yapmak için kullanılır.
+type EnvView struct {
+	store *envStoreSnapshot
 }
 
-// deepCopy performs an optimized deep copy using type assertions and reflection
-func deepCopy(src any) any {
-	if src == nil {
-		return nil
-	}
-
-	// Handle primitive types and safe copies first
-	switch v := src.(type) {
-	case int, int8, int16, int32, int64,
-		uint, uint8, uint16, uint32, uint64,
-		float32, float64, complex64, complex128,
-		string, bool:
-		return v
-
-	case []byte:
-		c := make([]byte, len(v))
-		copy(c, v)
-		return c
-
-	case *int:
-		if v == nil {
-			return (*int)(nil)
-		}
-		cpy := *v
-		return &cpy
-
-		// Add other pointer and slice types as needed...
-	}
+// yayınlanmış son store snapshot döner. View üzerinden yapılan okumalar sonradan commit edilen işlemlerden etkilenmez.
+func LoadEnvView() EnvView {
+	return EnvView{store: loadEnvStore()}
+}
 
-	// Handle complex types with reflection
-	val := reflect.ValueOf(src)
-	switch val.Kind() {
-	case reflect.Ptr:
-		if val.IsNil() {
-			return reflect.New(val.Type()).Interface()
-		}
-		cpy := reflect.New(val.Type().Elem())
-		cpy.Elem().Set(reflect.ValueOf(deepCopy(val.Elem().Interface())))
-		return cpy.Interface()
-
-	case reflect.Slice:
-		if val.IsNil() {
-			return reflect.Zero(val.Type()).Interface()
-		}
-		// Optimize primitive slices with reflect.Copy
-		if elemKind := val.Type().Elem().Kind(); isPrimitive(elemKind) {
-			cpy := reflect.MakeSlice(val.Type(), val.Len(), val.Cap())
-			reflect.Copy(cpy, val)
-			return cpy.Interface()
-		}
-		// Deep copy for complex slices
-		cpy := reflect.MakeSlice(val.Type(), val.Len(), val.Cap())
-		for i := 0; i < val.Len(); i++ {
-			cpy.Index(i).Set(reflect.ValueOf(deepCopy(val.Index(i).Interface())))
-		}
-		return cpy.Interface()
-
-	case reflect.Map:
-		if val.IsNil() {
-			return reflect.Zero(val.Type()).Interface()
-		}
-		cpy := reflect.MakeMapWithSize(val.Type(), val.Len())
-		for _, key := range val.MapKeys() {
-			origValue := val.MapIndex(key)
-			cpy.SetMapIndex(
-				reflect.ValueOf(deepCopy(key.Interface())),
-				reflect.ValueOf(deepCopy(origValue.Interface())),
-			)
-		}
-		return cpy.Interface()
-
-	case reflect.Struct:
-		cpy := reflect.New(val.Type()).Elem()
-		for i := 0; i < val.NumField(); i++ {
-			if !cpy.Field(i).CanSet() {
-				continue
-			}
-			fieldCopy := deepCopy(val.Field(i).Interface())
-			cpy.Field(i).Set(reflect.ValueOf(fieldCopy))
-		}
-		return cpy.Interface()
-
-	case reflect.Interface:
-		if val.IsNil() {
-			return nil
-		}
-		return deepCopy(val.Elem().Interface())
-
-	default:
-		if val.CanInterface() {
-			return copyUnexported(val)
-		}
-		return src
-	}
+// view üzerindeki env map keys sıralı olarak döner.
+func (view EnvView) EnvMapKeys() []string {
+	return slices.Sorted(maps.Keys(view.store.envMaps))
 }
 
-// copyUnexported carefully copies unexported fields
-func copyUnexported(original reflect.Value) any {
-	if !original.IsValid() {
-		return nil
+// env map türü bilinmeden data ve revision bilgisi getirilir. Store dışa aktarımı için kullanılır, data salt okunurdur.
+func (view EnvView) EnvMapRaw(envMapKey string) (any, uint64, bool) {
+	snapshot, exists := view.store.envMaps[envMapKey]
+	if !exists {
+		return nil, 0, false
 	}
+	return snapshot.data, snapshot.revision, true
+}
 
-	cpy := reflect.New(original.Type()).Elem()
-	size := original.Type().Size()
-
-	// Use unsafe to copy underlying memory
-	origPtr := unsafe.Pointer(original.UnsafeAddr())
-	cpyPtr := unsafe.Pointer(cpy.UnsafeAddr())
-	copy((*[1 << 30]byte)(cpyPtr)[:size], (*[1 << 30]byte)(origPtr)[:size])
+// view üzerindeki pub key owner keys sıralı olarak döner.
+func (view EnvView) PubKeyKeys() []string {
+	return slices.Sorted(maps.Keys(view.store.pubKeys))
+}
 
-	return cpy.Interface()
+// view üzerindeki snapshot ve typed env map getirilir.
+func loadEnvMapSnapshot[K comparable, V any](view EnvView, envMapKey string) (*envMapSnapshot, e.EnvMapData[K, V], error) {
+	snapshot, exists := view.store.envMaps[envMapKey]
+	if !exists {
+		return nil, e.EnvMapData[K, V]{}, GetFuncError(EnvMapKeyNotFound, nil, envMapKey)
+	}
+	typedData, valid := snapshot.data.(e.EnvMapData[K, V])
+	if !valid {
+		return nil, e.EnvMapData[K, V]{}, GetFuncError(EnvMapTypeMismatch, nil)
+	}
+	return snapshot, typedData, nil
 }
 
 // SetNewEnvMap yeni bir ortam haritası oluşturur (Tamamen thread-safe)
 func SetNewEnvMap[K comparable, V any](envMapKey string, input e.EnvMapData[K, V]) error {
-	lock := getEnvLock(envMapKey)
-	lock.Lock()
-	defer lock.Unlock()
-
-	if _, exists := envMaps.Load(envMapKey); exists {
-		return GetFuncError(EnvMapKeyAlreadyExists, nil, envMapKey)
-	}
-
-	clonedEnvInfos := cloneMap(input.EnvInfos)
-	newData := e.EnvMapData[K, V]{
-		EnvInfos:    clonedEnvInfos,
-		StatusInfos: input.StatusInfos,
-	}
-	envMaps.Store(envMapKey, newData)
-	return nil
+	tx := NewEnvTx()
+	TxSetNewEnvMap(tx, envMapKey, input)
+	return tx.Commit()
 }
 
 // UpdateEnvMap varolan bir ortam haritasını atomik olarak günceller
 func UpdateEnvMap[K comparable, V any](envMapKey string, input e.EnvMapData[K, V]) error {
-	lock := getEnvLock(envMapKey)
-	lock.Lock()
-	defer lock.Unlock()
-
-	current, exists := envMaps.Load(envMapKey)
-	if !exists {
-		return GetFuncError(EnvMapKeyNotFound, nil, envMapKey)
-	}
-
-	if _, valid := current.(e.EnvMapData[K, V]); !valid {
-		return GetFuncError(EnvMapTypeMismatch, nil)
-	}
+	tx := NewEnvTx()
+	TxUpdateEnvMap(tx, envMapKey, input)
+	return tx.Commit()
+}
 
-	clonedEnvInfos := cloneMap(input.EnvInfos)
-	newData := e.EnvMapData[K, V]{
-		EnvInfos:    clonedEnvInfos,
-		StatusInfos: input.StatusInfos,
-	}
-	envMaps.Store(envMapKey, newData)
-	return nil
+// PutEnvMap env map yoksa ekler, varsa günceller
+func PutEnvMap[K comparable, V any](envMapKey string, input e.EnvMapData[K, V]) error {
+	tx := NewEnvTx()
+	TxPutEnvMap(tx, envMapKey, input)
+	return tx.Commit()
 }
 
+// GetEnvMap yayınlanmış snapshot üzerindeki env map döner. EnvInfos salt okunurdur.
 func GetEnvMap[K comparable, V any](envMapKey string) (e.EnvMapData[K, V], error) {
-	lock := getEnvLock(envMapKey)
-	lock.RLock()
-	defer lock.RUnlock()
-
-	rawData, exists := envMaps.Load(envMapKey)
-	if !exists {
-		return e.EnvMapData[K, V]{}, GetFuncError(EnvMapKeyNotFound, nil, envMapKey)
-	}
-
-	typedData, valid := rawData.(e.EnvMapData[K, V])
-	if !valid {
-		return e.EnvMapData[K, V]{}, GetFuncError(EnvMapTypeMismatch, nil)
-	}
-
-	// Clone EnvInfos to prevent external mutations
-	clonedEnvInfos := cloneMap(typedData.EnvInfos)
+	return ViewEnvMap[K, V](LoadEnvView(), envMapKey)
+}
 
-	// If StatusInfos is mutable, add deep-cloning logic here
-	return e.EnvMapData[K, V]{
-		EnvInfos:    clonedEnvInfos,
-		StatusInfos: typedData.StatusInfos, // Ensure immutability or clone
-	}, nil
+// ViewEnvMap view üzerindeki env map döner. EnvInfos salt okunurdur.
+func ViewEnvMap[K comparable, V any](view EnvView, envMapKey string) (e.EnvMapData[K, V], error) {
+	_, typedData, err := loadEnvMapSnapshot[K, V](view, envMapKey)
+	return typedData, err
 }
 
 // GetEnv belirli bir ortam değerini thread-safe şekilde getirir
 func GetEnv[K comparable, V any](envMapKey string, key K) (V, error) {
-	var zero V
-	lock := getEnvLock(envMapKey)
-	lock.RLock()
-	defer lock.RUnlock()
+	value, _, err := ViewEnvWithRevision[K, V](LoadEnvView(), envMapKey, key)
+	return value, err
+}
 
-	rawData, exists := envMaps.Load(envMapKey)
-	if !exists {
-		return zero, GetFuncError(EnvMapKeyNotFound, nil)
-	}
+// ViewEnv view üzerindeki env map içerisinden değer getirir
+func ViewEnv[K comparable, V any](view EnvView, envMapKey string, key K) (V, error) {
+	value, _, err := ViewEnvWithRevision[K, V](view, envMapKey, key)
+	return value, err
+}
 
-	typedData, valid := rawData.(e.EnvMapData[K, V])
-	if !valid {
-		return zero, GetFuncError(EnvMapTypeMismatch, nil)
+/*
+- env map içerisindeki değer env map revision bilgisi ile birlikte getirilir.
+- revision aynı kaldığı sürece değer değişmez, çözülen değerler revision ile cache edilebilir.
+*/
+func GetEnvWithRevision[K comparable, V any](envMapKey string, key K) (V, uint64, error) {
+	return ViewEnvWithRevision[K, V](LoadEnvView(), envMapKey, key)
+}
+
+// ViewEnvWithRevision view üzerindeki değeri env map revision bilgisi ile birlikte getirir
+func ViewEnvWithRevision[K comparable, V any](view EnvView, envMapKey string, key K) (V, uint64, error) {
+	var zero V
+	snapshot, typedData, err := loadEnvMapSnapshot[K, V](view, envMapKey)
+	if err != nil {
+		return zero, 0, err
 	}
 
 	value, found := typedData.EnvInfos[key]
 	if !found {
-		return zero, GetFuncError(EnvKeyNotFound, nil, key)
+		return zero, 0, GetFuncError(EnvKeyNotFound, nil, key)
 	}
-	return value, nil
+	return value, snapshot.revision, nil
 }
 
 // DeleteEnvMap bir ortam haritasını atomik olarak siler
 func DeleteEnvMap(envMapKey string) {
-	lock := getEnvLock(envMapKey)
-	lock.Lock()
-	defer lock.Unlock()
-
-	envMaps.Delete(envMapKey)
-	envMapLocks.Delete(envMapKey)
+	tx := NewEnvTx()
+	tx.DeleteEnvMap(envMapKey)
+	_ = tx.Commit()
 }
 
 // ****general env map operations****
 
 // ****Pubkey operations****
-var pubKeyEnvs sync.Map // *PubKeyData saklar
-
 func SetNewPubKey(ownerKey string, data e.PubKeyData) error {
-	newData := clonePubKeyData(data)
-
-	// Atomic check-and-set
-	if _, loaded := pubKeyEnvs.LoadOrStore(ownerKey, newData); loaded {
-		return GetFuncError(OwnerKeyAlreadyExists, nil, ownerKey)
-	}
-	return nil
+	tx := NewEnvTx()
+	tx.SetNewPubKey(ownerKey, data)
+	return tx.Commit()
 }
 
 func UpdatePubKey(ownerKey string, data e.PubKeyData) error {
-	newData := clonePubKeyData(data)
-
-	// Optimistic locking with retry
-	for {
-		oldVal, exists := pubKeyEnvs.Load(ownerKey)
-		if !exists {
-			return GetFuncError(InvalidOwnerKey, nil, ownerKey)
-		}
-
-		// Compare-and-swap pattern
-		if pubKeyEnvs.CompareAndSwap(ownerKey, oldVal, newData) {
-			return nil
-		}
-		// Retry if value changed concurrently
-	}
+	tx := NewEnvTx()
+	tx.UpdatePubKey(ownerKey, data)
+	return tx.Commit()
 }
 
 func GetPubKey(ownerKey string) (*e.PubKeyData, error) {
-	value, exists := pubKeyEnvs.Load(ownerKey)
+	return LoadEnvView().PubKey(ownerKey)
+}
+
+// PubKey view üzerindeki pub key klonlanarak döner
+func (view EnvView) PubKey(ownerKey string) (*e.PubKeyData, error) {
+	dataPtr, exists := view.store.pubKeys[ownerKey]
 	if !exists {
 		return nil, GetFuncError(InvalidOwnerKey, nil, ownerKey)
 	}
 
-	// Type safety check
-	dataPtr, ok := value.(*e.PubKeyData)
-	if !ok {
-		pubKeyEnvs.Delete(ownerKey) // Geçersiz veriyi temizle
-		return nil, GetFuncError(InvalidDataType, nil, value)
-	}
-
 	// İç veriyi korumak için klonlanmış kopyayı döndür
 	return clonePubKeyData(*dataPtr), nil
 }
 
 func DeletePubKeyEnv(ownerKey string) {
-	pubKeyEnvs.Delete(ownerKey)
+	tx := NewEnvTx()
+	tx.DeletePubKey(ownerKey)
+	_ = tx.Commit()
 }
 
 // Yardımcı fonksiyonlar
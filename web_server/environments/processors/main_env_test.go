@@ -0,0 +1,285 @@
+package processors
+
+import (
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	e "web_server/domain/entities"
+)
+
+// benchmark env map boyutu. Main env üzerinde bulunan keys sayısına yakın tutulur.
+const benchEnvMapSize = 256
+
+func newBenchEnvMap() e.EnvMapData[string, e.EnvData[[]byte]] {
+	envMap := e.EnvMapData[string, e.EnvData[[]byte]]{
+		EnvInfos:    make(map[string]e.EnvData[[]byte], benchEnvMapSize),
+		StatusInfos: e.StatusData{Status: true, Description: MainEnvMapField},
+	}
+	for i := range benchEnvMapSize {
+		envMap.EnvInfos["key-"+strconv.Itoa(i)] = e.EnvData[[]byte]{
+			Value:        []byte("value-" + strconv.Itoa(i)),
+			SpecificInfo: e.SpecificData{FieldInfos: e.FieldData{CID: []byte("cid-" + strconv.Itoa(i))}},
+			StatusInfo:   e.StatusData{Status: true},
+		}
+	}
+	return envMap
+}
+
+/*
+- immutable snapshot öncesi env store: env map başına RWMutex, sync.Map ve her okuma ve yazmada reflection ile deep copy.
+- sadece benchmark karşılaştırması için tutulur.
+*/
+type legacyEnvStore struct {
+	envMaps     sync.Map
+	envMapLocks sync.Map
+}
+
+func (store *legacyEnvStore) lock(envMapKey string) *sync.RWMutex {
+	lock, _ := store.envMapLocks.LoadOrStore(envMapKey, &sync.RWMutex{})
+	return lock.(*sync.RWMutex)
+}
+
+func legacyPutEnvMap[K comparable, V any](store *legacyEnvStore, envMapKey string, input e.EnvMapData[K, V]) {
+	lock := store.lock(envMapKey)
+	lock.Lock()
+	defer lock.Unlock()
+	store.envMaps.Store(envMapKey, e.EnvMapData[K, V]{EnvInfos: legacyCloneMap(input.EnvInfos), StatusInfos: input.StatusInfos})
+}
+
+func legacyGetEnvMap[K comparable, V any](store *legacyEnvStore, envMapKey string) (e.EnvMapData[K, V], error) {
+	lock := store.lock(envMapKey)
+	lock.RLock()
+	defer lock.RUnlock()
+
+	rawData, exists := store.envMaps.Load(envMapKey)
+	if !exists {
+		return e.EnvMapData[K, V]{}, GetFuncError(EnvMapKeyNotFound, nil, envMapKey)
+	}
+	typedData, valid := rawData.(e.EnvMapData[K, V])
+	if !valid {
+		return e.EnvMapData[K, V]{}, GetFuncError(EnvMapTypeMismatch, nil)
+	}
+	return e.EnvMapData[K, V]{EnvInfos: legacyCloneMap(typedData.EnvInfos), StatusInfos: typedData.StatusInfos}, nil
+}
+
+func legacyGetEnv[K comparable, V any](store *legacyEnvStore, envMapKey string, key K) (V, error) {
+	var zero V
+	lock := store.lock(envMapKey)
+	lock.RLock()
+	defer lock.RUnlock()
+
+	rawData, exists := store.envMaps.Load(envMapKey)
+	if !exists {
+		return zero, GetFuncError(EnvMapKeyNotFound, nil, envMapKey)
+	}
+	typedData, valid := rawData.(e.EnvMapData[K, V])
+	if !valid {
+		return zero, GetFuncError(EnvMapTypeMismatch, nil)
+	}
+	value, found := typedData.EnvInfos[key]
+	if !found {
+		return zero, GetFuncError(EnvKeyNotFound, nil, key)
+	}
+	return value, nil
+}
+
+func legacyCloneMap[K comparable, V any](src map[K]V) map[K]V {
+	if src == nil {
+		return nil
+	}
+	dst := make(map[K]V, len(src))
+	for k, v := range src {
+		dst[legacyDeepCopy(k).(K)] = legacyDeepCopy(v).(V)
+	}
+	return dst
+}
+
+func legacyDeepCopy(src any) any {
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case string, bool, int, int64, uint64, float64:
+		return v
+	case []byte:
+		c := make([]byte, len(v))
+		copy(c, v)
+		return c
+	}
+
+	val := reflect.ValueOf(src)
+	switch val.Kind() {
+	case reflect.Slice:
+		if val.IsNil() {
+			return reflect.Zero(val.Type()).Interface()
+		}
+		cpy := reflect.MakeSlice(val.Type(), val.Len(), val.Cap())
+		for i := 0; i < val.Len(); i++ {
+			cpy.Index(i).Set(reflect.ValueOf(legacyDeepCopy(val.Index(i).Interface())))
+		}
+		return cpy.Interface()
+	case reflect.Map:
+		if val.IsNil() {
+			return reflect.Zero(val.Type()).Interface()
+		}
+		cpy := reflect.MakeMapWithSize(val.Type(), val.Len())
+		for _, key := range val.MapKeys() {
+			cpy.SetMapIndex(reflect.ValueOf(legacyDeepCopy(key.Interface())), reflect.ValueOf(legacyDeepCopy(val.MapIndex(key).Interface())))
+		}
+		return cpy.Interface()
+	case reflect.Struct:
+		cpy := reflect.New(val.Type()).Elem()
+		for i := 0; i < val.NumField(); i++ {
+			if cpy.Field(i).CanSet() {
+				cpy.Field(i).Set(reflect.ValueOf(legacyDeepCopy(val.Field(i).Interface())))
+			}
+		}
+		return cpy.Interface()
+	default:
+		return src
+	}
+}
+
+func BenchmarkGetEnvMap(b *testing.B) {
+	const envMapKey = `bench-get-env-map`
+	if err := PutEnvMap(envMapKey, newBenchEnvMap()); err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := GetEnvMap[string, e.EnvData[[]byte]](envMapKey); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkGetEnvMapLegacyClone(b *testing.B) {
+	const envMapKey = `bench-get-env-map`
+	store := &legacyEnvStore{}
+	legacyPutEnvMap(store, envMapKey, newBenchEnvMap())
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := legacyGetEnvMap[string, e.EnvData[[]byte]](store, envMapKey); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkGetEnv(b *testing.B) {
+	const envMapKey = `bench-get-env`
+	if err := PutEnvMap(envMapKey, newBenchEnvMap()); err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := GetEnv[string, e.EnvData[[]byte]](envMapKey, "key-0"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkGetEnvLegacyClone(b *testing.B) {
+	const envMapKey = `bench-get-env`
+	store := &legacyEnvStore{}
+	legacyPutEnvMap(store, envMapKey, newBenchEnvMap())
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := legacyGetEnv[string, e.EnvData[[]byte]](store, envMapKey, "key-0"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// yazma maliyeti: snapshot store her commit ile yeni store oluşturur, eski store her yazmada env map kopyalar.
+func BenchmarkPutEnvMap(b *testing.B) {
+	const envMapKey = `bench-put-env-map`
+	envMap := newBenchEnvMap()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		if err := PutEnvMap(envMapKey, envMap); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPutEnvMapLegacyClone(b *testing.B) {
+	const envMapKey = `bench-put-env-map`
+	store := &legacyEnvStore{}
+	envMap := newBenchEnvMap()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		legacyPutEnvMap(store, envMapKey, envMap)
+	}
+}
+
+// okumalar devam ederken yazma: snapshot store okumaları kilit beklemez, eski store okumaları yazma kilidini bekler.
+func BenchmarkGetEnvDuringPutEnvMap(b *testing.B) {
+	const envMapKey = `bench-get-env-during-put`
+	envMap := newBenchEnvMap()
+	if err := PutEnvMap(envMapKey, envMap); err != nil {
+		b.Fatal(err)
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				_ = PutEnvMap(envMapKey, envMap)
+			}
+		}
+	}()
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := GetEnv[string, e.EnvData[[]byte]](envMapKey, "key-0"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkGetEnvDuringPutEnvMapLegacyClone(b *testing.B) {
+	const envMapKey = `bench-get-env-during-put`
+	store := &legacyEnvStore{}
+	envMap := newBenchEnvMap()
+	legacyPutEnvMap(store, envMapKey, envMap)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				legacyPutEnvMap(store, envMapKey, envMap)
+			}
+		}
+	}()
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := legacyGetEnv[string, e.EnvData[[]byte]](store, envMapKey, "key-0"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
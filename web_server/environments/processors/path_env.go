@@ -23,6 +23,18 @@ const (
 	DeveloperPubKeyField = `developer-pub-key.cbor`
 
 	OwnerEnvAuthnTokenField = `owner-env-authn-token.cbor`
+
+	//tek seferlik okunup silinen bootstrap bundle ve sonrasında oluşan trust kaydı
+	BootstrapBundleField = `bootstrap-bundle.cbor`
+	TrustStateField      = `trust-state.cbor`
+	//çalışan instance üzerinden dışa aktarılan imzalı env store bundle
+	EnvStoreBundleField = `env-store-bundle.cbor`
+	//secret env values için system tarafından imzalanan data key ring
+	EnvSecretKeyRingField = `env-secret-keyring.cbor`
+	//sistem tarafından imzalanan kafka topic policy env map, main env ile aynı formatta tutulur.
+	KafkaTopicPolicyEnvMapField = `kafka-topic-policy-env.cbor`
+	//sistem tarafından imzalanan env map schemas. Key env map field, value cbor(EnvSchemaData) formatındadır.
+	EnvSchemaEnvMapField = `env-schema.cbor`
 	//external eklenecek env tanımlandığı alan
 	PathEnvMapField      = `path-env.cbor`
 	TaskEnvMapField      = `task-env.cbor`
@@ -33,8 +45,25 @@ const (
 	Cbor = `.cbor`
 	Json = `.json`
 	SH   = `.sh`
+	Sig  = `.sig`
 )
 
+// owner tarafından external olarak yüklenen env maps
+var ExternalEnvMapFieldSlice []string = []string{
+	PathEnvMapField,
+	TaskEnvMapField,
+	RestEnvMapField,
+	FuncEnvMapField,
+}
+
+// kafka env distribution topic üzerinden dağıtılan imzalı env map fields. Record key field ismidir.
+var DistributedEnvMapFieldSlice []string = []string{
+	EnvSchemaEnvMapField,
+	MainEnvMapField,
+	WhitelistEnvMapField,
+	KafkaTopicPolicyEnvMapField,
+}
+
 // internal-env-keys
 
 // external-env-keys
@@ -46,7 +75,7 @@ var PathEnvKeyRefSlice []string = []string{}
 // external-env-keys
 func GetPath(pathKey int, PathFields ...string) (string, error) {
 	switch pathKey {
-	case MainPathEnvsPathKey: //TODO: gecici internal olarak verildi. tek seferlik imzalı file okumaya göre yapılandırılacak
+	case MainPathEnvsPathKey: //bootstrap bundle ile trust kurulan datanın saklandığı store path
 		return filepath.Join(MainPathEnvsPath, PathFields[0]), nil
 	case SpecificPathKey:
 		return PathFields[0], nil
@@ -0,0 +1,15 @@
+package processors
+
+// internal-env-keys
+const (
+	//reddedilen files main envs path altında bu dizinde <cid>.cbor olarak saklanır.
+	EnvQuarantineDir = `quarantine`
+
+	//file reddeden kaynaklar
+	EnvQuarantineSourceMainEnv    = `main-env-loader`
+	EnvQuarantineSourceWhitelist  = `whitelist-loader`
+	EnvQuarantineSourceAccessData = `access-data-loader`
+	EnvQuarantineSourceWatcher    = `watcher`
+)
+
+// internal-env-keys
@@ -0,0 +1,29 @@
+package processors
+
+import (
+	"regexp"
+	"strings"
+)
+
+// internal-env-keys
+const (
+	//references içeren env values çözülmemiş halleri ile store üzerinde effective env map yanında tutulur.
+	EnvRefSuffix = `@refs`
+)
+
+// internal-env-keys
+
+// env value içerisindeki reference formatı. Ex: ${main-env:kafka-env-distribution-topic}, ${path-env.cbor:broker-host}
+var EnvRefPattern = regexp.MustCompile(`\$\{([a-z0-9][a-z0-9._-]*):([^{}]+)\}`)
+
+func EnvRefKey(field string) string {
+	return field + EnvRefSuffix
+}
+
+// reference içerisindeki env map ismi env map field bilgisine çevrilir. Ex: path-env => path-env.cbor
+func EnvRefEnvMapField(name string) string {
+	if strings.HasSuffix(name, Cbor) {
+		return name
+	}
+	return name + Cbor
+}
@@ -3,6 +3,46 @@ package processors
 // internal-env-keys
 const (
 	RestEnvTag = `rest-env-tag`
+
+	//owner istekleri base64(cbor(WhitelistAccessData)) formatında bu header ile gönderilir.
+	OwnerAccessHeader = `X-Kaftion-Access`
+
+	//hata mesajları bu header ile belirtilen dilde döner.
+	AcceptLanguageHeader = `Accept-Language`
+
+	ContentTypeJSON = `application/json`
+	ContentTypeCBOR = `application/cbor`
+
+	//gin context üzerinde doğrulanan owner ve request bilgilerinin tutulduğu keys
+	CtxOwnerKey     = `owner-key`
+	CtxOwnerAuthn   = `owner-authn`
+	CtxRequestInput = `request-input`
+
+	KafkaTopicsBasePath  = `/kafka/topics`
+	KafkaTopicPath       = `/:topic`
+	KafkaTopicConfigPath = `/:topic/config`
+	KafkaTopicKeysPath   = `/:topic/keys`
+	KafkaTopicParam      = `topic`
+
+	KafkaProxyBasePath            = `/kafka/proxy`
+	KafkaProxyRecordsPath         = `/topics/:topic/records`
+	KafkaProxyTopicKeysPath       = `/topics/:topic/keys`
+	KafkaProxyConsumersPath       = `/consumers`
+	KafkaProxyConsumerPath        = `/consumers/:instance`
+	KafkaProxyConsumerRecordsPath = `/consumers/:instance/records`
+	KafkaProxyConsumerOffsetsPath = `/consumers/:instance/offsets`
+	KafkaConsumerInstanceParam    = `instance`
+	MaxRecordsQuery               = `max-records`
+	TimeoutMsQuery                = `timeout-ms`
+
+	EnvBasePath           = `/envs`
+	EnvDryRunPath         = `/dry-run`
+	EnvQuarantinePath     = `/quarantine`
+	EnvQuarantineItemPath = `/quarantine/:id`
+	EnvQuarantineParam    = `id`
+	EnvDiffPath           = `/diff`
+	EnvRevisionsPath      = `/revisions/:field`
+	EnvFieldParam         = `field`
 )
 
 // internal-env-keys
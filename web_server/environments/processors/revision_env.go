@@ -0,0 +1,73 @@
+package processors
+
+import (
+	"maps"
+	"slices"
+	e "web_server/domain/entities"
+)
+
+// internal-env-keys
+const (
+	//env map başına saklanan önceki revisions sayısı. Limit aşılırsa en eski revision silinir.
+	EnvMapRevisionHistoryLimit = 16
+)
+
+// internal-env-keys
+
+/*
+- commit ile değişen ya da silinen env maps önceki snapshot bilgisi history üzerine eklenir.
+- history store snapshot ile birlikte yayınlanır ve değiştirilmez, değişen env maps için yeni slice oluşturulur.
+*/
+func nextEnvMapHistory(current *envStoreSnapshot, next *envStoreSnapshot) map[string][]*envMapSnapshot {
+	history := make(map[string][]*envMapSnapshot, len(current.history)+1)
+	maps.Copy(history, current.history)
+	for envMapKey, snapshot := range current.envMaps {
+		if nextSnapshot, exists := next.envMaps[envMapKey]; exists && nextSnapshot == snapshot {
+			continue
+		}
+		revisions := append(slices.Clone(history[envMapKey]), snapshot)
+		if len(revisions) > EnvMapRevisionHistoryLimit {
+			revisions = revisions[len(revisions)-EnvMapRevisionHistoryLimit:]
+		}
+		history[envMapKey] = revisions
+	}
+	return history
+}
+
+// view üzerindeki env map revisions eskiden yeniye sıralı döner. Env map yüklü ise son revision yüklü env map revision bilgisidir.
+func (view EnvView) EnvMapRevisions(envMapKey string) []uint64 {
+	var revisions []uint64
+	for _, snapshot := range view.store.history[envMapKey] {
+		revisions = append(revisions, snapshot.revision)
+	}
+	if snapshot, exists := view.store.envMaps[envMapKey]; exists {
+		revisions = append(revisions, snapshot.revision)
+	}
+	return revisions
+}
+
+// env map türü bilinmeden revision ile data getirilir. Yüklü env map ve history üzerinde aranır, data salt okunurdur.
+func (view EnvView) EnvMapRawRevision(envMapKey string, revision uint64) (any, bool) {
+	if snapshot, exists := view.store.envMaps[envMapKey]; exists && snapshot.revision == revision {
+		return snapshot.data, true
+	}
+	for _, snapshot := range view.store.history[envMapKey] {
+		if snapshot.revision == revision {
+			return snapshot.data, true
+		}
+	}
+	return nil, false
+}
+
+// ViewEnvMapRevision view üzerinde revision ile env map döner. EnvInfos salt okunurdur.
+func ViewEnvMapRevision[K comparable, V any](view EnvView, envMapKey string, revision uint64) (e.EnvMapData[K, V], error) {
+	data, exists := view.EnvMapRawRevision(envMapKey, revision)
+	if !exists {
+		return e.EnvMapData[K, V]{}, GetFuncError(EnvMapRevisionNotFound, nil, envMapKey, revision)
+	}
+	typedData, valid := data.(e.EnvMapData[K, V])
+	if !valid {
+		return e.EnvMapData[K, V]{}, GetFuncError(EnvMapTypeMismatch, nil)
+	}
+	return typedData, nil
+}
@@ -0,0 +1,19 @@
+package processors
+
+// internal-env-keys
+const (
+	EnvSchemaEnvTag = `env-schema-env-tag`
+
+	EnvSchemaTypeString   = `string`
+	EnvSchemaTypeInt      = `int`
+	EnvSchemaTypeBool     = `bool`
+	EnvSchemaTypeDuration = `duration`
+	EnvSchemaTypeURL      = `url`
+	EnvSchemaTypeHostPort = `host-port`
+	EnvSchemaTypeEnum     = `enum`
+	EnvSchemaTypeList     = `list`
+	EnvSchemaTypeMap      = `map`
+	EnvSchemaTypeSecret   = `secret` //value EnvValueKindSecret olmak zorundadır, plaintext doğrulanmaz.
+)
+
+// internal-env-keys
@@ -0,0 +1,9 @@
+package processors
+
+// internal-env-keys
+const (
+	EnvValueKindSecret = `secret`
+	RedactedEnvValue   = `[REDACTED]`
+)
+
+// internal-env-keys
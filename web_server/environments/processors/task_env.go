@@ -30,6 +30,33 @@ const (
 	*/
 	FuncIncludeEnvMapPerm = `func-include-env-map-perm`
 	FuncGetEnvPerm        = `func-get-env-perm`
+
+	/*
+		kafka topic api task keys owner PermInfos içerisinde aranır.
+		create/alter/delete/rotate-key Bridge, describe Read yetkisi ister. Topic üzerinde ayrıca kafka-topic:<topic> yetkisi aranır.
+	*/
+	KafkaTopicCreateTask      = `kafka-topic-create-task`
+	KafkaTopicDescribeTask    = `kafka-topic-describe-task`
+	KafkaTopicAlterConfigTask = `kafka-topic-alter-config-task`
+	KafkaTopicDeleteTask      = `kafka-topic-delete-task`
+	KafkaTopicRotateKeyTask   = `kafka-topic-rotate-key-task`
+
+	/*
+		kafka rest proxy task keys. Produce Write, consume Read yetkisi ister.
+		Topic üzerinde kafka-topic:<topic>, consumer group üzerinde kafka-group:<group> yetkisi ayrıca aranır.
+	*/
+	KafkaProxyProduceTask = `kafka-proxy-produce-task`
+	KafkaProxyConsumeTask = `kafka-proxy-consume-task`
+
+	//env dry run istekleri hiçbir değişiklik yapmaz, Read yetkisi ister.
+	EnvDryRunTask = `env-dry-run-task`
+
+	//karantina kayıtları listeleme ve inceleme Read, silme Write yetkisi ister.
+	EnvQuarantineReadTask  = `env-quarantine-read-task`
+	EnvQuarantinePurgeTask = `env-quarantine-purge-task`
+
+	//env map diff ve revisions istekleri hiçbir değişiklik yapmaz, Read yetkisi ister.
+	EnvDiffTask = `env-diff-task`
 	// IncPathEnvPerm      = `inc-path-env-perm`       //RWPermType
 	// IncTaskEnvPerm      = `inc-task-env-perm`       //RWSPermType
 	// IncRestEnvPerm      = `inc-rest-env-perm`       //RWBPermType
@@ -42,6 +69,17 @@ const (
 var TaskEnvKeyRefSlice []string = []string{
 	FuncIncludeEnvMapPerm,
 	FuncGetEnvPerm,
+	KafkaTopicCreateTask,
+	KafkaTopicDescribeTask,
+	KafkaTopicAlterConfigTask,
+	KafkaTopicDeleteTask,
+	KafkaTopicRotateKeyTask,
+	KafkaProxyProduceTask,
+	KafkaProxyConsumeTask,
+	EnvDryRunTask,
+	EnvQuarantineReadTask,
+	EnvQuarantinePurgeTask,
+	EnvDiffTask,
 }
 
 // external-env-keys
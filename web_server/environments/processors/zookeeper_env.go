@@ -0,0 +1,57 @@
+package processors
+
+// internal-env-keys
+const (
+	ZookeeperEnvTag = `zookeeper-env-tag`
+
+	ZookeeperRoleParticipant = `participant`
+	ZookeeperRoleObserver    = `observer`
+
+	ZookeeperModeLeader     = `leader`
+	ZookeeperModeFollower   = `follower`
+	ZookeeperModeObserver   = `observer`
+	ZookeeperModeStandalone = `standalone`
+
+	ZookeeperStepAdd    = `add`
+	ZookeeperStepRemove = `remove`
+	ZookeeperStepUpdate = `update`
+
+	//four letter words komutları zoo.cfg 4lw.commands.whitelist içerisinde bulunmak zorundadır.
+	ZookeeperCmdSrvr = `srvr`
+	ZookeeperCmdMntr = `mntr`
+	ZookeeperCmdConf = `conf`
+
+	ZookeeperPropDynamicConfigFile = `dynamicConfigFile`
+	ZookeeperPropReconfigEnabled   = `reconfigEnabled`
+	ZookeeperProp4lwWhitelist      = `4lw.commands.whitelist`
+
+	ZookeeperConfigFile        = `zoo.cfg`
+	ZookeeperDynamicConfigFile = `dynamic-config.cfg`
+	ZookeeperMemberDirPrefix   = `zookeeper` //üretilen files <path>/zookeeper<member_id>/ altına yazılır.
+)
+
+// zoo.cfg üzerinde ensemble bilgisi verilmesi engellenen keys, ensemble sadece dynamic config üzerinden yönetilir.
+var ZookeeperDynamicOnlyPropSlice []string = []string{`server.`, `group.`, `weight.`}
+
+// verify işlemi için whitelist içerisinde bulunması gereken komutlar
+var ZookeeperFourLetterWordSlice []string = []string{ZookeeperCmdSrvr, ZookeeperCmdMntr, ZookeeperCmdConf}
+
+// internal-env-keys
+
+// external-env-keys
+const (
+	ZookeeperEnsemble          = `zookeeper-ensemble`            //e.ZookeeperEnsembleData => istenen ensemble üyeleri
+	ZookeeperConfigProperties  = `zookeeper-config-properties`   //map[string]string => zoo.cfg static properties
+	ZookeeperDynamicConfigPath = `zookeeper-dynamic-config-path` //string => container içerisindeki dynamic config file path
+	ZookeeperConfigPath        = `zookeeper-config-path`         //string => üretilen files dizini
+)
+
+// zookeeper env keys main env içerisinde barınır, doğrulamak için reference alınacak slice
+var ZookeeperEnvKeyRefSlice []string = []string{
+	ZookeeperEnsemble,
+	ZookeeperConfigProperties,
+	ZookeeperDynamicConfigPath,
+	ZookeeperConfigPath,
+}
+
+// external-env-keys
@@ -0,0 +1,154 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+	a "web_server/domain/abstractions"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+	u "web_server/utils"
+)
+
+// FileAuditLog, kayıtları her satırda bir json olacak şekilde file sonuna ekler.
+type FileAuditLog struct {
+	mu       sync.Mutex
+	filePath string
+	sequence uint64
+	lastHash []byte
+}
+
+// derleme zamanı audit log interface check
+var _ a.IAuditLog = (*FileAuditLog)(nil)
+
+// mevcut file okunur, zincir doğrulanır ve yeni kayıtlar son kaydın devamı olarak yazılır.
+func NewFileAuditLog(filePath string) (*FileAuditLog, error) {
+	entries, err := ReadAuditFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := VerifyAuditChain(entries); err != nil {
+		return nil, err
+	}
+
+	auditLog := &FileAuditLog{filePath: filePath}
+	if len(entries) > 0 {
+		last := entries[len(entries)-1]
+		auditLog.sequence, auditLog.lastHash = last.Sequence, last.Hash
+	}
+	return auditLog, nil
+}
+
+func (l *FileAuditLog) IFAppend(entry e.AuditEntryData) (e.AuditEntryData, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, err := chainEntry(entry, l.sequence, l.lastHash)
+	if err != nil {
+		return entry, err
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return entry, env.GetFuncError(env.AuditWriteFailed, err)
+	}
+
+	file, err := os.OpenFile(l.filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return entry, env.GetFuncError(env.AuditWriteFailed, err)
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return entry, env.GetFuncError(env.AuditWriteFailed, err)
+	}
+	//kayıt diske yazılmadan işlem tamamlanmış sayılmaz.
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return entry, env.GetFuncError(env.AuditWriteFailed, err)
+	}
+	if err := file.Close(); err != nil {
+		return entry, env.GetFuncError(env.AuditWriteFailed, err)
+	}
+
+	l.sequence, l.lastHash = entry.Sequence, entry.Hash
+	return entry, nil
+}
+
+// audit file kayıtları okunur. File yoksa boş liste döner.
+func ReadAuditFile(filePath string) ([]e.AuditEntryData, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, env.GetFuncError(env.AuditWriteFailed, err)
+	}
+	defer file.Close()
+
+	var entries []e.AuditEntryData
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		entry := e.AuditEntryData{}
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, env.GetFuncError(env.AuditWriteFailed, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, env.GetFuncError(env.AuditWriteFailed, err)
+	}
+	return entries, nil
+}
+
+// kayıtların sırası, önceki kayıt hash bilgisi ve kendi hash bilgisi kontrol edilir.
+func VerifyAuditChain(entries []e.AuditEntryData) error {
+	var prevHash []byte
+	for i, entry := range entries {
+		if entry.Sequence != uint64(i+1) || !u.ComparisonHash(entry.PrevHash, prevHash) {
+			return env.GetFuncError(env.AuditWriteFailed, fmt.Errorf("audit chain broken at sequence %d", entry.Sequence))
+		}
+
+		hash, err := entryHash(entry)
+		if err != nil {
+			return err
+		}
+		if !u.ComparisonHash(entry.Hash, hash) {
+			return env.GetFuncError(env.AuditWriteFailed, fmt.Errorf("audit entry hash mismatch at sequence %d", entry.Sequence))
+		}
+		prevHash = entry.Hash
+	}
+	return nil
+}
+
+// kayıt zincire bağlanır, sıra, zaman ve hash bilgileri eklenir.
+func chainEntry(entry e.AuditEntryData, sequence uint64, prevHash []byte) (e.AuditEntryData, error) {
+	entry.Sequence = sequence + 1
+	entry.PrevHash = prevHash
+	if entry.CreatedAt == 0 {
+		entry.CreatedAt = time.Now().Unix()
+	}
+
+	hash, err := entryHash(entry)
+	if err != nil {
+		return entry, err
+	}
+	entry.Hash = hash
+	return entry, nil
+}
+
+func entryHash(entry e.AuditEntryData) ([]byte, error) {
+	entry.Hash = nil
+	encodedEntry, err := u.MarshalCanonicalCBOR(entry)
+	if err != nil {
+		return nil, err
+	}
+	return u.GenerateNativeBytetoSHA2(encodedEntry), nil
+}
@@ -0,0 +1,46 @@
+package audit
+
+import (
+	"sync"
+	a "web_server/domain/abstractions"
+	e "web_server/domain/entities"
+)
+
+// MemoryAuditLog, kayıtları bellek üzerinde tutar. Dry-run ve test senaryolarında kullanılır.
+type MemoryAuditLog struct {
+	mu      sync.Mutex
+	entries []e.AuditEntryData
+}
+
+// derleme zamanı audit log interface check
+var _ a.IAuditLog = (*MemoryAuditLog)(nil)
+
+func NewMemoryAuditLog() *MemoryAuditLog {
+	return &MemoryAuditLog{}
+}
+
+func (l *MemoryAuditLog) IFAppend(entry e.AuditEntryData) (e.AuditEntryData, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var sequence uint64
+	var prevHash []byte
+	if len(l.entries) > 0 {
+		last := l.entries[len(l.entries)-1]
+		sequence, prevHash = last.Sequence, last.Hash
+	}
+
+	entry, err := chainEntry(entry, sequence, prevHash)
+	if err != nil {
+		return entry, err
+	}
+	l.entries = append(l.entries, entry)
+	return entry, nil
+}
+
+// yazılan kayıtların kopyası döner.
+func (l *MemoryAuditLog) Entries() []e.AuditEntryData {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]e.AuditEntryData(nil), l.entries...)
+}
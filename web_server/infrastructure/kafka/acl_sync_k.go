@@ -0,0 +1,213 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	a "web_server/domain/abstractions"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+	u "web_server/utils"
+)
+
+// whitelist key bilgisinden sistem tarafından yönetilen kafka principal üretilir.
+func KafkaPrincipal(whitelistKey string) string {
+	return env.KafkaPrincipalType + KafkaUserName(whitelistKey)
+}
+
+// whitelist key bilgisinden kafka (SASL) kullanıcı adı üretilir.
+func KafkaUserName(whitelistKey string) string {
+	return env.KafkaUserPrefix + whitelistKey
+}
+
+// principal sistem tarafından yönetiliyor mu kontrol edilir. Yönetilmeyen principal acl kayıtlarına dokunulmaz.
+func isManagedPrincipal(principal string) bool {
+	return strings.HasPrefix(principal, env.KafkaPrincipalType+env.KafkaUserPrefix)
+}
+
+// permission key bilgisinden kafka resource type, pattern ve name bilgisi çıkarılır.
+func parsePermResource(permKey string) (resourceType, patternType, resourceName string, ok bool) {
+	switch {
+	case strings.HasPrefix(permKey, env.KafkaTopicPrefixPermPrefix):
+		return env.KafkaResourceTopic, env.KafkaPatternPrefixed, strings.TrimPrefix(permKey, env.KafkaTopicPrefixPermPrefix), true
+	case strings.HasPrefix(permKey, env.KafkaTopicPermPrefix):
+		return env.KafkaResourceTopic, env.KafkaPatternLiteral, strings.TrimPrefix(permKey, env.KafkaTopicPermPrefix), true
+	case strings.HasPrefix(permKey, env.KafkaGroupPrefixPermPrefix):
+		return env.KafkaResourceGroup, env.KafkaPatternPrefixed, strings.TrimPrefix(permKey, env.KafkaGroupPrefixPermPrefix), true
+	case strings.HasPrefix(permKey, env.KafkaGroupPermPrefix):
+		return env.KafkaResourceGroup, env.KafkaPatternLiteral, strings.TrimPrefix(permKey, env.KafkaGroupPermPrefix), true
+	default:
+		return "", "", "", false
+	}
+}
+
+/*
+owner permission bitleri kafka operation karşılığına çevrilir.
+- topic: Read => READ/DESCRIBE, Write => WRITE/DESCRIBE, Bridge => READ/WRITE/DESCRIBE
+- group: Read ya da Bridge => READ
+- Swap kafka tarafında karşılığı olmadığı için acl üretmez.
+*/
+func permOperations(resourceType string, permType uint8) []string {
+	var operations []string
+	switch resourceType {
+	case env.KafkaResourceTopic:
+		if permType&(env.Read|env.Bridge) != 0 {
+			operations = append(operations, env.KafkaOperationRead)
+		}
+		if permType&(env.Write|env.Bridge) != 0 {
+			operations = append(operations, env.KafkaOperationWrite)
+		}
+		if len(operations) > 0 {
+			operations = append(operations, env.KafkaOperationDescribe)
+		}
+	case env.KafkaResourceGroup:
+		if permType&(env.Read|env.Bridge) != 0 {
+			operations = append(operations, env.KafkaOperationRead)
+		}
+	}
+	return operations
+}
+
+// owner AuthnData bilgilerinden broker üzerinde olması gereken acl listesi üretilir.
+func BuildDesiredACLs(ownerAuthnInfos map[string]e.AuthnData) []e.KafkaACLData {
+	desired := map[e.KafkaACLData]struct{}{}
+
+	for whitelistKey, authnInfo := range ownerAuthnInfos {
+		principal := KafkaPrincipal(whitelistKey)
+		canWrite := false
+
+		for permKey, permInfo := range authnInfo.PermInfos {
+			resourceType, patternType, resourceName, ok := parsePermResource(permKey)
+			if !ok || resourceName == "" {
+				continue
+			}
+
+			//status bilgisi geçerli olmayan permission acl üretmez.
+			if err := u.CheckDataStatusInfos(&e.CheckDataStatusInfosInput{
+				Status:      permInfo.StatusInfos.Status,
+				ActiveAt:    permInfo.StatusInfos.ActiveAt,
+				ExpiresAt:   permInfo.StatusInfos.ExpiresAt,
+				Description: permInfo.StatusInfos.Description,
+			}); err != nil {
+				continue
+			}
+
+			for _, operation := range permOperations(resourceType, permInfo.PermType) {
+				if operation == env.KafkaOperationWrite {
+					canWrite = true
+				}
+				desired[e.KafkaACLData{
+					Principal:    principal,
+					Host:         env.KafkaAnyHost,
+					ResourceType: resourceType,
+					ResourceName: resourceName,
+					PatternType:  patternType,
+					Operation:    operation,
+					Permission:   env.KafkaPermissionAllow,
+				}] = struct{}{}
+			}
+		}
+
+		//herhangi bir topic üzerinde yazma yetkisi olan owner idempotent producer kullanabilir.
+		if canWrite {
+			desired[e.KafkaACLData{
+				Principal:    principal,
+				Host:         env.KafkaAnyHost,
+				ResourceType: env.KafkaResourceCluster,
+				ResourceName: env.KafkaClusterName,
+				PatternType:  env.KafkaPatternLiteral,
+				Operation:    env.KafkaOperationIdempotentWrite,
+				Permission:   env.KafkaPermissionAllow,
+			}] = struct{}{}
+		}
+	}
+
+	acls := make([]e.KafkaACLData, 0, len(desired))
+	for acl := range desired {
+		acls = append(acls, acl)
+	}
+	sortACLs(acls)
+	return acls
+}
+
+// istenen acl listesi ile broker üzerindeki acl listesi karşılaştırılır. Sadece yönetilen principal kayıtları silinir.
+func PlanACLs(desired, current []e.KafkaACLData) e.KafkaACLPlanData {
+	currentSet := make(map[e.KafkaACLData]struct{}, len(current))
+	for _, acl := range current {
+		currentSet[acl] = struct{}{}
+	}
+
+	desiredSet := make(map[e.KafkaACLData]struct{}, len(desired))
+	plan := e.KafkaACLPlanData{}
+	for _, acl := range desired {
+		desiredSet[acl] = struct{}{}
+		if _, ok := currentSet[acl]; !ok {
+			plan.Create = append(plan.Create, acl)
+		}
+	}
+
+	for _, acl := range current {
+		if !isManagedPrincipal(acl.Principal) {
+			continue
+		}
+		if _, ok := desiredSet[acl]; !ok {
+			plan.Delete = append(plan.Delete, acl)
+		}
+	}
+
+	sortACLs(plan.Create)
+	sortACLs(plan.Delete)
+	return plan
+}
+
+/*
+- owner yetkilerinden üretilen acl listesi broker üzerindeki acl listesi ile karşılaştırılır ve sadece farklar uygulanır.
+- DryRun durumunda broker üzerinde değişiklik yapılmaz, sadece plan döner.
+- erişim boşluğu oluşmaması için önce eklemeler sonra silmeler uygulanır.
+*/
+func SyncACLs(ctx context.Context, admin a.IKafkaAdmin, input e.SyncKafkaACLInput) (e.KafkaACLPlanData, error) {
+	current, err := admin.IFDescribeACLs(ctx)
+	if err != nil {
+		return e.KafkaACLPlanData{}, err
+	}
+
+	plan := PlanACLs(BuildDesiredACLs(input.OwnerAuthnInfos), current)
+	if input.DryRun {
+		return plan, nil
+	}
+
+	if len(plan.Create) > 0 {
+		if err := admin.IFCreateACLs(ctx, plan.Create); err != nil {
+			return plan, err
+		}
+	}
+
+	if len(plan.Delete) > 0 {
+		if err := admin.IFDeleteACLs(ctx, plan.Delete); err != nil {
+			return plan, err
+		}
+	}
+	return plan, nil
+}
+
+// acl planı okunabilir formatta yazdırılır.
+func FormatACLPlan(plan e.KafkaACLPlanData) string {
+	if len(plan.Create) == 0 && len(plan.Delete) == 0 {
+		return env.GetFuncStatus(env.SpecificOK, "kafka acls are up to date") + "\n"
+	}
+
+	var builder strings.Builder
+	for _, acl := range plan.Create {
+		builder.WriteString(formatACL(env.KafkaACLPlanCreate, acl))
+	}
+	for _, acl := range plan.Delete {
+		builder.WriteString(formatACL(env.KafkaACLPlanDelete, acl))
+	}
+	fmt.Fprintf(&builder, "%d to create, %d to delete\n", len(plan.Create), len(plan.Delete))
+	return builder.String()
+}
+
+func formatACL(action string, acl e.KafkaACLData) string {
+	return fmt.Sprintf("%s %s %s %s:%s:%s host=%s\n",
+		action, acl.Principal, acl.Operation, acl.ResourceType, acl.PatternType, acl.ResourceName, acl.Host)
+}
@@ -0,0 +1,416 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"sort"
+	a "web_server/domain/abstractions"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+// KafkaAdmin, franz-go admin client üzerinden broker yönetim işlemlerini gerçekleştirir.
+type KafkaAdmin struct {
+	client *kgo.Client
+	admin  *kadm.Client
+}
+
+// derleme zamanı kafka admin interface check
+var _ a.IKafkaAdmin = (*KafkaAdmin)(nil)
+var _ a.IKafkaTopicAdmin = (*KafkaAdmin)(nil)
+var _ a.IKafkaMetricsAdmin = (*KafkaAdmin)(nil)
+
+func NewKafkaAdmin(seedBrokers []string, opts ...kgo.Opt) (*KafkaAdmin, error) {
+	client, err := kgo.NewClient(append([]kgo.Opt{kgo.SeedBrokers(seedBrokers...)}, opts...)...)
+	if err != nil {
+		return nil, env.GetFuncError(env.KafkaOperationFailed, err, "new client")
+	}
+	return &KafkaAdmin{client: client, admin: kadm.NewClient(client)}, nil
+}
+
+func (k *KafkaAdmin) Close() {
+	k.admin.Close()
+}
+
+// broker üzerindeki bütün allow acl kayıtları getirilir.
+func (k *KafkaAdmin) IFDescribeACLs(ctx context.Context) ([]e.KafkaACLData, error) {
+	builder := kadm.NewACLs().
+		AnyResource().
+		ResourcePatternType(kadm.ACLPatternAny).
+		Operations(kadm.OpAny).
+		Allow().
+		AllowHosts()
+
+	results, err := k.admin.DescribeACLs(ctx, builder)
+	if err != nil {
+		return nil, env.GetFuncError(env.KafkaOperationFailed, err, "describe acls")
+	}
+
+	var acls []e.KafkaACLData
+	for _, result := range results {
+		if result.Err != nil {
+			return nil, env.GetFuncError(env.KafkaOperationFailed, result.Err, "describe acls")
+		}
+		for _, described := range result.Described {
+			acls = append(acls, e.KafkaACLData{
+				Principal:    described.Principal,
+				Host:         described.Host,
+				ResourceType: described.Type.String(),
+				ResourceName: described.Name,
+				PatternType:  described.Pattern.String(),
+				Operation:    described.Operation.String(),
+				Permission:   described.Permission.String(),
+			})
+		}
+	}
+	return acls, nil
+}
+
+func (k *KafkaAdmin) IFCreateACLs(ctx context.Context, acls []e.KafkaACLData) error {
+	for _, acl := range acls {
+		builder, err := newACLBuilder(acl)
+		if err != nil {
+			return err
+		}
+
+		results, err := k.admin.CreateACLs(ctx, builder)
+		if err != nil {
+			return env.GetFuncError(env.KafkaOperationFailed, err, "create acls")
+		}
+		for _, result := range results {
+			if result.Err != nil {
+				return env.GetFuncError(env.KafkaOperationFailed, result.Err, "create acls")
+			}
+		}
+	}
+	return nil
+}
+
+func (k *KafkaAdmin) IFDeleteACLs(ctx context.Context, acls []e.KafkaACLData) error {
+	for _, acl := range acls {
+		builder, err := newACLBuilder(acl)
+		if err != nil {
+			return err
+		}
+
+		results, err := k.admin.DeleteACLs(ctx, builder)
+		if err != nil {
+			return env.GetFuncError(env.KafkaOperationFailed, err, "delete acls")
+		}
+		for _, result := range results {
+			if result.Err != nil {
+				return env.GetFuncError(env.KafkaOperationFailed, result.Err, "delete acls")
+			}
+		}
+	}
+	return nil
+}
+
+// broker üzerinde scram credential bulunan bütün kullanıcılar getirilir.
+func (k *KafkaAdmin) IFDescribeScramUsers(ctx context.Context) ([]e.KafkaScramUserData, error) {
+	described, err := k.admin.DescribeUserSCRAMs(ctx)
+	if err != nil {
+		return nil, env.GetFuncError(env.KafkaOperationFailed, err, "describe scram users")
+	}
+
+	var users []e.KafkaScramUserData
+	for _, userInfo := range described.Sorted() {
+		if userInfo.Err != nil {
+			//credential bulunmayan kullanıcılar hata olarak dönebilir.
+			if errors.Is(userInfo.Err, kerr.ResourceNotFound) {
+				continue
+			}
+			return nil, env.GetFuncError(env.KafkaOperationFailed, userInfo.Err, "describe scram users")
+		}
+		for _, credInfo := range userInfo.CredInfos {
+			users = append(users, e.KafkaScramUserData{
+				UserName:   userInfo.User,
+				Mechanism:  credInfo.Mechanism.String(),
+				Iterations: credInfo.Iterations,
+			})
+		}
+	}
+	return users, nil
+}
+
+func (k *KafkaAdmin) IFUpsertScramCredentials(ctx context.Context, inputs []e.UpsertKafkaScramInput) error {
+	upserts := make([]kadm.UpsertSCRAM, 0, len(inputs))
+	for _, input := range inputs {
+		mechanism, err := parseScramMechanism(input.Mechanism)
+		if err != nil {
+			return err
+		}
+		upserts = append(upserts, kadm.UpsertSCRAM{
+			User:           input.UserName,
+			Mechanism:      mechanism,
+			Iterations:     input.SecretInfo.Iterations,
+			Salt:           input.SecretInfo.Salt,
+			SaltedPassword: input.SecretInfo.SaltedPassword,
+		})
+	}
+
+	altered, err := k.admin.AlterUserSCRAMs(ctx, nil, upserts)
+	if err != nil {
+		return env.GetFuncError(env.KafkaOperationFailed, err, "upsert scram credentials")
+	}
+	if err := altered.Error(); err != nil {
+		return env.GetFuncError(env.KafkaOperationFailed, err, "upsert scram credentials")
+	}
+	return nil
+}
+
+func (k *KafkaAdmin) IFDeleteScramCredentials(ctx context.Context, users []e.KafkaScramUserData) error {
+	deletes := make([]kadm.DeleteSCRAM, 0, len(users))
+	for _, user := range users {
+		mechanism, err := parseScramMechanism(user.Mechanism)
+		if err != nil {
+			return err
+		}
+		deletes = append(deletes, kadm.DeleteSCRAM{User: user.UserName, Mechanism: mechanism})
+	}
+
+	altered, err := k.admin.AlterUserSCRAMs(ctx, deletes, nil)
+	if err != nil {
+		return env.GetFuncError(env.KafkaOperationFailed, err, "delete scram credentials")
+	}
+	if err := altered.Error(); err != nil {
+		return env.GetFuncError(env.KafkaOperationFailed, err, "delete scram credentials")
+	}
+	return nil
+}
+
+// topic partition bilgisi metadata, configs sadece topic üzerinde tanımlananlar olacak şekilde getirilir.
+func (k *KafkaAdmin) IFDescribeTopic(ctx context.Context, name string) (e.KafkaTopicData, bool, error) {
+	details, err := k.admin.ListTopics(ctx, name)
+	if err != nil {
+		return e.KafkaTopicData{}, false, env.GetFuncError(env.KafkaOperationFailed, err, "describe topic")
+	}
+
+	detail, ok := details[name]
+	if !ok || errors.Is(detail.Err, kerr.UnknownTopicOrPartition) {
+		return e.KafkaTopicData{}, false, nil
+	}
+	if detail.Err != nil {
+		return e.KafkaTopicData{}, false, env.GetFuncError(env.KafkaOperationFailed, detail.Err, "describe topic")
+	}
+
+	topic := e.KafkaTopicData{
+		Name:       name,
+		Partitions: int32(len(detail.Partitions)),
+		Configs:    map[string]string{},
+	}
+	for _, partition := range detail.Partitions {
+		topic.ReplicationFactor = int16(len(partition.Replicas))
+		break
+	}
+
+	resourceConfigs, err := k.admin.DescribeTopicConfigs(ctx, name)
+	if err != nil {
+		return e.KafkaTopicData{}, false, env.GetFuncError(env.KafkaOperationFailed, err, "describe topic configs")
+	}
+	for _, resourceConfig := range resourceConfigs {
+		if resourceConfig.Err != nil {
+			return e.KafkaTopicData{}, false, env.GetFuncError(env.KafkaOperationFailed, resourceConfig.Err, "describe topic configs")
+		}
+		for _, config := range resourceConfig.Configs {
+			if config.Source == kmsg.ConfigSourceDynamicTopicConfig && config.Value != nil {
+				topic.Configs[config.Key] = *config.Value
+			}
+		}
+	}
+	return topic, true, nil
+}
+
+// topic zaten mevcut ise hata dönmez false döner.
+func (k *KafkaAdmin) IFCreateTopic(ctx context.Context, input e.CreateKafkaTopicInput) (bool, error) {
+	configs := make(map[string]*string, len(input.Configs))
+	for key, value := range input.Configs {
+		configs[key] = &value
+	}
+
+	//tekil CreateTopic topic hatasını err olarak döndüğü için mevcut topic durumu response üzerinden kontrol edilir.
+	responses, err := k.admin.CreateTopics(ctx, input.Partitions, input.ReplicationFactor, configs, input.Name)
+	if err != nil {
+		return false, env.GetFuncError(env.KafkaOperationFailed, err, "create topic")
+	}
+	response := responses[input.Name]
+	if errors.Is(response.Err, kerr.TopicAlreadyExists) {
+		return false, nil
+	}
+	if response.Err != nil {
+		return false, env.GetFuncError(env.KafkaOperationFailed, response.Err, "create topic")
+	}
+	return true, nil
+}
+
+func (k *KafkaAdmin) IFAlterTopicConfigs(ctx context.Context, input e.AlterKafkaTopicConfigInput) error {
+	alterConfigs := make([]kadm.AlterConfig, 0, len(input.SetConfigs)+len(input.DeleteConfigs))
+	for key, value := range input.SetConfigs {
+		alterConfigs = append(alterConfigs, kadm.AlterConfig{Op: kadm.SetConfig, Name: key, Value: &value})
+	}
+	for _, key := range input.DeleteConfigs {
+		alterConfigs = append(alterConfigs, kadm.AlterConfig{Op: kadm.DeleteConfig, Name: key})
+	}
+
+	responses, err := k.admin.AlterTopicConfigs(ctx, alterConfigs, input.Name)
+	if err != nil {
+		return env.GetFuncError(env.KafkaOperationFailed, err, "alter topic configs")
+	}
+	for _, response := range responses {
+		if errors.Is(response.Err, kerr.UnknownTopicOrPartition) {
+			return env.GetFuncError(env.KafkaTopicNotFound, nil, input.Name)
+		}
+		if response.Err != nil {
+			return env.GetFuncError(env.KafkaOperationFailed, response.Err, "alter topic configs")
+		}
+	}
+	return nil
+}
+
+// topic mevcut değilse hata dönmez false döner.
+func (k *KafkaAdmin) IFDeleteTopic(ctx context.Context, name string) (bool, error) {
+	responses, err := k.admin.DeleteTopics(ctx, name)
+	if err != nil {
+		return false, env.GetFuncError(env.KafkaOperationFailed, err, "delete topic")
+	}
+	response := responses[name]
+	if errors.Is(response.Err, kerr.UnknownTopicOrPartition) {
+		return false, nil
+	}
+	if response.Err != nil {
+		return false, env.GetFuncError(env.KafkaOperationFailed, response.Err, "delete topic")
+	}
+	return true, nil
+}
+
+func parseScramMechanism(mechanism string) (kadm.ScramMechanism, error) {
+	switch mechanism {
+	case kadm.ScramSha256.String():
+		return kadm.ScramSha256, nil
+	case kadm.ScramSha512.String():
+		return kadm.ScramSha512, nil
+	default:
+		return 0, env.GetFuncError(env.InvalidScramMechanism, nil, mechanism)
+	}
+}
+
+// tek bir acl kaydı için create/delete işlemlerinde kullanılacak builder hazırlanır.
+func newACLBuilder(acl e.KafkaACLData) (*kadm.ACLBuilder, error) {
+	pattern, err := kmsg.ParseACLResourcePatternType(acl.PatternType)
+	if err != nil {
+		return nil, env.GetFuncError(env.InvalidKafkaACL, err, acl)
+	}
+
+	operation, err := kmsg.ParseACLOperation(acl.Operation)
+	if err != nil {
+		return nil, env.GetFuncError(env.InvalidKafkaACL, err, acl)
+	}
+
+	if acl.Permission != env.KafkaPermissionAllow {
+		return nil, env.GetFuncError(env.InvalidKafkaACL, nil, acl)
+	}
+
+	builder := kadm.NewACLs().
+		Allow(acl.Principal).
+		AllowHosts(acl.Host).
+		ResourcePatternType(pattern).
+		Operations(operation)
+
+	switch acl.ResourceType {
+	case env.KafkaResourceTopic:
+		builder.Topics(acl.ResourceName)
+	case env.KafkaResourceGroup:
+		builder.Groups(acl.ResourceName)
+	case env.KafkaResourceCluster:
+		builder.Clusters()
+	default:
+		return nil, env.GetFuncError(env.InvalidKafkaACL, nil, acl)
+	}
+	return builder, nil
+}
+
+// topic partition bilgileri metadata, log end offsets ListOffsets isteği ile alınır.
+func (k *KafkaAdmin) IFDescribeTopicMetrics(ctx context.Context, topics []string) ([]e.KafkaTopicMetricsData, error) {
+	if len(topics) == 0 {
+		return nil, nil
+	}
+
+	details, err := k.admin.ListTopics(ctx, topics...)
+	if err != nil {
+		return nil, env.GetFuncError(env.KafkaOperationFailed, err, "describe topic metrics")
+	}
+
+	existing := make([]string, 0, len(details))
+	for _, detail := range details.Sorted() {
+		if !errors.Is(detail.Err, kerr.UnknownTopicOrPartition) {
+			existing = append(existing, detail.Topic)
+		}
+	}
+	if len(existing) == 0 {
+		return nil, nil
+	}
+
+	endOffsets, err := k.admin.ListEndOffsets(ctx, existing...)
+	if err != nil {
+		return nil, env.GetFuncError(env.KafkaOperationFailed, err, "list end offsets")
+	}
+
+	var metrics []e.KafkaTopicMetricsData
+	for _, topic := range existing {
+		detail := details[topic]
+		if detail.Err != nil {
+			return nil, env.GetFuncError(env.KafkaOperationFailed, detail.Err, "describe topic metrics "+topic)
+		}
+
+		topicMetrics := e.KafkaTopicMetricsData{Name: topic}
+		for _, partition := range detail.Partitions.Sorted() {
+			endOffset := int64(-1)
+			if listed, ok := endOffsets.Lookup(topic, partition.Partition); ok && listed.Err == nil {
+				endOffset = listed.Offset
+			}
+			topicMetrics.PartitionInfos = append(topicMetrics.PartitionInfos, e.KafkaPartitionMetricsData{
+				Partition:      partition.Partition,
+				Replicas:       len(partition.Replicas),
+				InSyncReplicas: len(partition.ISR),
+				EndOffset:      endOffset,
+			})
+		}
+		metrics = append(metrics, topicMetrics)
+	}
+	return metrics, nil
+}
+
+// consumer group tarafından commit edilen offsets getirilir. Commit bulunmayan partitions sonuçta yer almaz.
+func (k *KafkaAdmin) IFFetchGroupOffsets(ctx context.Context, group string) ([]e.KafkaRecordOffsetData, error) {
+	responses, err := k.admin.FetchOffsets(ctx, group)
+	if err != nil {
+		return nil, env.GetFuncError(env.KafkaOperationFailed, err, "fetch offsets "+group)
+	}
+	if err := responses.Error(); err != nil {
+		return nil, env.GetFuncError(env.KafkaOperationFailed, err, "fetch offsets "+group)
+	}
+
+	var offsets []e.KafkaRecordOffsetData
+	responses.Each(func(response kadm.OffsetResponse) {
+		if response.At >= 0 {
+			offsets = append(offsets, e.KafkaRecordOffsetData{Topic: response.Topic, Partition: response.Partition, Offset: response.At})
+		}
+	})
+	sortOffsets(offsets)
+	return offsets, nil
+}
+
+// offsets topic ve partition sırasına göre dizilir.
+func sortOffsets(offsets []e.KafkaRecordOffsetData) {
+	sort.Slice(offsets, func(i, j int) bool {
+		if offsets[i].Topic != offsets[j].Topic {
+			return offsets[i].Topic < offsets[j].Topic
+		}
+		return offsets[i].Partition < offsets[j].Partition
+	})
+}
@@ -0,0 +1,189 @@
+package kafka
+
+import (
+	"errors"
+	"sort"
+	"time"
+	a "web_server/domain/abstractions"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+	u "web_server/utils"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// record şifrelenirken topic, key version ve record key bilgisi additional data olarak kullanılır, şifreli value başka bir topic ya da key ile eşleştirilemez.
+func recordAdditionalData(topic string, keyVersion uint32, key []byte) ([]byte, error) {
+	//boş key ile key içermeyen record aynı kabul edilir.
+	if len(key) == 0 {
+		key = []byte{}
+	}
+	return u.MarshalCanonicalCBOR([]any{topic, keyVersion, key})
+}
+
+/*
+- yeni topic data key üretilir ve her alıcının x25519 pub key bilgisi ile ayrı ayrı mühürlenir.
+- recipients map[whitelist key] => x25519 pub key. Plaintext data key sadece çağıran tarafa döner, key ring üzerine yazılmaz.
+*/
+func NewTopicKey(version uint32, recipients map[string][]byte) ([]byte, e.KafkaTopicKeyData, error) {
+	dataKey, err := u.GenerateDataKey()
+	if err != nil {
+		return nil, e.KafkaTopicKeyData{}, err
+	}
+
+	ownerKeys := make([]string, 0, len(recipients))
+	for ownerKey := range recipients {
+		ownerKeys = append(ownerKeys, ownerKey)
+	}
+	sort.Strings(ownerKeys)
+
+	now := time.Now().Unix()
+	topicKey := e.KafkaTopicKeyData{
+		Version:    version,
+		StatusInfo: e.StatusData{Status: true, CreatedAt: now, ActiveAt: now, UpdatedAt: now},
+	}
+	for _, ownerKey := range ownerKeys {
+		sealedDataKey, err := u.SealToX25519(recipients[ownerKey], dataKey)
+		if err != nil {
+			return nil, e.KafkaTopicKeyData{}, err
+		}
+		topicKey.SealedKeyInfos = append(topicKey.SealedKeyInfos, e.KafkaSealedDataKeyData{
+			OwnerKey:      ownerKey,
+			SealedDataKey: sealedDataKey,
+			X25519PubKey:  recipients[ownerKey],
+		})
+	}
+	return dataKey, topicKey, nil
+}
+
+// key ring içerisindeki son key aktif key'dir.
+func ActiveTopicKey(keyRing e.KafkaTopicKeyRingInfoData) (e.KafkaTopicKeyData, bool) {
+	if len(keyRing.KeyInfos) == 0 {
+		return e.KafkaTopicKeyData{}, false
+	}
+	return keyRing.KeyInfos[len(keyRing.KeyInfos)-1], true
+}
+
+// key version içerisinde owner için mühürlenmiş data key açılır.
+func OpenTopicDataKey(keyRing e.KafkaTopicKeyRingInfoData, keyVersion uint32, ownerKey string, opener a.ISealedKeyOpener) ([]byte, error) {
+	for _, topicKey := range keyRing.KeyInfos {
+		if topicKey.Version != keyVersion {
+			continue
+		}
+		for _, sealedKey := range topicKey.SealedKeyInfos {
+			if sealedKey.OwnerKey == ownerKey {
+				return opener.IFOpenSealed(sealedKey.SealedDataKey)
+			}
+		}
+	}
+	return nil, env.GetFuncError(env.KafkaTopicKeyNotFound, nil, keyRing.Topic)
+}
+
+// owner'ın alıcı olduğu key versions döner. Diğer alıcıların mühürlenmiş keys sonuçta yer almaz.
+func FilterTopicKeyRing(keyRing e.KafkaTopicKeyRingInfoData, ownerKey string) e.KafkaTopicKeyRingInfoData {
+	filtered := e.KafkaTopicKeyRingInfoData{Topic: keyRing.Topic}
+	for _, topicKey := range keyRing.KeyInfos {
+		for _, sealedKey := range topicKey.SealedKeyInfos {
+			if sealedKey.OwnerKey == ownerKey {
+				filtered.KeyInfos = append(filtered.KeyInfos, e.KafkaTopicKeyData{
+					Version:        topicKey.Version,
+					SealedKeyInfos: []e.KafkaSealedDataKeyData{sealedKey},
+					StatusInfo:     topicKey.StatusInfo,
+				})
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// key ring canonical cbor karşılığı üzerinden imzalanır.
+func SignTopicKeyRing(signer a.ISigner, keyRing e.KafkaTopicKeyRingInfoData) (e.KafkaTopicKeyRingData, error) {
+	encodedKeyRing, err := u.MarshalCanonicalCBOR(keyRing)
+	if err != nil {
+		return e.KafkaTopicKeyRingData{}, err
+	}
+	signatureInfos, err := signer.IFSign(encodedKeyRing)
+	if err != nil {
+		return e.KafkaTopicKeyRingData{}, err
+	}
+	return e.KafkaTopicKeyRingData{KeyRingInfos: keyRing, SignatureInfos: signatureInfos}, nil
+}
+
+func VerifyTopicKeyRing(pubKey []byte, keyRing e.KafkaTopicKeyRingData) error {
+	encodedKeyRing, err := u.MarshalCanonicalCBOR(keyRing.KeyRingInfos)
+	if err != nil {
+		return err
+	}
+	return u.VerifySignED25519(e.VerifySignED25519Input{
+		PublicKey: pubKey,
+		Signed:    keyRing.SignatureInfos.Signature,
+		Data:      encodedKeyRing,
+	})
+}
+
+/*
+- record value topic data key ile şifrelenir, record key partition ve compaction için şifrelenmez.
+- şifreleme kaftion-encryption header ile belirtilir. Imzalı envelope istenirse envelope şifreli value üzerinden oluşturulur.
+*/
+func EncryptRecord(topic string, keyVersion uint32, dataKey []byte, record e.KafkaProduceRecordData) (e.KafkaProduceRecordData, error) {
+	additionalData, err := recordAdditionalData(topic, keyVersion, record.Key)
+	if err != nil {
+		return record, err
+	}
+	nonce, ciphertext, err := u.EncryptXChaCha20Poly1305(dataKey, record.Value, additionalData)
+	if err != nil {
+		return record, err
+	}
+
+	encodedRecord, err := u.MarshalCanonicalCBOR(e.KafkaEncryptedRecordData{
+		KeyVersion: keyVersion,
+		Nonce:      nonce,
+		Ciphertext: ciphertext,
+	})
+	if err != nil {
+		return record, err
+	}
+
+	record.Value = encodedRecord
+	record.Headers = append(append([]e.KafkaRecordHeaderData(nil), record.Headers...), e.KafkaRecordHeaderData{
+		Key:   env.KafkaEncryptionHeader,
+		Value: []byte(env.KafkaEncryptionVersion),
+	})
+	return record, nil
+}
+
+/*
+- consumer tarafında şifreli value owner'a mühürlenmiş data key ile açılır.
+- record imzalı envelope içeriyorsa value olarak doğrulanan envelope value bilgisi verilir.
+*/
+func DecryptRecordValue(keyRing e.KafkaTopicKeyRingInfoData, ownerKey string, opener a.ISealedKeyOpener, topic string, key []byte, value []byte) ([]byte, error) {
+	if keyRing.Topic != topic {
+		return nil, env.GetFuncError(env.InvalidKafkaEncryptedRecord, errors.New("topic mismatch"))
+	}
+
+	encryptedRecord := e.KafkaEncryptedRecordData{}
+	if err := cbor.Unmarshal(value, &encryptedRecord); err != nil {
+		return nil, env.GetFuncError(env.InvalidKafkaEncryptedRecord, err)
+	}
+
+	dataKey, err := OpenTopicDataKey(keyRing, encryptedRecord.KeyVersion, ownerKey, opener)
+	if err != nil {
+		return nil, err
+	}
+	additionalData, err := recordAdditionalData(topic, encryptedRecord.KeyVersion, key)
+	if err != nil {
+		return nil, err
+	}
+	return u.DecryptXChaCha20Poly1305(dataKey, encryptedRecord.Nonce, encryptedRecord.Ciphertext, additionalData)
+}
+
+// record kaftion-encryption header içeriyor mu kontrol edilir.
+func IsRecordEncrypted(headers []e.KafkaRecordHeaderData) bool {
+	for _, header := range headers {
+		if header.Key == env.KafkaEncryptionHeader {
+			return true
+		}
+	}
+	return false
+}
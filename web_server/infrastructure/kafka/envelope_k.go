@@ -0,0 +1,86 @@
+package kafka
+
+import (
+	"errors"
+	"time"
+	a "web_server/domain/abstractions"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+	u "web_server/utils"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+/*
+- record value imzalı envelope içerisine alınır, producer owner ve topic bilgisi imzaya dahil edilir.
+- envelope olduğu kaftion-envelope header ile belirtilir, consumer tarafı OpenRecordEnvelope ile doğrular.
+*/
+func SealRecordEnvelope(signer a.ISigner, producer string, topic string, record e.KafkaProduceRecordData) (e.KafkaProduceRecordData, error) {
+	envelopeInfos := e.KafkaEnvelopeInfoData{
+		Producer:  producer,
+		Topic:     topic,
+		Key:       record.Key,
+		Value:     record.Value,
+		CreatedAt: time.Now().Unix(),
+	}
+
+	encodedInfos, err := u.MarshalCanonicalCBOR(envelopeInfos)
+	if err != nil {
+		return record, err
+	}
+	signatureInfos, err := signer.IFSign(encodedInfos)
+	if err != nil {
+		return record, err
+	}
+
+	encodedEnvelope, err := u.MarshalCanonicalCBOR(e.KafkaRecordEnvelopeData{
+		EnvelopeInfos:  envelopeInfos,
+		SignatureInfos: signatureInfos,
+	})
+	if err != nil {
+		return record, err
+	}
+
+	record.Value = encodedEnvelope
+	record.Headers = append(append([]e.KafkaRecordHeaderData(nil), record.Headers...), e.KafkaRecordHeaderData{
+		Key:   env.KafkaEnvelopeHeader,
+		Value: []byte(env.KafkaEnvelopeVersion),
+	})
+	return record, nil
+}
+
+// envelope imzası ve record ile eşleşmesi kontrol edilir, doğrulanan envelope bilgisi döner.
+func OpenRecordEnvelope(pubKey []byte, record e.KafkaConsumedRecordData) (e.KafkaEnvelopeInfoData, error) {
+	envelope := e.KafkaRecordEnvelopeData{}
+	if err := cbor.Unmarshal(record.Value, &envelope); err != nil {
+		return e.KafkaEnvelopeInfoData{}, env.GetFuncError(env.InvalidKafkaEnvelope, err)
+	}
+
+	encodedInfos, err := u.MarshalCanonicalCBOR(envelope.EnvelopeInfos)
+	if err != nil {
+		return e.KafkaEnvelopeInfoData{}, err
+	}
+	if err := u.VerifySignED25519(e.VerifySignED25519Input{
+		PublicKey: pubKey,
+		Signed:    envelope.SignatureInfos.Signature,
+		Data:      encodedInfos,
+	}); err != nil {
+		return e.KafkaEnvelopeInfoData{}, env.GetFuncError(env.InvalidKafkaEnvelope, err)
+	}
+
+	//imzalı envelope başka bir topic üzerine kopyalanamaz.
+	if envelope.EnvelopeInfos.Topic != record.Topic {
+		return e.KafkaEnvelopeInfoData{}, env.GetFuncError(env.InvalidKafkaEnvelope, errors.New("topic mismatch"))
+	}
+	return envelope.EnvelopeInfos, nil
+}
+
+// record kaftion-envelope header içeriyor mu kontrol edilir.
+func IsRecordEnvelope(headers []e.KafkaRecordHeaderData) bool {
+	for _, header := range headers {
+		if header.Key == env.KafkaEnvelopeHeader {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,196 @@
+package kafka
+
+import (
+	"context"
+	"sort"
+	"sync"
+	a "web_server/domain/abstractions"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+)
+
+// MemoryAdmin, broker yerine geçen bellek üzerindeki admin client. Dry-run ve test senaryolarında kullanılır.
+type MemoryAdmin struct {
+	mu         sync.Mutex
+	acls       map[e.KafkaACLData]struct{}
+	scramUsers map[e.KafkaScramUserData]e.KafkaScramSecretData
+	topics     map[string]e.KafkaTopicData
+}
+
+// derleme zamanı kafka admin interface check
+var _ a.IKafkaAdmin = (*MemoryAdmin)(nil)
+var _ a.IKafkaTopicAdmin = (*MemoryAdmin)(nil)
+
+func NewMemoryAdmin() *MemoryAdmin {
+	return &MemoryAdmin{
+		acls:       map[e.KafkaACLData]struct{}{},
+		scramUsers: map[e.KafkaScramUserData]e.KafkaScramSecretData{},
+		topics:     map[string]e.KafkaTopicData{},
+	}
+}
+
+func (m *MemoryAdmin) IFDescribeACLs(ctx context.Context) ([]e.KafkaACLData, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	acls := make([]e.KafkaACLData, 0, len(m.acls))
+	for acl := range m.acls {
+		acls = append(acls, acl)
+	}
+	sortACLs(acls)
+	return acls, nil
+}
+
+func (m *MemoryAdmin) IFCreateACLs(ctx context.Context, acls []e.KafkaACLData) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, acl := range acls {
+		if _, err := newACLBuilder(acl); err != nil {
+			return err
+		}
+		m.acls[acl] = struct{}{}
+	}
+	return nil
+}
+
+func (m *MemoryAdmin) IFDeleteACLs(ctx context.Context, acls []e.KafkaACLData) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, acl := range acls {
+		delete(m.acls, acl)
+	}
+	return nil
+}
+
+func (m *MemoryAdmin) IFDescribeScramUsers(ctx context.Context) ([]e.KafkaScramUserData, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	users := make([]e.KafkaScramUserData, 0, len(m.scramUsers))
+	for user := range m.scramUsers {
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].UserName+users[i].Mechanism < users[j].UserName+users[j].Mechanism
+	})
+	return users, nil
+}
+
+func (m *MemoryAdmin) IFUpsertScramCredentials(ctx context.Context, inputs []e.UpsertKafkaScramInput) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, input := range inputs {
+		if _, err := parseScramMechanism(input.Mechanism); err != nil {
+			return err
+		}
+		//aynı kullanıcı ve mechanism için önceki credential kaldırılır.
+		for user := range m.scramUsers {
+			if user.UserName == input.UserName && user.Mechanism == input.Mechanism {
+				delete(m.scramUsers, user)
+			}
+		}
+		m.scramUsers[e.KafkaScramUserData{
+			UserName:   input.UserName,
+			Mechanism:  input.Mechanism,
+			Iterations: input.SecretInfo.Iterations,
+		}] = input.SecretInfo
+	}
+	return nil
+}
+
+func (m *MemoryAdmin) IFDeleteScramCredentials(ctx context.Context, users []e.KafkaScramUserData) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, deleted := range users {
+		for user := range m.scramUsers {
+			if user.UserName == deleted.UserName && user.Mechanism == deleted.Mechanism {
+				delete(m.scramUsers, user)
+			}
+		}
+	}
+	return nil
+}
+
+func (m *MemoryAdmin) IFDescribeTopic(ctx context.Context, name string) (e.KafkaTopicData, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	topic, ok := m.topics[name]
+	if !ok {
+		return e.KafkaTopicData{}, false, nil
+	}
+	return cloneTopic(topic), true, nil
+}
+
+func (m *MemoryAdmin) IFCreateTopic(ctx context.Context, input e.CreateKafkaTopicInput) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.topics[input.Name]; ok {
+		return false, nil
+	}
+	m.topics[input.Name] = cloneTopic(e.KafkaTopicData{
+		Name:              input.Name,
+		Partitions:        input.Partitions,
+		ReplicationFactor: input.ReplicationFactor,
+		Configs:           input.Configs,
+	})
+	return true, nil
+}
+
+func (m *MemoryAdmin) IFAlterTopicConfigs(ctx context.Context, input e.AlterKafkaTopicConfigInput) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	topic, ok := m.topics[input.Name]
+	if !ok {
+		return env.GetFuncError(env.KafkaTopicNotFound, nil, input.Name)
+	}
+	if topic.Configs == nil {
+		topic.Configs = map[string]string{}
+	}
+	for key, value := range input.SetConfigs {
+		topic.Configs[key] = value
+	}
+	for _, key := range input.DeleteConfigs {
+		delete(topic.Configs, key)
+	}
+	m.topics[input.Name] = topic
+	return nil
+}
+
+func (m *MemoryAdmin) IFDeleteTopic(ctx context.Context, name string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.topics[name]; !ok {
+		return false, nil
+	}
+	delete(m.topics, name)
+	return true, nil
+}
+
+func cloneTopic(topic e.KafkaTopicData) e.KafkaTopicData {
+	configs := make(map[string]string, len(topic.Configs))
+	for key, value := range topic.Configs {
+		configs[key] = value
+	}
+	topic.Configs = configs
+	return topic
+}
+
+// acl listesi deterministik sıraya getirilir.
+func sortACLs(acls []e.KafkaACLData) {
+	sort.Slice(acls, func(i, j int) bool {
+		return aclSortKey(acls[i]) < aclSortKey(acls[j])
+	})
+}
+
+func aclSortKey(acl e.KafkaACLData) string {
+	return acl.Principal + "\x00" + acl.ResourceType + "\x00" + acl.PatternType + "\x00" +
+		acl.ResourceName + "\x00" + acl.Operation + "\x00" + acl.Host + "\x00" + acl.Permission
+}
@@ -0,0 +1,250 @@
+package kafka
+
+import (
+	"context"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+	a "web_server/domain/abstractions"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+)
+
+/*
+- MemoryBroker, rest proxy için broker yerine geçen bellek üzerindeki producer/consumer. Dry-run ve test senaryolarında kullanılır.
+- consumer group rebalance yapılmaz, aynı group üzerindeki her consumer bütün partitions okur. Commit edilen offsets group bazlı tutulur.
+*/
+type MemoryBroker struct {
+	mu            sync.Mutex
+	topics        map[string][][]e.KafkaConsumedRecordData //map[topic] => partitions => records
+	committed     map[string]map[string]map[int32]int64    //map[group][topic][partition] => sonraki offset
+	nextPartition map[string]int32                         //key içermeyen records için round robin
+	replicas      map[string]map[int32][2]int              //map[topic][partition] => replicas, in sync replicas
+}
+
+// derleme zamanı kafka proxy client interface check
+var _ a.IKafkaProxyClient = (*MemoryBroker)(nil)
+
+// derleme zamanı kafka metrics admin interface check
+var _ a.IKafkaMetricsAdmin = (*MemoryBroker)(nil)
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		topics:        map[string][][]e.KafkaConsumedRecordData{},
+		committed:     map[string]map[string]map[int32]int64{},
+		nextPartition: map[string]int32{},
+		replicas:      map[string]map[int32][2]int{},
+	}
+}
+
+// topic mevcut değilse verilen partition sayısı ile oluşturulur.
+func (m *MemoryBroker) CreateTopic(name string, partitions int32) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.topics[name]; !ok {
+		m.topics[name] = make([][]e.KafkaConsumedRecordData, max(partitions, 1))
+	}
+}
+
+// partition replica bilgisi tanımlanır. Tanımlanmayan partitions tek replica ile senkron kabul edilir.
+func (m *MemoryBroker) SetPartitionReplicas(topic string, partition int32, replicas int, inSyncReplicas int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.replicas[topic] == nil {
+		m.replicas[topic] = map[int32][2]int{}
+	}
+	m.replicas[topic][partition] = [2]int{replicas, inSyncReplicas}
+}
+
+// key içeren records aynı partition üzerine yazılır.
+func (m *MemoryBroker) IFProduce(ctx context.Context, topic string, records []e.KafkaProduceRecordData) ([]e.KafkaRecordOffsetData, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	partitions, ok := m.topics[topic]
+	if !ok {
+		return nil, env.GetFuncError(env.KafkaTopicNotFound, nil, topic)
+	}
+
+	offsets := make([]e.KafkaRecordOffsetData, 0, len(records))
+	for _, record := range records {
+		partition := m.nextPartition[topic] % int32(len(partitions))
+		if record.Key != nil {
+			hash := fnv.New32a()
+			hash.Write(record.Key)
+			partition = int32(hash.Sum32() % uint32(len(partitions)))
+		} else {
+			m.nextPartition[topic]++
+		}
+
+		offset := int64(len(partitions[partition]))
+		partitions[partition] = append(partitions[partition], e.KafkaConsumedRecordData{
+			Topic:     topic,
+			Partition: partition,
+			Offset:    offset,
+			Key:       append([]byte(nil), record.Key...),
+			Value:     append([]byte(nil), record.Value...),
+			Headers:   append([]e.KafkaRecordHeaderData(nil), record.Headers...),
+			Timestamp: time.Now().UnixMilli(),
+		})
+		offsets = append(offsets, e.KafkaRecordOffsetData{Topic: topic, Partition: partition, Offset: offset})
+	}
+	return offsets, nil
+}
+
+func (m *MemoryBroker) IFNewConsumer(ctx context.Context, input e.CreateKafkaConsumerInput) (a.IKafkaConsumer, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, topic := range input.Topics {
+		if _, ok := m.topics[topic]; !ok {
+			return nil, env.GetFuncError(env.KafkaTopicNotFound, nil, topic)
+		}
+	}
+
+	topics := append([]string(nil), input.Topics...)
+	sort.Strings(topics)
+	return &memoryConsumer{
+		broker:      m,
+		group:       input.Group,
+		topics:      topics,
+		offsetReset: input.OffsetReset,
+		positions:   map[string]map[int32]int64{},
+	}, nil
+}
+
+type memoryConsumer struct {
+	broker      *MemoryBroker
+	group       string
+	topics      []string
+	offsetReset string
+	positions   map[string]map[int32]int64 //map[topic][partition] => sonraki okunacak offset
+	closed      bool
+}
+
+// partition pozisyonu ilk okumada commit edilen offset ya da offset reset bilgisi ile belirlenir.
+func (c *memoryConsumer) position(topic string, partition int32, partitionLength int64) int64 {
+	if c.positions[topic] == nil {
+		c.positions[topic] = map[int32]int64{}
+	}
+	if position, ok := c.positions[topic][partition]; ok {
+		return position
+	}
+
+	position := partitionLength
+	if committed, ok := c.broker.committed[c.group][topic][partition]; ok {
+		position = committed
+	} else if c.offsetReset == env.KafkaOffsetResetEarliest {
+		position = 0
+	}
+	c.positions[topic][partition] = position
+	return position
+}
+
+func (c *memoryConsumer) IFPoll(ctx context.Context, maxRecords int) ([]e.KafkaConsumedRecordData, error) {
+	c.broker.mu.Lock()
+	defer c.broker.mu.Unlock()
+
+	if c.closed {
+		return nil, env.GetFuncError(env.KafkaOperationFailed, context.Canceled, "poll")
+	}
+
+	var records []e.KafkaConsumedRecordData
+	for _, topic := range c.topics {
+		for partition, partitionRecords := range c.broker.topics[topic] {
+			position := c.position(topic, int32(partition), int64(len(partitionRecords)))
+			for ; position < int64(len(partitionRecords)); position++ {
+				if maxRecords > 0 && len(records) >= maxRecords {
+					break
+				}
+				records = append(records, partitionRecords[position])
+			}
+			c.positions[topic][int32(partition)] = position
+		}
+	}
+	return records, nil
+}
+
+// offsets boş ise okunan son pozisyonlar commit edilir.
+func (c *memoryConsumer) IFCommit(ctx context.Context, offsets []e.KafkaRecordOffsetData) error {
+	c.broker.mu.Lock()
+	defer c.broker.mu.Unlock()
+
+	if c.broker.committed[c.group] == nil {
+		c.broker.committed[c.group] = map[string]map[int32]int64{}
+	}
+	commit := func(topic string, partition int32, next int64) {
+		if c.broker.committed[c.group][topic] == nil {
+			c.broker.committed[c.group][topic] = map[int32]int64{}
+		}
+		c.broker.committed[c.group][topic][partition] = next
+	}
+
+	if len(offsets) == 0 {
+		for topic, partitions := range c.positions {
+			for partition, position := range partitions {
+				commit(topic, partition, position)
+			}
+		}
+		return nil
+	}
+	for _, offset := range offsets {
+		commit(offset.Topic, offset.Partition, offset.Offset+1)
+	}
+	return nil
+}
+
+func (c *memoryConsumer) IFClose() {
+	c.broker.mu.Lock()
+	defer c.broker.mu.Unlock()
+	c.closed = true
+}
+
+func (m *MemoryBroker) IFDescribeTopicMetrics(ctx context.Context, topics []string) ([]e.KafkaTopicMetricsData, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	names := append([]string(nil), topics...)
+	sort.Strings(names)
+
+	var metrics []e.KafkaTopicMetricsData
+	for _, name := range names {
+		partitions, ok := m.topics[name]
+		if !ok {
+			continue
+		}
+
+		topicMetrics := e.KafkaTopicMetricsData{Name: name}
+		for partition, records := range partitions {
+			replicas, ok := m.replicas[name][int32(partition)]
+			if !ok {
+				replicas = [2]int{1, 1}
+			}
+			topicMetrics.PartitionInfos = append(topicMetrics.PartitionInfos, e.KafkaPartitionMetricsData{
+				Partition:      int32(partition),
+				Replicas:       replicas[0],
+				InSyncReplicas: replicas[1],
+				EndOffset:      int64(len(records)),
+			})
+		}
+		metrics = append(metrics, topicMetrics)
+	}
+	return metrics, nil
+}
+
+func (m *MemoryBroker) IFFetchGroupOffsets(ctx context.Context, group string) ([]e.KafkaRecordOffsetData, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var offsets []e.KafkaRecordOffsetData
+	for topic, partitions := range m.committed[group] {
+		for partition, offset := range partitions {
+			offsets = append(offsets, e.KafkaRecordOffsetData{Topic: topic, Partition: partition, Offset: offset})
+		}
+	}
+	sortOffsets(offsets)
+	return offsets, nil
+}
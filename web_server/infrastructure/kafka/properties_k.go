@@ -0,0 +1,522 @@
+package kafka
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	a "web_server/domain/abstractions"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+	u "web_server/utils"
+)
+
+// property belirtilmediğinde kafka tarafından kullanılan varsayılan değerler
+const (
+	defaultReplicationFactor             int32 = 1
+	defaultOffsetsTopicReplicationFactor int32 = 3
+	defaultTransactionStateLogRF         int32 = 3
+	defaultTransactionStateLogMinISR     int32 = 2
+
+	serverPropertiesHeader = "# Bu file kaftion guard tarafından env map üzerinden üretilir. Elle düzenlenmemelidir.\n"
+)
+
+// listener tanımı. Ex: PLAINTEXT://kafka-broker1:9092
+type listenerData struct {
+	Name string
+	Host string
+	Port int
+}
+
+/*
+- server.properties içeriği okunur ve model üzerine aktarılır.
+- # ve ! ile başlayan satırlar yorum kabul edilir, \ ile biten satırlar bir sonraki satır ile birleştirilir.
+- aynı key birden fazla tanımlanırsa hata döner.
+*/
+func ParseServerProperties(data []byte) (e.KafkaServerPropertiesData, error) {
+	values := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	var pending string
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if pending == "" && (line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!")) {
+			continue
+		}
+
+		line = pending + line
+		if strings.HasSuffix(line, `\`) {
+			pending = strings.TrimSuffix(line, `\`)
+			continue
+		}
+		pending = ""
+
+		sep := strings.IndexAny(line, "=:")
+		if sep <= 0 {
+			return e.KafkaServerPropertiesData{}, env.GetFuncError(env.InvalidServerProperties, nil, line, "missing key separator")
+		}
+
+		key := strings.TrimSpace(line[:sep])
+		if _, ok := values[key]; ok {
+			return e.KafkaServerPropertiesData{}, env.GetFuncError(env.InvalidServerProperties, nil, key, "duplicate key")
+		}
+		values[key] = strings.TrimSpace(line[sep+1:])
+	}
+	if err := scanner.Err(); err != nil {
+		return e.KafkaServerPropertiesData{}, env.GetFuncError(env.UnexpectedError, err)
+	}
+
+	return serverPropertiesFromMap(values)
+}
+
+// env map üzerindeki ortak ve broker'a özel properties birleştirilerek broker modeli oluşturulur.
+func BuildServerProperties(input e.BuildKafkaServerPropertiesInput) (e.KafkaServerPropertiesData, error) {
+	values := make(map[string]string, len(input.CommonInfos)+len(input.BrokerInfos)+1)
+	for key, value := range input.CommonInfos {
+		values[key] = value
+	}
+	for key, value := range input.BrokerInfos {
+		values[key] = value
+	}
+	//broker id env map key bilgisinden alınır.
+	values[env.KafkaPropBrokerID] = strconv.FormatInt(int64(input.BrokerID), 10)
+
+	return serverPropertiesFromMap(values)
+}
+
+// key/value bilgileri model alanlarına dağıtılır, modellenmeyen keys ExtraInfos içerisinde kalır.
+func serverPropertiesFromMap(values map[string]string) (e.KafkaServerPropertiesData, error) {
+	props := e.KafkaServerPropertiesData{ExtraInfos: map[string]string{}}
+
+	int32Fields := map[string]*int32{
+		env.KafkaPropBrokerID:                             &props.BrokerID,
+		env.KafkaPropNumPartitions:                        &props.NumPartitions,
+		env.KafkaPropDefaultReplicationFactor:             &props.DefaultReplicationFactor,
+		env.KafkaPropOffsetsTopicReplicationFactor:        &props.OffsetsTopicReplicationFactor,
+		env.KafkaPropTransactionStateLogReplicationFactor: &props.TransactionStateLogReplicationFactor,
+		env.KafkaPropTransactionStateLogMinISR:            &props.TransactionStateLogMinISR,
+		env.KafkaPropZookeeperConnectionTimeoutMs:         &props.ZookeeperConnectionTimeoutMs,
+	}
+	sliceFields := map[string]*[]string{
+		env.KafkaPropListeners:             &props.Listeners,
+		env.KafkaPropAdvertisedListeners:   &props.AdvertisedListeners,
+		env.KafkaPropLogDirs:               &props.LogDirs,
+		env.KafkaPropSaslEnabledMechanisms: &props.SaslEnabledMechanisms,
+		env.KafkaPropZookeeperConnect:      &props.ZookeeperConnect,
+	}
+
+	if _, ok := values[env.KafkaPropBrokerID]; !ok {
+		return props, env.GetFuncError(env.InvalidServerProperties, nil, env.KafkaPropBrokerID, "required")
+	}
+
+	for key, value := range values {
+		if field, ok := int32Fields[key]; ok {
+			parsed, err := strconv.ParseInt(value, 10, 32)
+			if err != nil {
+				return props, env.GetFuncError(env.InvalidServerProperties, nil, key, "not an int32 value")
+			}
+			*field = int32(parsed)
+			continue
+		}
+
+		if field, ok := sliceFields[key]; ok {
+			*field = splitPropertyList(value)
+			continue
+		}
+
+		switch key {
+		case env.KafkaPropListenerSecurityProtocolMap:
+			protocolMap, err := parseSecurityProtocolMap(value)
+			if err != nil {
+				return props, err
+			}
+			props.ListenerSecurityProtocolMap = protocolMap
+		case env.KafkaPropInterBrokerListenerName:
+			props.InterBrokerListenerName = value
+		case env.KafkaPropSaslMechanismInterBrokerProtocol:
+			props.SaslMechanismInterBrokerProtocol = value
+		default:
+			props.ExtraInfos[key] = value
+		}
+	}
+	return props, nil
+}
+
+/*
+- model üzerindeki alanlar broker sayısı ve birbirleri ile tutarlılık açısından kontrol edilir.
+- replication factor broker sayısını aşamaz, belirtilmeyen alanlar için kafka varsayılan değerleri kontrol edilir.
+- advertised listeners ile listeners aynı listener isimlerini barındırmak zorundadır.
+*/
+func ValidateServerProperties(input e.ValidateKafkaServerPropertiesInput) error {
+	props := &input.PropertiesInfo
+
+	if input.BrokerCount < 1 {
+		return env.GetFuncError(env.InvalidServerProperties, nil, "broker count", "must be at least 1")
+	}
+
+	if props.BrokerID < 0 {
+		return env.GetFuncError(env.InvalidServerProperties, nil, env.KafkaPropBrokerID, "must not be negative")
+	}
+
+	listeners, err := validateListeners(props)
+	if err != nil {
+		return err
+	}
+
+	if err := validateAdvertisedListeners(props, listeners); err != nil {
+		return err
+	}
+
+	if props.InterBrokerListenerName != "" {
+		if _, ok := listeners[props.InterBrokerListenerName]; !ok {
+			return env.GetFuncError(env.InvalidServerProperties, nil, env.KafkaPropInterBrokerListenerName, "not defined in listeners")
+		}
+	}
+
+	if err := validateLogDirs(props.LogDirs); err != nil {
+		return err
+	}
+
+	if props.NumPartitions < 0 {
+		return env.GetFuncError(env.InvalidServerProperties, nil, env.KafkaPropNumPartitions, "must not be negative")
+	}
+
+	if err := validateReplication(props, input.BrokerCount); err != nil {
+		return err
+	}
+
+	if err := validateSasl(props, listeners); err != nil {
+		return err
+	}
+
+	if err := validateZookeeperConnect(props); err != nil {
+		return err
+	}
+
+	for key, value := range props.ExtraInfos {
+		if key == "" || strings.ContainsAny(key, "=:\n\r") || strings.ContainsAny(value, "\n\r") {
+			return env.GetFuncError(env.InvalidServerProperties, nil, key, "invalid extra property")
+		}
+	}
+	return nil
+}
+
+// listener isimleri benzersiz, security protocol bilgisi tanımlı olmak zorundadır.
+func validateListeners(props *e.KafkaServerPropertiesData) (map[string]listenerData, error) {
+	if len(props.Listeners) == 0 {
+		return nil, env.GetFuncError(env.InvalidServerProperties, nil, env.KafkaPropListeners, "required")
+	}
+
+	for name, protocol := range props.ListenerSecurityProtocolMap {
+		if !slices.Contains(env.KafkaSecurityProtocolSlice, protocol) {
+			return nil, env.GetFuncError(env.InvalidServerProperties, nil, env.KafkaPropListenerSecurityProtocolMap, "unknown security protocol for "+name)
+		}
+	}
+
+	listeners := make(map[string]listenerData, len(props.Listeners))
+	ports := map[int]struct{}{}
+	for _, rawListener := range props.Listeners {
+		listener, err := parseListener(env.KafkaPropListeners, rawListener)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := listeners[listener.Name]; ok {
+			return nil, env.GetFuncError(env.InvalidServerProperties, nil, env.KafkaPropListeners, "duplicate listener name "+listener.Name)
+		}
+		if _, ok := ports[listener.Port]; ok {
+			return nil, env.GetFuncError(env.InvalidServerProperties, nil, env.KafkaPropListeners, "duplicate listener port "+strconv.Itoa(listener.Port))
+		}
+
+		if _, ok := props.ListenerSecurityProtocolMap[listener.Name]; !ok && !slices.Contains(env.KafkaSecurityProtocolSlice, listener.Name) {
+			return nil, env.GetFuncError(env.InvalidServerProperties, nil, env.KafkaPropListeners, "missing security protocol for "+listener.Name)
+		}
+
+		listeners[listener.Name] = listener
+		ports[listener.Port] = struct{}{}
+	}
+	return listeners, nil
+}
+
+// advertised listeners belirtilmezse kafka listeners kullanır, bu durumda wildcard host istemcilere duyurulamaz.
+func validateAdvertisedListeners(props *e.KafkaServerPropertiesData, listeners map[string]listenerData) error {
+	if len(props.AdvertisedListeners) == 0 {
+		for _, listener := range listeners {
+			if isWildcardHost(listener.Host) {
+				return env.GetFuncError(env.InvalidServerProperties, nil, env.KafkaPropAdvertisedListeners, "required when listeners bind a wildcard host")
+			}
+		}
+		return nil
+	}
+
+	advertised := make(map[string]struct{}, len(props.AdvertisedListeners))
+	for _, rawListener := range props.AdvertisedListeners {
+		listener, err := parseListener(env.KafkaPropAdvertisedListeners, rawListener)
+		if err != nil {
+			return err
+		}
+
+		if isWildcardHost(listener.Host) {
+			return env.GetFuncError(env.InvalidServerProperties, nil, env.KafkaPropAdvertisedListeners, "wildcard host cannot be advertised for "+listener.Name)
+		}
+		if _, ok := advertised[listener.Name]; ok {
+			return env.GetFuncError(env.InvalidServerProperties, nil, env.KafkaPropAdvertisedListeners, "duplicate listener name "+listener.Name)
+		}
+		if _, ok := listeners[listener.Name]; !ok {
+			return env.GetFuncError(env.InvalidServerProperties, nil, env.KafkaPropAdvertisedListeners, "listener not defined in listeners: "+listener.Name)
+		}
+		advertised[listener.Name] = struct{}{}
+	}
+
+	for name := range listeners {
+		if _, ok := advertised[name]; !ok {
+			return env.GetFuncError(env.InvalidServerProperties, nil, env.KafkaPropAdvertisedListeners, "listener not advertised: "+name)
+		}
+	}
+	return nil
+}
+
+func validateLogDirs(logDirs []string) error {
+	if len(logDirs) == 0 {
+		return env.GetFuncError(env.InvalidServerProperties, nil, env.KafkaPropLogDirs, "required")
+	}
+
+	seen := make(map[string]struct{}, len(logDirs))
+	for _, logDir := range logDirs {
+		if !filepath.IsAbs(logDir) {
+			return env.GetFuncError(env.InvalidServerProperties, nil, env.KafkaPropLogDirs, "path must be absolute: "+logDir)
+		}
+		cleanDir := filepath.Clean(logDir)
+		if _, ok := seen[cleanDir]; ok {
+			return env.GetFuncError(env.InvalidServerProperties, nil, env.KafkaPropLogDirs, "duplicate path: "+logDir)
+		}
+		seen[cleanDir] = struct{}{}
+	}
+	return nil
+}
+
+// replication factor değerleri belirtilmemişse kafka varsayılan değerleri üzerinden kontrol edilir.
+func validateReplication(props *e.KafkaServerPropertiesData, brokerCount int) error {
+	replicationInfos := []struct {
+		key          string
+		value        int32
+		defaultValue int32
+	}{
+		{env.KafkaPropDefaultReplicationFactor, props.DefaultReplicationFactor, defaultReplicationFactor},
+		{env.KafkaPropOffsetsTopicReplicationFactor, props.OffsetsTopicReplicationFactor, defaultOffsetsTopicReplicationFactor},
+		{env.KafkaPropTransactionStateLogReplicationFactor, props.TransactionStateLogReplicationFactor, defaultTransactionStateLogRF},
+	}
+
+	for _, replicationInfo := range replicationInfos {
+		value := effectiveInt32(replicationInfo.value, replicationInfo.defaultValue)
+		if value < 1 {
+			return env.GetFuncError(env.InvalidServerProperties, nil, replicationInfo.key, "must be at least 1")
+		}
+		if int(value) > brokerCount {
+			return env.GetFuncError(env.InvalidServerProperties, nil, replicationInfo.key,
+				fmt.Sprintf("replication factor %d exceeds broker count %d", value, brokerCount))
+		}
+	}
+
+	minISR := effectiveInt32(props.TransactionStateLogMinISR, defaultTransactionStateLogMinISR)
+	transactionRF := effectiveInt32(props.TransactionStateLogReplicationFactor, defaultTransactionStateLogRF)
+	if minISR < 1 || minISR > transactionRF {
+		return env.GetFuncError(env.InvalidServerProperties, nil, env.KafkaPropTransactionStateLogMinISR,
+			fmt.Sprintf("min isr %d must be between 1 and replication factor %d", minISR, transactionRF))
+	}
+	return nil
+}
+
+func validateSasl(props *e.KafkaServerPropertiesData, listeners map[string]listenerData) error {
+	seen := make(map[string]struct{}, len(props.SaslEnabledMechanisms))
+	for _, mechanism := range props.SaslEnabledMechanisms {
+		if !slices.Contains(env.KafkaSaslMechanismSlice, mechanism) {
+			return env.GetFuncError(env.InvalidServerProperties, nil, env.KafkaPropSaslEnabledMechanisms, "unknown mechanism "+mechanism)
+		}
+		if _, ok := seen[mechanism]; ok {
+			return env.GetFuncError(env.InvalidServerProperties, nil, env.KafkaPropSaslEnabledMechanisms, "duplicate mechanism "+mechanism)
+		}
+		seen[mechanism] = struct{}{}
+	}
+
+	if props.SaslMechanismInterBrokerProtocol != "" {
+		if _, ok := seen[props.SaslMechanismInterBrokerProtocol]; !ok {
+			return env.GetFuncError(env.InvalidServerProperties, nil, env.KafkaPropSaslMechanismInterBrokerProtocol, "mechanism not enabled")
+		}
+	}
+
+	//sasl kullanan listener varsa en az bir mechanism aktif olmak zorundadır.
+	for name := range listeners {
+		protocol, ok := props.ListenerSecurityProtocolMap[name]
+		if !ok {
+			protocol = name
+		}
+		if strings.HasPrefix(protocol, "SASL_") && len(props.SaslEnabledMechanisms) == 0 {
+			return env.GetFuncError(env.InvalidServerProperties, nil, env.KafkaPropSaslEnabledMechanisms, "required for sasl listener "+name)
+		}
+	}
+	return nil
+}
+
+// zookeeper connect host:port listesidir, chroot sadece son eleman üzerinde bulunabilir. Ex: zookeeper1:2181,zookeeper2:2181/kafka
+func validateZookeeperConnect(props *e.KafkaServerPropertiesData) error {
+	if len(props.ZookeeperConnect) == 0 {
+		return env.GetFuncError(env.InvalidServerProperties, nil, env.KafkaPropZookeeperConnect, "required")
+	}
+
+	for i, server := range props.ZookeeperConnect {
+		if chroot := strings.Index(server, "/"); chroot >= 0 {
+			if i != len(props.ZookeeperConnect)-1 {
+				return env.GetFuncError(env.InvalidServerProperties, nil, env.KafkaPropZookeeperConnect, "chroot only allowed on the last server")
+			}
+			server = server[:chroot]
+		}
+		if _, _, err := splitHostPort(server); err != nil {
+			return env.GetFuncError(env.InvalidServerProperties, nil, env.KafkaPropZookeeperConnect, "invalid server "+server)
+		}
+	}
+
+	if props.ZookeeperConnectionTimeoutMs < 0 {
+		return env.GetFuncError(env.InvalidServerProperties, nil, env.KafkaPropZookeeperConnectionTimeoutMs, "must not be negative")
+	}
+	return nil
+}
+
+/*
+- model deterministik olarak server.properties formatına çevrilir.
+- modellenen alanlar sabit sırada, ExtraInfos alanları key sırasına göre yazılır. Belirtilmeyen alanlar yazılmaz.
+*/
+func RenderServerProperties(props e.KafkaServerPropertiesData) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(serverPropertiesHeader)
+
+	writeProp := func(key, value string) {
+		if value == "" {
+			return
+		}
+		buf.WriteString(key)
+		buf.WriteByte('=')
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+	}
+	writeInt := func(key string, value int32) {
+		if value != 0 {
+			writeProp(key, strconv.FormatInt(int64(value), 10))
+		}
+	}
+
+	writeProp(env.KafkaPropBrokerID, strconv.FormatInt(int64(props.BrokerID), 10))
+	writeProp(env.KafkaPropListeners, strings.Join(props.Listeners, ","))
+	writeProp(env.KafkaPropAdvertisedListeners, strings.Join(props.AdvertisedListeners, ","))
+	writeProp(env.KafkaPropListenerSecurityProtocolMap, formatSecurityProtocolMap(props.ListenerSecurityProtocolMap))
+	writeProp(env.KafkaPropInterBrokerListenerName, props.InterBrokerListenerName)
+	writeProp(env.KafkaPropLogDirs, strings.Join(props.LogDirs, ","))
+	writeInt(env.KafkaPropNumPartitions, props.NumPartitions)
+	writeInt(env.KafkaPropDefaultReplicationFactor, props.DefaultReplicationFactor)
+	writeInt(env.KafkaPropOffsetsTopicReplicationFactor, props.OffsetsTopicReplicationFactor)
+	writeInt(env.KafkaPropTransactionStateLogReplicationFactor, props.TransactionStateLogReplicationFactor)
+	writeInt(env.KafkaPropTransactionStateLogMinISR, props.TransactionStateLogMinISR)
+	writeProp(env.KafkaPropSaslEnabledMechanisms, strings.Join(props.SaslEnabledMechanisms, ","))
+	writeProp(env.KafkaPropSaslMechanismInterBrokerProtocol, props.SaslMechanismInterBrokerProtocol)
+	writeProp(env.KafkaPropZookeeperConnect, strings.Join(props.ZookeeperConnect, ","))
+	writeInt(env.KafkaPropZookeeperConnectionTimeoutMs, props.ZookeeperConnectionTimeoutMs)
+
+	extraKeys := make([]string, 0, len(props.ExtraInfos))
+	for key := range props.ExtraInfos {
+		extraKeys = append(extraKeys, key)
+	}
+	sort.Strings(extraKeys)
+	for _, key := range extraKeys {
+		writeProp(key, props.ExtraInfos[key])
+	}
+	return buf.Bytes()
+}
+
+// model render edilir, file ve imza bilgisi <file>.sig olarak yazılır.
+func WriteSignedServerProperties(filePath string, props e.KafkaServerPropertiesData, signer a.ISigner) error {
+	return u.WriteSignedFile(filePath, RenderServerProperties(props), 0o644, signer)
+}
+
+func parseListener(key string, rawListener string) (listenerData, error) {
+	name, address, ok := strings.Cut(rawListener, "://")
+	if !ok || name == "" {
+		return listenerData{}, env.GetFuncError(env.InvalidServerProperties, nil, key, "invalid listener "+rawListener)
+	}
+
+	host, port, err := splitHostPort(address)
+	if err != nil {
+		return listenerData{}, env.GetFuncError(env.InvalidServerProperties, nil, key, "invalid listener "+rawListener)
+	}
+	return listenerData{Name: name, Host: host, Port: port}, nil
+}
+
+func splitHostPort(address string) (string, int, error) {
+	host, rawPort, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", 0, err
+	}
+
+	port, err := strconv.Atoi(rawPort)
+	if err != nil || port < 1 || port > 65535 {
+		return "", 0, fmt.Errorf("invalid port: %s", rawPort)
+	}
+	return host, port, nil
+}
+
+func isWildcardHost(host string) bool {
+	return host == "" || host == "0.0.0.0" || host == "::"
+}
+
+// virgül ile ayrılmış liste boşluklardan temizlenir, boş elemanlar alınmaz.
+func splitPropertyList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Ex: INTERNAL:PLAINTEXT,EXTERNAL:SASL_SSL
+func parseSecurityProtocolMap(value string) (map[string]string, error) {
+	protocolMap := map[string]string{}
+	for _, item := range splitPropertyList(value) {
+		name, protocol, ok := strings.Cut(item, ":")
+		name, protocol = strings.TrimSpace(name), strings.TrimSpace(protocol)
+		if !ok || name == "" || protocol == "" {
+			return nil, env.GetFuncError(env.InvalidServerProperties, nil, env.KafkaPropListenerSecurityProtocolMap, "invalid entry "+item)
+		}
+		if _, exists := protocolMap[name]; exists {
+			return nil, env.GetFuncError(env.InvalidServerProperties, nil, env.KafkaPropListenerSecurityProtocolMap, "duplicate listener name "+name)
+		}
+		protocolMap[name] = protocol
+	}
+	return protocolMap, nil
+}
+
+func formatSecurityProtocolMap(protocolMap map[string]string) string {
+	names := make([]string, 0, len(protocolMap))
+	for name := range protocolMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	items := make([]string, 0, len(names))
+	for _, name := range names {
+		items = append(items, name+":"+protocolMap[name])
+	}
+	return strings.Join(items, ",")
+}
+
+func effectiveInt32(value int32, defaultValue int32) int32 {
+	if value == 0 {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,158 @@
+package kafka
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+	a "web_server/domain/abstractions"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+)
+
+/*
+- ProxyConsumerRegistry, rest proxy üzerinden oluşturulan consumer instances owner bazlı tutar.
+- instance sadece oluşturan owner tarafından kullanılabilir, başka owner için instance yokmuş gibi davranılır.
+- idleTimeout süresince kullanılmayan instances yeni instance oluşturulurken kapatılır.
+*/
+type ProxyConsumerRegistry struct {
+	mu          sync.Mutex
+	instances   map[string]*proxyConsumerInstance //map[instance_id] => instance
+	idleTimeout time.Duration
+	now         func() time.Time
+}
+
+type proxyConsumerInstance struct {
+	mu            sync.Mutex //aynı instance üzerinde poll ve commit sıralı çalışır.
+	owner         string
+	instanceInfos e.KafkaConsumerInstanceData
+	consumer      a.IKafkaConsumer
+	lastUsedAt    time.Time
+}
+
+func NewProxyConsumerRegistry(idleTimeout time.Duration) *ProxyConsumerRegistry {
+	return &ProxyConsumerRegistry{
+		instances:   map[string]*proxyConsumerInstance{},
+		idleTimeout: idleTimeout,
+		now:         time.Now,
+	}
+}
+
+func newConsumerInstanceID() (string, error) {
+	id := make([]byte, env.KafkaProxyConsumerInstanceBytes)
+	if _, err := rand.Read(id); err != nil {
+		return "", env.GetFuncError(env.UnexpectedError, err)
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// consumer owner quota içerisinde kalıyorsa kaydedilir. Quota aşılırsa consumer kapatılır.
+func (r *ProxyConsumerRegistry) Register(owner string, quota e.KafkaProxyQuotaData, input e.CreateKafkaConsumerInput, consumer a.IKafkaConsumer) (e.KafkaConsumerInstanceData, error) {
+	r.closeIdle()
+
+	instanceID, err := newConsumerInstanceID()
+	if err != nil {
+		consumer.IFClose()
+		return e.KafkaConsumerInstanceData{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if quota.MaxConsumers > 0 {
+		ownerInstances := 0
+		for _, instance := range r.instances {
+			if instance.owner == owner {
+				ownerInstances++
+			}
+		}
+		if ownerInstances >= quota.MaxConsumers {
+			consumer.IFClose()
+			return e.KafkaConsumerInstanceData{}, env.GetFuncError(env.KafkaQuotaExceeded, nil, owner,
+				fmt.Sprintf("consumer instance limit %d", quota.MaxConsumers))
+		}
+	}
+
+	instanceInfos := e.KafkaConsumerInstanceData{
+		InstanceID: instanceID,
+		Group:      input.Group,
+		Topics:     append([]string(nil), input.Topics...),
+	}
+	r.instances[instanceID] = &proxyConsumerInstance{
+		owner:         owner,
+		instanceInfos: instanceInfos,
+		consumer:      consumer,
+		lastUsedAt:    r.now(),
+	}
+	return instanceInfos, nil
+}
+
+// owner ait instance üzerinde fn çalıştırılır.
+func (r *ProxyConsumerRegistry) Use(owner string, instanceID string, fn func(instanceInfos e.KafkaConsumerInstanceData, consumer a.IKafkaConsumer) error) error {
+	r.mu.Lock()
+	instance, ok := r.instances[instanceID]
+	if ok && instance.owner == owner {
+		instance.lastUsedAt = r.now()
+	}
+	r.mu.Unlock()
+	if !ok || instance.owner != owner {
+		return env.GetFuncError(env.KafkaConsumerNotFound, nil, instanceID)
+	}
+
+	instance.mu.Lock()
+	defer instance.mu.Unlock()
+	return fn(instance.instanceInfos, instance.consumer)
+}
+
+func (r *ProxyConsumerRegistry) Remove(owner string, instanceID string) error {
+	r.mu.Lock()
+	instance, ok := r.instances[instanceID]
+	if !ok || instance.owner != owner {
+		r.mu.Unlock()
+		return env.GetFuncError(env.KafkaConsumerNotFound, nil, instanceID)
+	}
+	delete(r.instances, instanceID)
+	r.mu.Unlock()
+
+	instance.mu.Lock()
+	defer instance.mu.Unlock()
+	instance.consumer.IFClose()
+	return nil
+}
+
+func (r *ProxyConsumerRegistry) closeIdle() {
+	if r.idleTimeout <= 0 {
+		return
+	}
+
+	r.mu.Lock()
+	var idleInstances []*proxyConsumerInstance
+	for instanceID, instance := range r.instances {
+		if r.now().Sub(instance.lastUsedAt) > r.idleTimeout {
+			idleInstances = append(idleInstances, instance)
+			delete(r.instances, instanceID)
+		}
+	}
+	r.mu.Unlock()
+
+	for _, instance := range idleInstances {
+		instance.mu.Lock()
+		instance.consumer.IFClose()
+		instance.mu.Unlock()
+	}
+}
+
+// bütün instances kapatılır.
+func (r *ProxyConsumerRegistry) Close() {
+	r.mu.Lock()
+	instances := r.instances
+	r.instances = map[string]*proxyConsumerInstance{}
+	r.mu.Unlock()
+
+	for _, instance := range instances {
+		instance.mu.Lock()
+		instance.consumer.IFClose()
+		instance.mu.Unlock()
+	}
+}
@@ -0,0 +1,174 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	a "web_server/domain/abstractions"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+// KafkaProxyClient, rest proxy istekleri için tek bir producer client kullanır, her consumer instance ayrı client açar.
+type KafkaProxyClient struct {
+	producer    *kgo.Client
+	seedBrokers []string
+	opts        []kgo.Opt
+}
+
+// derleme zamanı kafka proxy client interface check
+var _ a.IKafkaProxyClient = (*KafkaProxyClient)(nil)
+
+func NewKafkaProxyClient(seedBrokers []string, opts ...kgo.Opt) (*KafkaProxyClient, error) {
+	producer, err := kgo.NewClient(append([]kgo.Opt{kgo.SeedBrokers(seedBrokers...)}, opts...)...)
+	if err != nil {
+		return nil, env.GetFuncError(env.KafkaOperationFailed, err, "new producer client")
+	}
+	return &KafkaProxyClient{producer: producer, seedBrokers: seedBrokers, opts: opts}, nil
+}
+
+func (k *KafkaProxyClient) Close() {
+	k.producer.Close()
+}
+
+// batch senkron olarak yazılır. Herhangi bir record yazılamazsa hata döner.
+func (k *KafkaProxyClient) IFProduce(ctx context.Context, topic string, records []e.KafkaProduceRecordData) ([]e.KafkaRecordOffsetData, error) {
+	kafkaRecords := make([]*kgo.Record, 0, len(records))
+	for _, record := range records {
+		kafkaRecord := &kgo.Record{Topic: topic, Key: record.Key, Value: record.Value}
+		for _, header := range record.Headers {
+			kafkaRecord.Headers = append(kafkaRecord.Headers, kgo.RecordHeader{Key: header.Key, Value: header.Value})
+		}
+		kafkaRecords = append(kafkaRecords, kafkaRecord)
+	}
+
+	results := k.producer.ProduceSync(ctx, kafkaRecords...)
+	if err := results.FirstErr(); err != nil {
+		if errors.Is(err, kerr.UnknownTopicOrPartition) {
+			return nil, env.GetFuncError(env.KafkaTopicNotFound, nil, topic)
+		}
+		return nil, env.GetFuncError(env.KafkaOperationFailed, err, "produce "+topic)
+	}
+
+	offsets := make([]e.KafkaRecordOffsetData, 0, len(results))
+	for _, result := range results {
+		offsets = append(offsets, e.KafkaRecordOffsetData{
+			Topic:     result.Record.Topic,
+			Partition: result.Record.Partition,
+			Offset:    result.Record.Offset,
+		})
+	}
+	return offsets, nil
+}
+
+// auto commit kapalı consumer group üyesi oluşturulur.
+func (k *KafkaProxyClient) IFNewConsumer(ctx context.Context, input e.CreateKafkaConsumerInput) (a.IKafkaConsumer, error) {
+	resetOffset := kgo.NewOffset().AtEnd()
+	if input.OffsetReset == env.KafkaOffsetResetEarliest {
+		resetOffset = kgo.NewOffset().AtStart()
+	}
+
+	opts := append([]kgo.Opt{
+		kgo.SeedBrokers(k.seedBrokers...),
+		kgo.ConsumerGroup(input.Group),
+		kgo.ConsumeTopics(input.Topics...),
+		kgo.ConsumeResetOffset(resetOffset),
+		kgo.DisableAutoCommit(),
+	}, k.opts...)
+
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, env.GetFuncError(env.KafkaOperationFailed, err, "new consumer client")
+	}
+	return &kafkaConsumer{client: client, polled: map[string]map[int32]kgo.EpochOffset{}}, nil
+}
+
+type kafkaConsumer struct {
+	client *kgo.Client
+	polled map[string]map[int32]kgo.EpochOffset //son poll ile alınan records için commit edilecek offsets
+}
+
+// poll context süresi dolarsa o ana kadar alınan records döner.
+func (c *kafkaConsumer) IFPoll(ctx context.Context, maxRecords int) ([]e.KafkaConsumedRecordData, error) {
+	fetches := c.client.PollRecords(ctx, maxRecords)
+
+	var fetchErr error
+	fetches.EachError(func(topic string, partition int32, err error) {
+		if fetchErr == nil && !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, context.Canceled) {
+			fetchErr = env.GetFuncError(env.KafkaOperationFailed, err, "poll "+topic)
+		}
+	})
+	if fetchErr != nil {
+		return nil, fetchErr
+	}
+
+	records := make([]e.KafkaConsumedRecordData, 0, fetches.NumRecords())
+	fetches.EachRecord(func(record *kgo.Record) {
+		consumedRecord := e.KafkaConsumedRecordData{
+			Topic:     record.Topic,
+			Partition: record.Partition,
+			Offset:    record.Offset,
+			Key:       record.Key,
+			Value:     record.Value,
+			Timestamp: record.Timestamp.UnixMilli(),
+		}
+		for _, header := range record.Headers {
+			consumedRecord.Headers = append(consumedRecord.Headers, e.KafkaRecordHeaderData{Key: header.Key, Value: header.Value})
+		}
+		records = append(records, consumedRecord)
+
+		if c.polled[record.Topic] == nil {
+			c.polled[record.Topic] = map[int32]kgo.EpochOffset{}
+		}
+		c.polled[record.Topic][record.Partition] = kgo.EpochOffset{Epoch: record.LeaderEpoch, Offset: record.Offset + 1}
+	})
+	return records, nil
+}
+
+// offsets boş ise son poll ile alınan records commit edilir. Commit edilen offset işlenen son record offset + 1 olarak yazılır.
+func (c *kafkaConsumer) IFCommit(ctx context.Context, offsets []e.KafkaRecordOffsetData) error {
+	uncommitted := c.polled
+	if len(offsets) > 0 {
+		uncommitted = map[string]map[int32]kgo.EpochOffset{}
+		for _, offset := range offsets {
+			if uncommitted[offset.Topic] == nil {
+				uncommitted[offset.Topic] = map[int32]kgo.EpochOffset{}
+			}
+			uncommitted[offset.Topic][offset.Partition] = kgo.EpochOffset{Epoch: -1, Offset: offset.Offset + 1}
+		}
+	}
+	if len(uncommitted) == 0 {
+		return nil
+	}
+
+	var commitErr error
+	c.client.CommitOffsetsSync(ctx, uncommitted, func(_ *kgo.Client, _ *kmsg.OffsetCommitRequest, response *kmsg.OffsetCommitResponse, err error) {
+		if err != nil {
+			commitErr = env.GetFuncError(env.KafkaOperationFailed, err, "commit")
+			return
+		}
+		for _, topic := range response.Topics {
+			for _, partition := range topic.Partitions {
+				if err := kerr.ErrorForCode(partition.ErrorCode); err != nil && commitErr == nil {
+					commitErr = env.GetFuncError(env.KafkaOperationFailed, err, "commit "+topic.Topic)
+				}
+			}
+		}
+	})
+	if commitErr != nil {
+		return commitErr
+	}
+
+	if len(offsets) == 0 {
+		c.polled = map[string]map[int32]kgo.EpochOffset{}
+	}
+	return nil
+}
+
+// client kapatılırken consumer group üzerinden ayrılır.
+func (c *kafkaConsumer) IFClose() {
+	c.client.Close()
+}
@@ -0,0 +1,76 @@
+package kafka
+
+import (
+	"fmt"
+	"sync"
+	"time"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+)
+
+// owner bazlı byte/saniye limitini token bucket ile uygular.
+type ProxyQuotaLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*quotaBucket //map[owner] => bucket
+	now     func() time.Time
+}
+
+type quotaBucket struct {
+	tokens   float64
+	updateAt time.Time
+}
+
+func NewProxyQuotaLimiter() *ProxyQuotaLimiter {
+	return &ProxyQuotaLimiter{buckets: map[string]*quotaBucket{}, now: time.Now}
+}
+
+// batch record sayısı ve toplam byte bilgisi owner quota ile karşılaştırılır.
+func CheckProduceBatch(owner string, quota e.KafkaProxyQuotaData, records []e.KafkaProduceRecordData) (int, error) {
+	if quota.MaxBatchRecords > 0 && len(records) > quota.MaxBatchRecords {
+		return 0, env.GetFuncError(env.KafkaQuotaExceeded, nil, owner,
+			fmt.Sprintf("batch has %d records, limit %d", len(records), quota.MaxBatchRecords))
+	}
+
+	batchBytes := 0
+	for _, record := range records {
+		batchBytes += len(record.Key) + len(record.Value)
+		for _, header := range record.Headers {
+			batchBytes += len(header.Key) + len(header.Value)
+		}
+	}
+	if quota.MaxBatchBytes > 0 && batchBytes > quota.MaxBatchBytes {
+		return 0, env.GetFuncError(env.KafkaQuotaExceeded, nil, owner,
+			fmt.Sprintf("batch has %d bytes, limit %d", batchBytes, quota.MaxBatchBytes))
+	}
+	return batchBytes, nil
+}
+
+/*
+- owner bucket saniyede ProduceBytesPerSec kadar dolar, en fazla bir saniyelik kapasite birikir.
+- batch byte bilgisi kapasiteden büyük ise istek reddedilir ve bucket değişmez.
+*/
+func (l *ProxyQuotaLimiter) AllowBytes(owner string, quota e.KafkaProxyQuotaData, bytes int) error {
+	if quota.ProduceBytesPerSec <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	capacity := float64(quota.ProduceBytesPerSec)
+	bucket, ok := l.buckets[owner]
+	if !ok {
+		bucket = &quotaBucket{tokens: capacity, updateAt: now}
+		l.buckets[owner] = bucket
+	}
+
+	bucket.tokens = min(capacity, bucket.tokens+now.Sub(bucket.updateAt).Seconds()*capacity)
+	bucket.updateAt = now
+	if float64(bytes) > bucket.tokens {
+		return env.GetFuncError(env.KafkaQuotaExceeded, nil, owner,
+			fmt.Sprintf("produce rate limit %d bytes/sec", quota.ProduceBytesPerSec))
+	}
+	bucket.tokens -= float64(bytes)
+	return nil
+}
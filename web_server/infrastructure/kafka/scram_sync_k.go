@@ -0,0 +1,66 @@
+package kafka
+
+import (
+	"sort"
+	"strings"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+)
+
+/*
+owner, store ve broker durumuna göre scram credential planı çıkarılır.
+- status bilgisi geçersiz owner: credential broker ve store üzerinden kaldırılır.
+- aktif owner: credential yoksa üretilir, süresi dolmuşsa ya da owner süresinden uzunsa yenilenir.
+- whitelist üzerinde bulunmayan yönetilen broker kullanıcıları kaldırılır.
+*/
+func PlanScramCredentials(input e.PlanKafkaScramInput) e.KafkaScramPlanData {
+	brokerUsers := map[string]bool{}
+	for _, user := range input.BrokerUserInfos {
+		if user.Mechanism == env.KafkaScramMechanismSHA512 {
+			brokerUsers[user.UserName] = true
+		}
+	}
+
+	plan := e.KafkaScramPlanData{}
+	knownUsers := map[string]bool{}
+	for _, owner := range input.OwnerInfos {
+		knownUsers[owner.UserName] = true
+		credential, stored := input.CredentialInfos[owner.UserName]
+
+		if !owner.Active {
+			if stored || brokerUsers[owner.UserName] {
+				plan.Revoke = append(plan.Revoke, owner.UserName)
+			}
+			continue
+		}
+
+		switch {
+		case !stored || !brokerUsers[owner.UserName]:
+			plan.Issue = append(plan.Issue, owner.UserName)
+		case credential.StatusInfo.ExpiresAt != 0 && input.CheckUnixTime >= credential.StatusInfo.ExpiresAt:
+			plan.Rotate = append(plan.Rotate, owner.UserName)
+		case owner.ExpiresAt != 0 && (credential.StatusInfo.ExpiresAt == 0 || credential.StatusInfo.ExpiresAt > owner.ExpiresAt):
+			plan.Rotate = append(plan.Rotate, owner.UserName)
+		}
+	}
+
+	for userName := range brokerUsers {
+		if !knownUsers[userName] && strings.HasPrefix(userName, env.KafkaUserPrefix) {
+			plan.Revoke = append(plan.Revoke, userName)
+		}
+	}
+
+	sort.Strings(plan.Issue)
+	sort.Strings(plan.Rotate)
+	sort.Strings(plan.Revoke)
+	return plan
+}
+
+// credential geçerlilik süresi rotation süresi ile belirlenir, owner süresini aşamaz.
+func ScramCredentialExpiresAt(checkUnixTime, rotationSeconds, ownerExpiresAt int64) int64 {
+	expiresAt := checkUnixTime + rotationSeconds
+	if ownerExpiresAt != 0 && ownerExpiresAt < expiresAt {
+		return ownerExpiresAt
+	}
+	return expiresAt
+}
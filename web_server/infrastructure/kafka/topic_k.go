@@ -0,0 +1,245 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	a "web_server/domain/abstractions"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+	u "web_server/utils"
+)
+
+// kafka tarafından kabul edilen topic isim sınırları
+const maxTopicNameLength = 249
+
+var legalTopicName = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+// topic ismi kafka kuralları, policy regex ve rezerve prefix bilgilerine göre kontrol edilir.
+func CheckTopicNamePolicy(policy e.KafkaTopicPolicyData, name string) error {
+	if name == "" || name == "." || name == ".." || len(name) > maxTopicNameLength || !legalTopicName.MatchString(name) {
+		return env.GetFuncError(env.InvalidKafkaTopic, nil, name, "illegal topic name")
+	}
+
+	for _, prefix := range policy.ReservedPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return env.GetFuncError(env.InvalidKafkaTopic, nil, name, "reserved prefix "+prefix)
+		}
+	}
+
+	if policy.NamePattern != "" {
+		pattern, err := regexp.Compile(policy.NamePattern)
+		if err != nil {
+			return env.GetFuncError(env.InvalidKafkaTopic, nil, name, "invalid policy name pattern")
+		}
+		if !pattern.MatchString(name) {
+			return env.GetFuncError(env.InvalidKafkaTopic, nil, name, "name does not match "+policy.NamePattern)
+		}
+	}
+	return nil
+}
+
+// topic configs sadece policy üzerinde izin verilen keys içerebilir.
+func checkTopicConfigKeys(policy e.KafkaTopicPolicyData, name string, keys []string) error {
+	for _, key := range keys {
+		if !slices.Contains(policy.AllowedConfigs, key) {
+			return env.GetFuncError(env.InvalidKafkaTopic, nil, name, "config not allowed "+key)
+		}
+	}
+	return nil
+}
+
+// oluşturulacak topic isim, partition, replication factor ve configs açısından policy ile karşılaştırılır.
+func CheckCreateTopicPolicy(policy e.KafkaTopicPolicyData, input e.CreateKafkaTopicInput) error {
+	if err := CheckTopicNamePolicy(policy, input.Name); err != nil {
+		return err
+	}
+
+	if input.Partitions < max(policy.MinPartitions, 1) || (policy.MaxPartitions > 0 && input.Partitions > policy.MaxPartitions) {
+		return env.GetFuncError(env.InvalidKafkaTopic, nil, input.Name,
+			fmt.Sprintf("partitions %d outside allowed range %d-%d", input.Partitions, max(policy.MinPartitions, 1), policy.MaxPartitions))
+	}
+
+	if input.ReplicationFactor < max(policy.MinReplicationFactor, 1) || (policy.MaxReplicationFactor > 0 && input.ReplicationFactor > policy.MaxReplicationFactor) {
+		return env.GetFuncError(env.InvalidKafkaTopic, nil, input.Name,
+			fmt.Sprintf("replication factor %d outside allowed range %d-%d", input.ReplicationFactor, max(policy.MinReplicationFactor, 1), policy.MaxReplicationFactor))
+	}
+
+	keys := make([]string, 0, len(input.Configs))
+	for key := range input.Configs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return checkTopicConfigKeys(policy, input.Name, keys)
+}
+
+func CheckAlterTopicConfigPolicy(policy e.KafkaTopicPolicyData, input e.AlterKafkaTopicConfigInput) error {
+	if err := CheckTopicNamePolicy(policy, input.Name); err != nil {
+		return err
+	}
+
+	if len(input.SetConfigs) == 0 && len(input.DeleteConfigs) == 0 {
+		return env.GetFuncError(env.InvalidKafkaTopic, nil, input.Name, "no config changes")
+	}
+
+	keys := make([]string, 0, len(input.SetConfigs)+len(input.DeleteConfigs))
+	for key := range input.SetConfigs {
+		if slices.Contains(input.DeleteConfigs, key) {
+			return env.GetFuncError(env.InvalidKafkaTopic, nil, input.Name, "config both set and deleted "+key)
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return checkTopicConfigKeys(policy, input.Name, append(keys, input.DeleteConfigs...))
+}
+
+/*
+- owner PermInfos içerisinde resource için literal <literalPrefix><name> yetkisi aranır.
+- bulunamazsa name ile eşleşen <prefixPrefix><prefix> yetkileri en uzun prefix önce olacak şekilde kontrol edilir.
+*/
+func checkResourcePerm(permInfos map[string]e.PermissionData, literalPrefix string, prefixPrefix string, name string, permType uint8) error {
+	literalPermKey := literalPrefix + name
+	if _, ok := permInfos[literalPermKey]; ok {
+		return u.CheckPermInfos(permInfos, map[string]uint8{literalPermKey: permType})
+	}
+
+	var prefixPermKeys []string
+	for permKey := range permInfos {
+		if prefix, ok := strings.CutPrefix(permKey, prefixPrefix); ok && strings.HasPrefix(name, prefix) {
+			prefixPermKeys = append(prefixPermKeys, permKey)
+		}
+	}
+	sort.Slice(prefixPermKeys, func(i, j int) bool { return len(prefixPermKeys[i]) > len(prefixPermKeys[j]) })
+
+	for _, permKey := range prefixPermKeys {
+		if err := u.CheckPermInfos(permInfos, map[string]uint8{permKey: permType}); err == nil {
+			return nil
+		}
+	}
+	return env.GetFuncError(env.InvalidTaskAuthn, nil, literalPermKey)
+}
+
+// topic için kafka-topic:<topic> ya da eşleşen kafka-topic-prefix:<prefix> yetkisi aranır.
+func CheckTopicPerm(permInfos map[string]e.PermissionData, topic string, permType uint8) error {
+	return checkResourcePerm(permInfos, env.KafkaTopicPermPrefix, env.KafkaTopicPrefixPermPrefix, topic, permType)
+}
+
+// consumer group için kafka-group:<group> ya da eşleşen kafka-group-prefix:<prefix> yetkisi aranır.
+func CheckGroupPerm(permInfos map[string]e.PermissionData, group string, permType uint8) error {
+	return checkResourcePerm(permInfos, env.KafkaGroupPermPrefix, env.KafkaGroupPrefixPermPrefix, group, permType)
+}
+
+func DescribeTopic(ctx context.Context, admin a.IKafkaTopicAdmin, name string) (e.KafkaTopicData, error) {
+	topic, exists, err := admin.IFDescribeTopic(ctx, name)
+	if err != nil {
+		return topic, err
+	}
+	if !exists {
+		return topic, env.GetFuncError(env.KafkaTopicNotFound, nil, name)
+	}
+	return topic, nil
+}
+
+/*
+- topic yoksa oluşturulur. kafka-topics.sh --if-not-exists davranışı ile aynıdır.
+- topic mevcut ve partition, replication factor ve istenen configs aynı ise değişiklik yapılmaz.
+- mevcut topic ayarları farklı ise conflict hatası döner, mevcut topic değiştirilmez.
+*/
+func CreateTopicIfNotExists(ctx context.Context, admin a.IKafkaTopicAdmin, input e.CreateKafkaTopicInput) (e.KafkaTopicResultData, error) {
+	existing, exists, err := admin.IFDescribeTopic(ctx, input.Name)
+	if err != nil {
+		return e.KafkaTopicResultData{}, err
+	}
+
+	if !exists {
+		created, err := admin.IFCreateTopic(ctx, input)
+		if err != nil {
+			return e.KafkaTopicResultData{}, err
+		}
+		if created {
+			return e.KafkaTopicResultData{TopicInfo: e.KafkaTopicData{
+				Name:              input.Name,
+				Partitions:        input.Partitions,
+				ReplicationFactor: input.ReplicationFactor,
+				Configs:           input.Configs,
+			}, Changed: true}, nil
+		}
+
+		//eş zamanlı oluşturma durumunda topic tekrar okunarak karşılaştırılır.
+		if existing, err = DescribeTopic(ctx, admin, input.Name); err != nil {
+			return e.KafkaTopicResultData{}, err
+		}
+	}
+
+	if reason := compareTopic(existing, input); reason != "" {
+		return e.KafkaTopicResultData{TopicInfo: existing}, env.GetFuncError(env.KafkaTopicConflict, nil, input.Name, reason)
+	}
+	return e.KafkaTopicResultData{TopicInfo: existing}, nil
+}
+
+// mevcut topic ile istek arasındaki ilk fark döner. Fark yoksa boş string döner.
+func compareTopic(existing e.KafkaTopicData, input e.CreateKafkaTopicInput) string {
+	if existing.Partitions != input.Partitions {
+		return fmt.Sprintf("partitions %d != %d", existing.Partitions, input.Partitions)
+	}
+	if existing.ReplicationFactor != input.ReplicationFactor {
+		return fmt.Sprintf("replication factor %d != %d", existing.ReplicationFactor, input.ReplicationFactor)
+	}
+
+	keys := make([]string, 0, len(input.Configs))
+	for key := range input.Configs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if existing.Configs[key] != input.Configs[key] {
+			return fmt.Sprintf("config %s %q != %q", key, existing.Configs[key], input.Configs[key])
+		}
+	}
+	return ""
+}
+
+// istenen configs zaten uygulanmış ise broker üzerinde değişiklik yapılmaz.
+func AlterTopicConfigs(ctx context.Context, admin a.IKafkaTopicAdmin, input e.AlterKafkaTopicConfigInput) (e.KafkaTopicResultData, error) {
+	existing, err := DescribeTopic(ctx, admin, input.Name)
+	if err != nil {
+		return e.KafkaTopicResultData{}, err
+	}
+
+	changed := false
+	for key, value := range input.SetConfigs {
+		if current, ok := existing.Configs[key]; !ok || current != value {
+			changed = true
+		}
+	}
+	for _, key := range input.DeleteConfigs {
+		if _, ok := existing.Configs[key]; ok {
+			changed = true
+		}
+	}
+	if !changed {
+		return e.KafkaTopicResultData{TopicInfo: existing}, nil
+	}
+
+	if err := admin.IFAlterTopicConfigs(ctx, input); err != nil {
+		return e.KafkaTopicResultData{}, err
+	}
+
+	updated, err := DescribeTopic(ctx, admin, input.Name)
+	if err != nil {
+		return e.KafkaTopicResultData{}, err
+	}
+	return e.KafkaTopicResultData{TopicInfo: updated, Changed: true}, nil
+}
+
+// topic yoksa hata dönmez, Changed false döner.
+func DeleteTopicIfExists(ctx context.Context, admin a.IKafkaTopicAdmin, name string) (e.KafkaTopicResultData, error) {
+	deleted, err := admin.IFDeleteTopic(ctx, name)
+	if err != nil {
+		return e.KafkaTopicResultData{}, err
+	}
+	return e.KafkaTopicResultData{TopicInfo: e.KafkaTopicData{Name: name}, Changed: deleted}, nil
+}
@@ -0,0 +1,9 @@
+package prometheus
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// karantinaya alınan yeni files kaynak bilgisine göre sayılır. Aynı file tekrar reddedilirse sayılmaz.
+var EnvQuarantinedFilesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "env_quarantined_files_total",
+	Help: "Number of rejected env, whitelist and access data files copied into quarantine, partitioned by rejecting source.",
+}, []string{"source"})
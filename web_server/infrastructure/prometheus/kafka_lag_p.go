@@ -0,0 +1,195 @@
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+	a "web_server/domain/abstractions"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	kafkaCommittedOffsetDesc = prometheus.NewDesc(
+		"kafka_consumergroup_committed_offset",
+		"Committed offset of the consumer group, partitioned by topic and partition.",
+		[]string{"group", "topic", "partition"}, nil)
+
+	kafkaLogEndOffsetDesc = prometheus.NewDesc(
+		"kafka_consumergroup_log_end_offset",
+		"Log end offset of the partition consumed by the consumer group.",
+		[]string{"group", "topic", "partition"}, nil)
+
+	kafkaLagDesc = prometheus.NewDesc(
+		"kafka_consumergroup_lag",
+		"Difference between the log end offset and the committed offset of the consumer group.",
+		[]string{"group", "topic", "partition"}, nil)
+
+	kafkaSinceLastCommitDesc = prometheus.NewDesc(
+		"kafka_consumergroup_seconds_since_last_commit",
+		"Seconds since the committed offset of the consumer group last changed, as observed by this exporter.",
+		[]string{"group", "topic", "partition"}, nil)
+
+	kafkaTopicPartitionsDesc = prometheus.NewDesc(
+		"kafka_topic_partitions",
+		"Number of partitions of the topic.",
+		[]string{"topic"}, nil)
+
+	kafkaUnderReplicatedDesc = prometheus.NewDesc(
+		"kafka_topic_under_replicated_partitions",
+		"Number of partitions of the topic whose in sync replicas are fewer than its replicas.",
+		[]string{"topic"}, nil)
+
+	kafkaScrapeSuccessDesc = prometheus.NewDesc(
+		"kafka_metrics_scrape_success",
+		"Whether the last scrape of the Kafka admin api succeeded for every target.",
+		nil, nil)
+)
+
+// commit edilen offset en son değiştiğinde gözlemlenen zaman
+type lastCommitData struct {
+	offset    int64
+	changedAt time.Time
+}
+
+/*
+- KafkaLagCollector, main env üzerinde tanımlanan consumer groups ve topics için lag metrics üretir.
+- kafka commit zamanını döndürmediği için son commit zamanı committed offset değişiminin gözlemlendiği scrape zamanıdır. Exporter açıldığında ilk scrape zamanı kabul edilir.
+- hedefler her scrape sırasında targets func ile tekrar okunur, env güncellemeleri yeniden başlatma gerektirmez.
+- admin hataları scrape işlemini bozmaz, kafka_metrics_scrape_success 0 olarak raporlanır.
+*/
+type KafkaLagCollector struct {
+	admin   a.IKafkaMetricsAdmin
+	targets func() (e.KafkaMetricsTargetData, error)
+	now     func() time.Time
+	timeout time.Duration
+
+	mu          sync.Mutex
+	lastCommits map[string]lastCommitData //map[group/topic/partition] => son commit bilgisi
+}
+
+// derleme zamanı prometheus collector interface check
+var _ prometheus.Collector = (*KafkaLagCollector)(nil)
+
+func NewKafkaLagCollector(admin a.IKafkaMetricsAdmin, targets func() (e.KafkaMetricsTargetData, error)) *KafkaLagCollector {
+	return &KafkaLagCollector{
+		admin:       admin,
+		targets:     targets,
+		now:         time.Now,
+		timeout:     env.KafkaMetricsScrapeTimeoutMs * time.Millisecond,
+		lastCommits: map[string]lastCommitData{},
+	}
+}
+
+// son commit süreleri hesaplanırken kullanılan saat değiştirilir.
+func (c *KafkaLagCollector) SetClock(now func() time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+func (c *KafkaLagCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- kafkaCommittedOffsetDesc
+	ch <- kafkaLogEndOffsetDesc
+	ch <- kafkaLagDesc
+	ch <- kafkaSinceLastCommitDesc
+	ch <- kafkaTopicPartitionsDesc
+	ch <- kafkaUnderReplicatedDesc
+	ch <- kafkaScrapeSuccessDesc
+}
+
+func (c *KafkaLagCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	success := 1.0
+	targets, err := c.targets()
+	if err != nil {
+		ch <- prometheus.MustNewConstMetric(kafkaScrapeSuccessDesc, prometheus.GaugeValue, 0)
+		return
+	}
+
+	//consumer groups tarafından commit edilen topics, topic metrics hedeflerine eklenir.
+	topics := slices.Clone(targets.Topics)
+	groupOffsets := map[string][]e.KafkaRecordOffsetData{}
+	for _, group := range sortedUnique(targets.Groups) {
+		offsets, err := c.admin.IFFetchGroupOffsets(ctx, group)
+		if err != nil {
+			success = 0
+			continue
+		}
+		groupOffsets[group] = offsets
+		for _, offset := range offsets {
+			topics = append(topics, offset.Topic)
+		}
+	}
+
+	endOffsets := map[string]map[int32]int64{} //map[topic][partition] => log end offset
+	if topics = sortedUnique(topics); len(topics) > 0 {
+		topicMetrics, err := c.admin.IFDescribeTopicMetrics(ctx, topics)
+		if err != nil {
+			success = 0
+		}
+		for _, topic := range topicMetrics {
+			underReplicated := 0
+			endOffsets[topic.Name] = map[int32]int64{}
+			for _, partition := range topic.PartitionInfos {
+				if partition.InSyncReplicas < partition.Replicas {
+					underReplicated++
+				}
+				endOffsets[topic.Name][partition.Partition] = partition.EndOffset
+			}
+			ch <- prometheus.MustNewConstMetric(kafkaTopicPartitionsDesc, prometheus.GaugeValue, float64(len(topic.PartitionInfos)), topic.Name)
+			ch <- prometheus.MustNewConstMetric(kafkaUnderReplicatedDesc, prometheus.GaugeValue, float64(underReplicated), topic.Name)
+		}
+	}
+
+	now := c.now()
+	seen := map[string]bool{}
+	for group, offsets := range groupOffsets {
+		for _, offset := range offsets {
+			partition := strconv.Itoa(int(offset.Partition))
+			ch <- prometheus.MustNewConstMetric(kafkaCommittedOffsetDesc, prometheus.GaugeValue, float64(offset.Offset), group, offset.Topic, partition)
+
+			key := fmt.Sprintf("%s/%s/%d", group, offset.Topic, offset.Partition)
+			seen[key] = true
+			lastCommit, ok := c.lastCommits[key]
+			if !ok || lastCommit.offset != offset.Offset {
+				lastCommit = lastCommitData{offset: offset.Offset, changedAt: now}
+				c.lastCommits[key] = lastCommit
+			}
+			ch <- prometheus.MustNewConstMetric(kafkaSinceLastCommitDesc, prometheus.GaugeValue, now.Sub(lastCommit.changedAt).Seconds(), group, offset.Topic, partition)
+
+			endOffset, ok := endOffsets[offset.Topic][offset.Partition]
+			if !ok || endOffset < 0 {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(kafkaLogEndOffsetDesc, prometheus.GaugeValue, float64(endOffset), group, offset.Topic, partition)
+			ch <- prometheus.MustNewConstMetric(kafkaLagDesc, prometheus.GaugeValue, float64(max(endOffset-offset.Offset, 0)), group, offset.Topic, partition)
+		}
+	}
+
+	//artık hedeflenmeyen ya da silinen partitions için tutulan commit bilgileri temizlenir.
+	for key := range c.lastCommits {
+		if !seen[key] {
+			delete(c.lastCommits, key)
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(kafkaScrapeSuccessDesc, prometheus.GaugeValue, success)
+}
+
+func sortedUnique(values []string) []string {
+	sorted := slices.Clone(values)
+	sort.Strings(sorted)
+	return slices.Compact(sorted)
+}
@@ -0,0 +1,306 @@
+package zookeeper
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	a "web_server/domain/abstractions"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+	u "web_server/utils"
+)
+
+const (
+	defaultClientAddress = "0.0.0.0"
+
+	configHeader = "# Bu file kaftion guard tarafından env map üzerinden üretilir. Elle düzenlenmemelidir.\n"
+
+	serverKeyPrefix = "server."
+	versionKey      = "version"
+)
+
+// üye bilgisi karşılaştırma ve render işlemlerinden önce varsayılan değerler ile tamamlanır.
+func normalizeMember(member e.ZookeeperMemberData) e.ZookeeperMemberData {
+	if member.Role == "" {
+		member.Role = env.ZookeeperRoleParticipant
+	}
+	if member.ClientPort != 0 && member.ClientAddress == "" {
+		member.ClientAddress = defaultClientAddress
+	}
+	return member
+}
+
+// üyeler normalize edilerek id sırasına göre dizilir.
+func normalizeEnsemble(ensemble e.ZookeeperEnsembleData) e.ZookeeperEnsembleData {
+	members := make([]e.ZookeeperMemberData, 0, len(ensemble.MemberInfos))
+	for _, member := range ensemble.MemberInfos {
+		members = append(members, normalizeMember(member))
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].ID < members[j].ID })
+	return e.ZookeeperEnsembleData{MemberInfos: members, Version: ensemble.Version}
+}
+
+/*
+- ensemble üyeleri kontrol edilir. id 1-255 aralığında ve benzersiz olmak zorundadır.
+- quorum ve election port aynı host üzerinde başka bir üye tarafından kullanılamaz.
+- en az bir participant bulunmak zorundadır.
+*/
+func ValidateEnsemble(ensemble e.ZookeeperEnsembleData) error {
+	if len(ensemble.MemberInfos) == 0 {
+		return env.GetFuncError(env.InvalidZookeeperEnsemble, nil, "at least one member required")
+	}
+
+	ids := map[int32]struct{}{}
+	addresses := map[string]struct{}{}
+	voters := 0
+	for _, member := range normalizeEnsemble(ensemble).MemberInfos {
+		if member.ID < 1 || member.ID > 255 {
+			return env.GetFuncError(env.InvalidZookeeperEnsemble, nil, fmt.Sprintf("server id %d out of range 1-255", member.ID))
+		}
+		if _, ok := ids[member.ID]; ok {
+			return env.GetFuncError(env.InvalidZookeeperEnsemble, nil, fmt.Sprintf("duplicate server id %d", member.ID))
+		}
+		ids[member.ID] = struct{}{}
+
+		if member.Host == "" {
+			return env.GetFuncError(env.InvalidZookeeperEnsemble, nil, fmt.Sprintf("server %d host required", member.ID))
+		}
+
+		for _, port := range []int{member.QuorumPort, member.ElectionPort, member.ClientPort} {
+			if port < 1 || port > 65535 {
+				return env.GetFuncError(env.InvalidZookeeperEnsemble, nil, fmt.Sprintf("server %d invalid port %d", member.ID, port))
+			}
+		}
+		if member.QuorumPort == member.ElectionPort {
+			return env.GetFuncError(env.InvalidZookeeperEnsemble, nil, fmt.Sprintf("server %d quorum and election ports must differ", member.ID))
+		}
+
+		for _, port := range []int{member.QuorumPort, member.ElectionPort} {
+			address := net.JoinHostPort(member.Host, strconv.Itoa(port))
+			if _, ok := addresses[address]; ok {
+				return env.GetFuncError(env.InvalidZookeeperEnsemble, nil, "duplicate server address "+address)
+			}
+			addresses[address] = struct{}{}
+		}
+
+		switch member.Role {
+		case env.ZookeeperRoleParticipant:
+			voters++
+		case env.ZookeeperRoleObserver:
+		default:
+			return env.GetFuncError(env.InvalidZookeeperEnsemble, nil, fmt.Sprintf("server %d invalid role %s", member.ID, member.Role))
+		}
+	}
+
+	if voters == 0 {
+		return env.GetFuncError(env.InvalidZookeeperEnsemble, nil, "at least one participant required")
+	}
+	return nil
+}
+
+// Ex: server.1=zookeeper1:2888:3888:participant;0.0.0.0:2181
+func FormatMember(member e.ZookeeperMemberData) string {
+	member = normalizeMember(member)
+	line := fmt.Sprintf("%s%d=%s:%d:%d:%s", serverKeyPrefix, member.ID,
+		formatHost(member.Host), member.QuorumPort, member.ElectionPort, member.Role)
+	if member.ClientPort != 0 {
+		line += ";" + net.JoinHostPort(member.ClientAddress, strconv.Itoa(member.ClientPort))
+	}
+	return line
+}
+
+// dynamic config file üyeler id sırasına göre yazılarak deterministik olarak üretilir.
+func RenderDynamicConfig(ensemble e.ZookeeperEnsembleData) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(configHeader)
+	for _, member := range normalizeEnsemble(ensemble).MemberInfos {
+		buf.WriteString(FormatMember(member))
+		buf.WriteByte('\n')
+	}
+	if ensemble.Version != 0 {
+		buf.WriteString(versionKey + "=" + strconv.FormatInt(ensemble.Version, 16) + "\n")
+	}
+	return buf.Bytes()
+}
+
+/*
+- dynamic config içeriği okunur. server.<id> ve version dışındaki satırlar hata döner.
+- version hex formatında tutulur. Ex: version=100000000
+*/
+func ParseDynamicConfig(data []byte) (e.ZookeeperEnsembleData, error) {
+	return parseEnsembleLines(data, false)
+}
+
+// conf komutu çıktısı içerisindeki ensemble bilgisi alınır, diğer satırlar atlanır.
+func parseConfOutput(data []byte) (e.ZookeeperEnsembleData, error) {
+	return parseEnsembleLines(data, true)
+}
+
+func parseEnsembleLines(data []byte, skipUnknown bool) (e.ZookeeperEnsembleData, error) {
+	ensemble := e.ZookeeperEnsembleData{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		switch {
+		case ok && strings.HasPrefix(key, serverKeyPrefix):
+			member, err := parseMember(key, value)
+			if err != nil {
+				return ensemble, err
+			}
+			ensemble.MemberInfos = append(ensemble.MemberInfos, member)
+		case ok && key == versionKey:
+			version, err := strconv.ParseInt(value, 16, 64)
+			if err != nil {
+				return ensemble, env.GetFuncError(env.InvalidZookeeperEnsemble, nil, "invalid version "+value)
+			}
+			ensemble.Version = version
+		case skipUnknown:
+			continue
+		default:
+			return ensemble, env.GetFuncError(env.InvalidZookeeperEnsemble, nil, "unexpected line "+line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return ensemble, env.GetFuncError(env.UnexpectedError, err)
+	}
+	return normalizeEnsemble(ensemble), nil
+}
+
+// server.<id>=<host>:<quorum_port>:<election_port>[:<role>][;[<client_address>:]<client_port>]
+func parseMember(key string, value string) (e.ZookeeperMemberData, error) {
+	invalid := env.GetFuncError(env.InvalidZookeeperEnsemble, nil, "invalid server line "+key+"="+value)
+
+	id, err := strconv.ParseInt(strings.TrimPrefix(key, serverKeyPrefix), 10, 32)
+	if err != nil {
+		return e.ZookeeperMemberData{}, invalid
+	}
+	member := e.ZookeeperMemberData{ID: int32(id)}
+
+	serverPart, clientPart, hasClient := strings.Cut(value, ";")
+
+	host, rest := serverPart, ""
+	if strings.HasPrefix(serverPart, "[") {
+		end := strings.Index(serverPart, "]")
+		if end < 0 {
+			return member, invalid
+		}
+		host, rest = serverPart[1:end], strings.TrimPrefix(serverPart[end+1:], ":")
+	} else {
+		var found bool
+		if host, rest, found = strings.Cut(serverPart, ":"); !found {
+			return member, invalid
+		}
+	}
+	member.Host = host
+
+	fields := strings.Split(rest, ":")
+	if len(fields) < 2 || len(fields) > 3 {
+		return member, invalid
+	}
+	if member.QuorumPort, err = strconv.Atoi(fields[0]); err != nil {
+		return member, invalid
+	}
+	if member.ElectionPort, err = strconv.Atoi(fields[1]); err != nil {
+		return member, invalid
+	}
+	if len(fields) == 3 {
+		member.Role = fields[2]
+	}
+
+	if hasClient {
+		clientAddress, rawPort := defaultClientAddress, clientPart
+		if strings.Contains(clientPart, ":") {
+			if clientAddress, rawPort, err = net.SplitHostPort(clientPart); err != nil {
+				return member, invalid
+			}
+		}
+		if member.ClientPort, err = strconv.Atoi(rawPort); err != nil {
+			return member, invalid
+		}
+		member.ClientAddress = clientAddress
+	}
+	return normalizeMember(member), nil
+}
+
+/*
+- zoo.cfg static properties ile üretilir, ensemble üyeleri zoo.cfg üzerinde bulunamaz.
+- dynamic config file ve reconfig ayarı eklenir, verify için gereken four letter words whitelist'e eklenir.
+*/
+func RenderZooConfig(input e.RenderZooConfigInput) ([]byte, error) {
+	if input.DynamicConfigFile == "" {
+		return nil, env.GetFuncError(env.InvalidZookeeperEnsemble, nil, env.ZookeeperPropDynamicConfigFile+" required")
+	}
+
+	properties := make(map[string]string, len(input.PropertyInfos)+3)
+	for key, value := range input.PropertyInfos {
+		for _, prefix := range env.ZookeeperDynamicOnlyPropSlice {
+			if strings.HasPrefix(key, prefix) {
+				return nil, env.GetFuncError(env.InvalidZookeeperEnsemble, nil, key+" must be defined in the dynamic config")
+			}
+		}
+		if key == "" || strings.ContainsAny(key, "=\n\r") || strings.ContainsAny(value, "\n\r") {
+			return nil, env.GetFuncError(env.InvalidZookeeperEnsemble, nil, "invalid property "+key)
+		}
+		properties[key] = value
+	}
+
+	properties[env.ZookeeperPropDynamicConfigFile] = input.DynamicConfigFile
+	properties[env.ZookeeperPropReconfigEnabled] = "true"
+	properties[env.ZookeeperProp4lwWhitelist] = mergeFourLetterWords(properties[env.ZookeeperProp4lwWhitelist])
+
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.WriteString(configHeader)
+	for _, key := range keys {
+		buf.WriteString(key + "=" + properties[key] + "\n")
+	}
+	return buf.Bytes(), nil
+}
+
+// mevcut whitelist korunur, verify için gereken komutlar eklenir. Whitelist * ise değiştirilmez.
+func mergeFourLetterWords(whitelist string) string {
+	if strings.TrimSpace(whitelist) == "*" {
+		return "*"
+	}
+
+	var commands []string
+	for _, command := range strings.FieldsFunc(whitelist, func(r rune) bool { return r == ',' || r == ' ' }) {
+		if !slices.Contains(commands, command) {
+			commands = append(commands, command)
+		}
+	}
+	for _, command := range env.ZookeeperFourLetterWordSlice {
+		if !slices.Contains(commands, command) {
+			commands = append(commands, command)
+		}
+	}
+	sort.Strings(commands)
+	return strings.Join(commands, ",")
+}
+
+// dynamic config ve zoo.cfg imzası ile birlikte yazılır.
+func WriteSignedConfig(filePath string, data []byte, signer a.ISigner) error {
+	return u.WriteSignedFile(filePath, data, 0o644, signer)
+}
+
+func formatHost(host string) string {
+	if strings.Contains(host, ":") {
+		return "[" + host + "]"
+	}
+	return host
+}
@@ -0,0 +1,216 @@
+package zookeeper
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+	a "web_server/domain/abstractions"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+)
+
+const (
+	defaultCommandTimeout = 5 * time.Second
+
+	//komut whitelist içerisinde değilse sunucu bu mesaj ile cevap verir.
+	notWhitelistedMessage = "is not executed because it is not in the whitelist"
+)
+
+// NetDialer, ensemble üyelerine tcp üzerinden bağlanır.
+type NetDialer struct {
+	Timeout time.Duration
+}
+
+// derleme zamanı zookeeper dialer interface check
+var _ a.IZookeeperDialer = NetDialer{}
+
+func (d NetDialer) IFDial(ctx context.Context, address string) (net.Conn, error) {
+	dialer := net.Dialer{Timeout: d.Timeout}
+	return dialer.DialContext(ctx, "tcp", address)
+}
+
+// four letter word komutu gönderilir, sunucu bağlantıyı kapatana kadar cevap okunur.
+func SendFourLetterWord(ctx context.Context, dialer a.IZookeeperDialer, address string, command string) (string, error) {
+	conn, err := dialer.IFDial(ctx, address)
+	if err != nil {
+		return "", env.GetFuncError(env.ZookeeperCommandFailed, err, command+"@"+address)
+	}
+	defer conn.Close()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(defaultCommandTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return "", env.GetFuncError(env.ZookeeperCommandFailed, err, command+"@"+address)
+	}
+
+	if _, err := io.WriteString(conn, command); err != nil {
+		return "", env.GetFuncError(env.ZookeeperCommandFailed, err, command+"@"+address)
+	}
+
+	response, err := io.ReadAll(conn)
+	if err != nil {
+		return "", env.GetFuncError(env.ZookeeperCommandFailed, err, command+"@"+address)
+	}
+
+	if strings.Contains(string(response), notWhitelistedMessage) {
+		return "", env.GetFuncError(env.ZookeeperCommandFailed, errors.New(strings.TrimSpace(string(response))), command+"@"+address)
+	}
+	return string(response), nil
+}
+
+// srvr çıktısı içerisindeki "Key: Value" satırları alınır. Ex: Mode: follower
+func parseSrvrOutput(output string) map[string]string {
+	values := map[string]string{}
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		if key, value, ok := strings.Cut(scanner.Text(), ":"); ok {
+			values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return values
+}
+
+// mntr çıktısı tab ile ayrılmış key/value satırlarından oluşur. Ex: zk_server_state	leader
+func parseMntrOutput(output string) map[string]string {
+	values := map[string]string{}
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		if key, value, ok := strings.Cut(scanner.Text(), "\t"); ok {
+			values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return values
+}
+
+// üye durumu srvr, conf ve leader için mntr komutları ile alınır.
+func describeMember(ctx context.Context, dialer a.IZookeeperDialer, member e.ZookeeperMemberData, expected e.ZookeeperEnsembleData) e.ZookeeperMemberStatusData {
+	status := e.ZookeeperMemberStatusData{
+		ID:      member.ID,
+		Address: net.JoinHostPort(member.Host, strconv.Itoa(member.ClientPort)),
+	}
+
+	srvrOutput, err := SendFourLetterWord(ctx, dialer, status.Address, env.ZookeeperCmdSrvr)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	srvrValues := parseSrvrOutput(srvrOutput)
+	status.Mode = srvrValues["Mode"]
+	status.Zxid = srvrValues["Zxid"]
+
+	confOutput, err := SendFourLetterWord(ctx, dialer, status.Address, env.ZookeeperCmdConf)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	running, err := parseConfOutput([]byte(confOutput))
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	status.ConfigMatches = ensemblesEqual(expected, running) &&
+		(expected.Version == 0 || expected.Version == running.Version)
+
+	if status.Mode == env.ZookeeperModeLeader {
+		mntrOutput, err := SendFourLetterWord(ctx, dialer, status.Address, env.ZookeeperCmdMntr)
+		if err != nil {
+			status.Error = err.Error()
+			return status
+		}
+		if status.SyncedFollowers, err = strconv.Atoi(parseMntrOutput(mntrOutput)["zk_synced_followers"]); err != nil {
+			status.Error = "invalid zk_synced_followers"
+		}
+	}
+	return status
+}
+
+/*
+- render edilen ensemble çalışan ensemble ile four letter words komutları üzerinden karşılaştırılır.
+- her üyenin conf çıktısı render edilen üyeler ile aynı, mode bilgisi role ile uyumlu olmak zorundadır.
+- tek bir leader bulunmalı ve leader ile senkron participant sayısı çoğunluğu sağlamalıdır.
+- eşleşmeyen durumlarda rapor hata ile birlikte döner.
+*/
+func VerifyEnsemble(ctx context.Context, dialer a.IZookeeperDialer, ensemble e.ZookeeperEnsembleData) (e.ZookeeperVerifyReportData, error) {
+	if err := ValidateEnsemble(ensemble); err != nil {
+		return e.ZookeeperVerifyReportData{}, err
+	}
+	ensemble = normalizeEnsemble(ensemble)
+
+	report := e.ZookeeperVerifyReportData{}
+	var problems []string
+	leaders := 0
+	voters := countVoters(ensemble.MemberInfos)
+
+	for _, member := range ensemble.MemberInfos {
+		status := describeMember(ctx, dialer, member, ensemble)
+		report.MemberStatusInfos = append(report.MemberStatusInfos, status)
+
+		if status.Error != "" {
+			problems = append(problems, fmt.Sprintf("server %d: %s", member.ID, status.Error))
+			continue
+		}
+		if !status.ConfigMatches {
+			problems = append(problems, fmt.Sprintf("server %d: config mismatch", member.ID))
+		}
+		if !modeMatchesRole(status.Mode, member.Role, len(ensemble.MemberInfos)) {
+			problems = append(problems, fmt.Sprintf("server %d: mode %s does not match role %s", member.ID, status.Mode, member.Role))
+		}
+
+		switch status.Mode {
+		case env.ZookeeperModeLeader:
+			leaders++
+			report.LeaderID = member.ID
+			if status.SyncedFollowers+1 < quorumSize(voters) {
+				problems = append(problems, fmt.Sprintf("leader %d has %d synced followers, quorum requires %d", member.ID, status.SyncedFollowers, quorumSize(voters)-1))
+			}
+		case env.ZookeeperModeStandalone:
+			leaders++
+			report.LeaderID = member.ID
+		}
+	}
+
+	if leaders != 1 {
+		problems = append(problems, fmt.Sprintf("expected exactly one leader, found %d", leaders))
+	}
+
+	report.Healthy = len(problems) == 0
+	if !report.Healthy {
+		return report, env.GetFuncError(env.ZookeeperEnsembleMismatch, nil, strings.Join(problems, "; "))
+	}
+	return report, nil
+}
+
+// participant leader ya da follower, observer sadece observer olabilir. Tek üyeli ensemble standalone çalışabilir.
+func modeMatchesRole(mode string, role string, memberCount int) bool {
+	switch role {
+	case env.ZookeeperRoleParticipant:
+		return mode == env.ZookeeperModeLeader || mode == env.ZookeeperModeFollower ||
+			(mode == env.ZookeeperModeStandalone && memberCount == 1)
+	case env.ZookeeperRoleObserver:
+		return mode == env.ZookeeperModeObserver
+	default:
+		return false
+	}
+}
+
+// çalışan ensemble bilgisi ulaşılabilen ilk üyenin conf çıktısı üzerinden alınır.
+func DescribeRunningEnsemble(ctx context.Context, dialer a.IZookeeperDialer, addresses []string) (e.ZookeeperEnsembleData, error) {
+	var lastErr error = env.GetFuncError(env.ZookeeperCommandFailed, nil, env.ZookeeperCmdConf)
+	for _, address := range addresses {
+		confOutput, err := SendFourLetterWord(ctx, dialer, address, env.ZookeeperCmdConf)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return parseConfOutput([]byte(confOutput))
+	}
+	return e.ZookeeperEnsembleData{}, lastErr
+}
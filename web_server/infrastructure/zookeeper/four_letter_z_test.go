@@ -0,0 +1,119 @@
+package zookeeper
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+)
+
+func newTestEnsemble(ids ...int32) e.ZookeeperEnsembleData {
+	ensemble := e.ZookeeperEnsembleData{}
+	for _, id := range ids {
+		ensemble.MemberInfos = append(ensemble.MemberInfos, e.ZookeeperMemberData{
+			ID:           id,
+			Host:         "zookeeper" + strconv.Itoa(int(id)),
+			QuorumPort:   2888,
+			ElectionPort: 3888,
+			ClientPort:   2181,
+		})
+	}
+	return ensemble
+}
+
+func memberAddress(member e.ZookeeperMemberData) string {
+	return net.JoinHostPort(member.Host, strconv.Itoa(member.ClientPort))
+}
+
+// ilk üye leader, diğer üyeler follower olarak running ensemble conf çıktısı ile çalışır.
+func newTestDialer(running e.ZookeeperEnsembleData, syncedFollowers int) *MemoryDialer {
+	dialer := NewMemoryDialer()
+	for i, member := range running.MemberInfos {
+		mode := env.ZookeeperModeFollower
+		if i == 0 {
+			mode = env.ZookeeperModeLeader
+		}
+		dialer.Handle(memberAddress(member), NewFakeMemberHandler(mode, syncedFollowers, running))
+	}
+	return dialer
+}
+
+func TestVerifyEnsembleMatch(t *testing.T) {
+	ensemble := newTestEnsemble(1, 2, 3)
+	report, err := VerifyEnsemble(context.Background(), newTestDialer(ensemble, 2), ensemble)
+	if err != nil {
+		t.Fatalf("VerifyEnsemble() error = %v", err)
+	}
+	if !report.Healthy || report.LeaderID != 1 {
+		t.Fatalf("report = %+v, want healthy with leader 1", report)
+	}
+	for _, status := range report.MemberStatusInfos {
+		if !status.ConfigMatches || status.Error != "" {
+			t.Errorf("member %d status = %+v, want config match", status.ID, status)
+		}
+	}
+}
+
+func TestVerifyEnsembleConfigMismatch(t *testing.T) {
+	rendered := newTestEnsemble(1, 2, 3)
+	dialer := newTestDialer(rendered, 2)
+	//üye 3 eski ensemble ile çalışıyor.
+	dialer.Handle(memberAddress(rendered.MemberInfos[2]), NewFakeMemberHandler(env.ZookeeperModeFollower, 0, newTestEnsemble(1, 2)))
+
+	report, err := VerifyEnsemble(context.Background(), dialer, rendered)
+	if !env.IsFuncError(err, env.ZookeeperEnsembleMismatch) {
+		t.Fatalf("VerifyEnsemble() error = %v, want ZookeeperEnsembleMismatch", err)
+	}
+	if report.Healthy {
+		t.Fatal("report healthy, want unhealthy")
+	}
+	if report.MemberStatusInfos[2].ConfigMatches {
+		t.Error("member 3 config matches, want mismatch")
+	}
+}
+
+func TestVerifyEnsembleVersionMismatch(t *testing.T) {
+	running := newTestEnsemble(1, 2, 3)
+	running.Version = 0x100000002
+	rendered := running
+	rendered.Version = 0x100000003
+
+	_, err := VerifyEnsemble(context.Background(), newTestDialer(running, 2), rendered)
+	if !env.IsFuncError(err, env.ZookeeperEnsembleMismatch) {
+		t.Fatalf("VerifyEnsemble() error = %v, want ZookeeperEnsembleMismatch", err)
+	}
+}
+
+func TestVerifyEnsembleLeaderWithoutQuorum(t *testing.T) {
+	ensemble := newTestEnsemble(1, 2, 3)
+	_, err := VerifyEnsemble(context.Background(), newTestDialer(ensemble, 0), ensemble)
+	if !env.IsFuncError(err, env.ZookeeperEnsembleMismatch) {
+		t.Fatalf("VerifyEnsemble() error = %v, want ZookeeperEnsembleMismatch", err)
+	}
+}
+
+func TestVerifyEnsembleUnreachableMember(t *testing.T) {
+	ensemble := newTestEnsemble(1, 2, 3)
+	dialer := newTestDialer(ensemble, 2)
+	dialer.Handle(memberAddress(ensemble.MemberInfos[1]), nil)
+
+	report, err := VerifyEnsemble(context.Background(), dialer, ensemble)
+	if !env.IsFuncError(err, env.ZookeeperEnsembleMismatch) {
+		t.Fatalf("VerifyEnsemble() error = %v, want ZookeeperEnsembleMismatch", err)
+	}
+	if report.MemberStatusInfos[1].Error == "" {
+		t.Error("member 2 error empty, want dial error")
+	}
+}
+
+func TestSendFourLetterWordNotWhitelisted(t *testing.T) {
+	ensemble := newTestEnsemble(1)
+	dialer := newTestDialer(ensemble, 0)
+
+	_, err := SendFourLetterWord(context.Background(), dialer, memberAddress(ensemble.MemberInfos[0]), "ruok")
+	if !env.IsFuncError(err, env.ZookeeperCommandFailed) {
+		t.Fatalf("SendFourLetterWord() error = %v, want ZookeeperCommandFailed", err)
+	}
+}
@@ -0,0 +1,85 @@
+package zookeeper
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	a "web_server/domain/abstractions"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+)
+
+// MemoryDialer, ensemble üyeleri yerine geçen bellek üzerindeki sunucular ile bağlantı kurar. Dry-run ve test senaryolarında kullanılır.
+type MemoryDialer struct {
+	mu       sync.Mutex
+	handlers map[string]func(command string) string //map[address] => komut cevabı
+}
+
+// derleme zamanı zookeeper dialer interface check
+var _ a.IZookeeperDialer = (*MemoryDialer)(nil)
+
+func NewMemoryDialer() *MemoryDialer {
+	return &MemoryDialer{handlers: map[string]func(command string) string{}}
+}
+
+// adres üzerindeki sunucu tanımlanır. handler nil ise sunucu kaldırılır.
+func (m *MemoryDialer) Handle(address string, handler func(command string) string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if handler == nil {
+		delete(m.handlers, address)
+		return
+	}
+	m.handlers[address] = handler
+}
+
+func (m *MemoryDialer) IFDial(ctx context.Context, address string) (net.Conn, error) {
+	m.mu.Lock()
+	handler, ok := m.handlers[address]
+	m.mu.Unlock()
+	if !ok {
+		return nil, &net.OpError{Op: "dial", Net: "memory", Err: fmt.Errorf("connection refused: %s", address)}
+	}
+
+	client, server := net.Pipe()
+	go func() {
+		defer server.Close()
+		command := make([]byte, 4)
+		if _, err := io.ReadFull(server, command); err != nil {
+			return
+		}
+		io.WriteString(server, handler(string(command)))
+	}()
+	return client, nil
+}
+
+/*
+- zookeeper üyesi gibi srvr, mntr ve conf komutlarına cevap veren handler üretilir.
+- conf cevabı verilen ensemble üzerinden, whitelist dışındaki komutlar zookeeper mesajı ile cevaplanır.
+*/
+func NewFakeMemberHandler(mode string, syncedFollowers int, ensemble e.ZookeeperEnsembleData) func(command string) string {
+	return func(command string) string {
+		switch command {
+		case env.ZookeeperCmdSrvr:
+			return fmt.Sprintf("Zookeeper version: 3.9.2\nLatency min/avg/max: 0/0.0/0\nReceived: 1\nSent: 0\nConnections: 1\nOutstanding: 0\nZxid: 0x100000000\nMode: %s\nNode count: 5\n", mode)
+		case env.ZookeeperCmdMntr:
+			return fmt.Sprintf("zk_version\t3.9.2\nzk_server_state\t%s\nzk_synced_followers\t%d\n", mode, syncedFollowers)
+		case env.ZookeeperCmdConf:
+			var buf strings.Builder
+			buf.WriteString("clientPort=2181\ndataDir=/bitnami/zookeeper/data\n")
+			for _, member := range normalizeEnsemble(ensemble).MemberInfos {
+				buf.WriteString(FormatMember(member) + "\n")
+			}
+			if ensemble.Version != 0 {
+				buf.WriteString(fmt.Sprintf("%s=%x\n", versionKey, ensemble.Version))
+			}
+			return buf.String()
+		default:
+			return command + " " + notWhitelistedMessage + ".\n"
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package zookeeper
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+)
+
+// ensemble üzerindeki participant sayısı
+func countVoters(members []e.ZookeeperMemberData) int {
+	voters := 0
+	for _, member := range members {
+		if normalizeMember(member).Role == env.ZookeeperRoleParticipant {
+			voters++
+		}
+	}
+	return voters
+}
+
+// voters sayısı için gereken çoğunluk
+func quorumSize(voters int) int {
+	return voters/2 + 1
+}
+
+func ensemblesEqual(left e.ZookeeperEnsembleData, right e.ZookeeperEnsembleData) bool {
+	left, right = normalizeEnsemble(left), normalizeEnsemble(right)
+	if len(left.MemberInfos) != len(right.MemberInfos) {
+		return false
+	}
+	for i := range left.MemberInfos {
+		if left.MemberInfos[i] != right.MemberInfos[i] {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+- mevcut ensemble istenen ensemble haline tek tek uygulanacak adımlar ile getirilir.
+- her adım participant sayısını en fazla bir değiştirir, böylece eski ve yeni çoğunluk her adımda kesişir.
+- önce ekleme ve observer => participant geçişleri, sonra participant => observer geçişleri ve çıkarmalar yapılır.
+- hiçbir adım ensemble üzerinde participant bırakmayacak şekilde planlanamaz.
+*/
+func PlanEnsembleChange(current e.ZookeeperEnsembleData, desired e.ZookeeperEnsembleData) ([]e.ZookeeperReconfigStepData, error) {
+	if err := ValidateEnsemble(desired); err != nil {
+		return nil, err
+	}
+	current, desired = normalizeEnsemble(current), normalizeEnsemble(desired)
+
+	currentMembers := make(map[int32]e.ZookeeperMemberData, len(current.MemberInfos))
+	for _, member := range current.MemberInfos {
+		currentMembers[member.ID] = member
+	}
+	desiredMembers := make(map[int32]e.ZookeeperMemberData, len(desired.MemberInfos))
+	for _, member := range desired.MemberInfos {
+		desiredMembers[member.ID] = member
+	}
+
+	var growSteps, shrinkSteps []e.ZookeeperReconfigStepData
+	for _, member := range desired.MemberInfos {
+		currentMember, exists := currentMembers[member.ID]
+		switch {
+		case !exists:
+			growSteps = append(growSteps, e.ZookeeperReconfigStepData{Action: env.ZookeeperStepAdd, MemberInfo: member})
+		case currentMember == member:
+			continue
+		case currentMember.Role == env.ZookeeperRoleParticipant && member.Role == env.ZookeeperRoleObserver:
+			shrinkSteps = append(shrinkSteps, e.ZookeeperReconfigStepData{Action: env.ZookeeperStepUpdate, MemberInfo: member})
+		default:
+			growSteps = append(growSteps, e.ZookeeperReconfigStepData{Action: env.ZookeeperStepUpdate, MemberInfo: member})
+		}
+	}
+	for _, member := range current.MemberInfos {
+		if _, exists := desiredMembers[member.ID]; !exists {
+			shrinkSteps = append(shrinkSteps, e.ZookeeperReconfigStepData{Action: env.ZookeeperStepRemove, MemberInfo: member})
+		}
+	}
+
+	//aynı grup içerisindeki adımlar id sırasına göre uygulanır.
+	for _, steps := range [][]e.ZookeeperReconfigStepData{growSteps, shrinkSteps} {
+		sort.SliceStable(steps, func(i, j int) bool { return steps[i].MemberInfo.ID < steps[j].MemberInfo.ID })
+	}
+
+	members := currentMembers
+	steps := append(growSteps, shrinkSteps...)
+	for i := range steps {
+		steps[i].VotersBefore = countVotersMap(members)
+		members = applyStep(members, steps[i])
+		steps[i].VotersAfter = countVotersMap(members)
+
+		if steps[i].VotersAfter < 1 {
+			return nil, env.GetFuncError(env.UnsafeZookeeperReconfig, nil,
+				fmt.Sprintf("step %d leaves the ensemble without participants", i+1))
+		}
+		if diff := steps[i].VotersAfter - steps[i].VotersBefore; diff > 1 || diff < -1 {
+			return nil, env.GetFuncError(env.UnsafeZookeeperReconfig, nil,
+				fmt.Sprintf("step %d changes more than one voter", i+1))
+		}
+	}
+	return steps, nil
+}
+
+func applyStep(members map[int32]e.ZookeeperMemberData, step e.ZookeeperReconfigStepData) map[int32]e.ZookeeperMemberData {
+	next := make(map[int32]e.ZookeeperMemberData, len(members)+1)
+	for id, member := range members {
+		next[id] = member
+	}
+	if step.Action == env.ZookeeperStepRemove {
+		delete(next, step.MemberInfo.ID)
+	} else {
+		next[step.MemberInfo.ID] = step.MemberInfo
+	}
+	return next
+}
+
+func countVotersMap(members map[int32]e.ZookeeperMemberData) int {
+	voters := 0
+	for _, member := range members {
+		if member.Role == env.ZookeeperRoleParticipant {
+			voters++
+		}
+	}
+	return voters
+}
+
+/*
+- adım uygulanmadan önce çalışan ensemble durumu kontrol edilir.
+- adım sonrası yeni participant listesi içerisinde sağlıklı olan üye sayısı yeni çoğunluğu sağlamak zorundadır.
+- yeni eklenen participant henüz senkron değilse sağlıklı kabul edilmez.
+*/
+func CheckStepQuorum(report e.ZookeeperVerifyReportData, current e.ZookeeperEnsembleData, step e.ZookeeperReconfigStepData) error {
+	healthy := map[int32]bool{}
+	for _, status := range report.MemberStatusInfos {
+		healthy[status.ID] = status.Error == "" &&
+			(status.Mode == env.ZookeeperModeLeader || status.Mode == env.ZookeeperModeFollower || status.Mode == env.ZookeeperModeStandalone)
+	}
+
+	currentMembers := make(map[int32]e.ZookeeperMemberData, len(current.MemberInfos))
+	for _, member := range normalizeEnsemble(current).MemberInfos {
+		currentMembers[member.ID] = member
+	}
+	step.MemberInfo = normalizeMember(step.MemberInfo)
+	next := applyStep(currentMembers, step)
+
+	voters, healthyVoters := 0, 0
+	for id, member := range next {
+		if member.Role != env.ZookeeperRoleParticipant {
+			continue
+		}
+		voters++
+		if healthy[id] {
+			healthyVoters++
+		}
+	}
+
+	if healthyVoters < quorumSize(voters) {
+		return env.GetFuncError(env.UnsafeZookeeperReconfig, nil,
+			fmt.Sprintf("%d healthy voters after %s of server %d, quorum requires %d", healthyVoters, step.Action, step.MemberInfo.ID, quorumSize(voters)))
+	}
+	return nil
+}
+
+// adım zkCli reconfig komutuna çevrilir. Ex: reconfig -remove 3
+func ReconfigCommand(step e.ZookeeperReconfigStepData) string {
+	if step.Action == env.ZookeeperStepRemove {
+		return "reconfig -remove " + strconv.FormatInt(int64(step.MemberInfo.ID), 10)
+	}
+	return "reconfig -add " + FormatMember(step.MemberInfo)
+}
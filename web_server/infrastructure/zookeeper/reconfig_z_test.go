@@ -0,0 +1,112 @@
+package zookeeper
+
+import (
+	"testing"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+)
+
+// her adım participant sayısını en fazla bir değiştirmeli ve ensemble üzerinde participant bırakmalıdır.
+func checkSingleVoterSteps(t *testing.T, steps []e.ZookeeperReconfigStepData) {
+	t.Helper()
+	for i, step := range steps {
+		if diff := step.VotersAfter - step.VotersBefore; diff > 1 || diff < -1 {
+			t.Errorf("step %d %s server %d changes %d voters", i+1, step.Action, step.MemberInfo.ID, diff)
+		}
+		if step.VotersAfter < 1 {
+			t.Errorf("step %d leaves no participants", i+1)
+		}
+	}
+}
+
+func TestPlanEnsembleChangeGrowsOneVoterPerStep(t *testing.T) {
+	steps, err := PlanEnsembleChange(newTestEnsemble(1), newTestEnsemble(1, 2, 3))
+	if err != nil {
+		t.Fatalf("PlanEnsembleChange() error = %v", err)
+	}
+	if len(steps) != 2 {
+		t.Fatalf("steps = %+v, want 2 add steps", steps)
+	}
+	checkSingleVoterSteps(t, steps)
+}
+
+func TestPlanEnsembleChangeReplacesAllMembers(t *testing.T) {
+	steps, err := PlanEnsembleChange(newTestEnsemble(1, 2, 3), newTestEnsemble(4, 5, 6))
+	if err != nil {
+		t.Fatalf("PlanEnsembleChange() error = %v", err)
+	}
+	checkSingleVoterSteps(t, steps)
+
+	//eklemeler çıkarmalardan önce yapılır.
+	for i, step := range steps {
+		wantAction := env.ZookeeperStepAdd
+		if i >= 3 {
+			wantAction = env.ZookeeperStepRemove
+		}
+		if step.Action != wantAction {
+			t.Errorf("step %d action = %s, want %s", i+1, step.Action, wantAction)
+		}
+	}
+}
+
+func TestPlanEnsembleChangeDemotesBeforeRemoving(t *testing.T) {
+	desired := newTestEnsemble(1, 2, 3)
+	desired.MemberInfos[2].Role = env.ZookeeperRoleObserver
+
+	steps, err := PlanEnsembleChange(newTestEnsemble(1, 2, 3), desired)
+	if err != nil {
+		t.Fatalf("PlanEnsembleChange() error = %v", err)
+	}
+	if len(steps) != 1 || steps[0].Action != env.ZookeeperStepUpdate || steps[0].VotersAfter != 2 {
+		t.Fatalf("steps = %+v, want single update to observer", steps)
+	}
+}
+
+func TestPlanEnsembleChangeRejectsEnsembleWithoutParticipants(t *testing.T) {
+	desired := newTestEnsemble(1, 2)
+	for i := range desired.MemberInfos {
+		desired.MemberInfos[i].Role = env.ZookeeperRoleObserver
+	}
+
+	if _, err := PlanEnsembleChange(newTestEnsemble(1, 2), desired); !env.IsFuncError(err, env.InvalidZookeeperEnsemble) {
+		t.Fatalf("PlanEnsembleChange() error = %v, want InvalidZookeeperEnsemble", err)
+	}
+}
+
+func TestCheckStepQuorum(t *testing.T) {
+	current := newTestEnsemble(1, 2, 3)
+	healthyReport := e.ZookeeperVerifyReportData{MemberStatusInfos: []e.ZookeeperMemberStatusData{
+		{ID: 1, Mode: env.ZookeeperModeLeader},
+		{ID: 2, Mode: env.ZookeeperModeFollower},
+		{ID: 3, Mode: env.ZookeeperModeFollower},
+	}}
+	degradedReport := e.ZookeeperVerifyReportData{MemberStatusInfos: []e.ZookeeperMemberStatusData{
+		{ID: 1, Mode: env.ZookeeperModeLeader},
+		{ID: 2, Mode: env.ZookeeperModeFollower},
+		{ID: 3, Error: "connection refused"},
+	}}
+	addStep := e.ZookeeperReconfigStepData{Action: env.ZookeeperStepAdd, MemberInfo: newTestEnsemble(4).MemberInfos[0]}
+	removeStep := e.ZookeeperReconfigStepData{Action: env.ZookeeperStepRemove, MemberInfo: current.MemberInfos[0]}
+
+	tests := []struct {
+		name    string
+		report  e.ZookeeperVerifyReportData
+		step    e.ZookeeperReconfigStepData
+		wantErr bool
+	}{
+		{name: "add with healthy ensemble", report: healthyReport, step: addStep},
+		{name: "remove with healthy ensemble", report: healthyReport, step: removeStep},
+		//4 voters için 3 sağlıklı üye gerekir, yeni üye henüz senkron değil.
+		{name: "add with unreachable member", report: degradedReport, step: addStep, wantErr: true},
+		//2 voters için 2 sağlıklı üye gerekir.
+		{name: "remove healthy member with unreachable member", report: degradedReport, step: removeStep, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckStepQuorum(tt.report, current, tt.step)
+			if tt.wantErr != env.IsFuncError(err, env.UnsafeZookeeperReconfig) || (!tt.wantErr && err != nil) {
+				t.Fatalf("CheckStepQuorum() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
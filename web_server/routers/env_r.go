@@ -0,0 +1,19 @@
+package routers
+
+import (
+	c "web_server/controllers"
+	env "web_server/environments/processors"
+	v "web_server/validations"
+
+	"github.com/gin-gonic/gin"
+)
+
+// dry run, diff ve karantina okuma istekleri store üzerinde değişiklik yapmaz, Read task yetkisi ister. Karantina silme Write task yetkisi ister.
+func EnvRouter(g *gin.RouterGroup, ec *c.EnvController) {
+	g.POST(env.EnvDryRunPath, c.AuthorizeOwner(env.EnvDryRunTask, env.Read), v.CheckEnvDryRun, ec.DryRun)
+	g.GET(env.EnvQuarantinePath, c.AuthorizeOwner(env.EnvQuarantineReadTask, env.Read), ec.ListQuarantine)
+	g.GET(env.EnvQuarantineItemPath, c.AuthorizeOwner(env.EnvQuarantineReadTask, env.Read), ec.GetQuarantine)
+	g.DELETE(env.EnvQuarantineItemPath, c.AuthorizeOwner(env.EnvQuarantinePurgeTask, env.Write), ec.PurgeQuarantine)
+	g.POST(env.EnvDiffPath, c.AuthorizeOwner(env.EnvDiffTask, env.Read), v.CheckEnvMapDiff, ec.DiffEnvMap)
+	g.GET(env.EnvRevisionsPath, c.AuthorizeOwner(env.EnvDiffTask, env.Read), ec.EnvMapRevisions)
+}
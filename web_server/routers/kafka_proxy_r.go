@@ -0,0 +1,19 @@
+package routers
+
+import (
+	c "web_server/controllers"
+	env "web_server/environments/processors"
+	v "web_server/validations"
+
+	"github.com/gin-gonic/gin"
+)
+
+// produce Write, consumer işlemleri Read task yetkisi ister.
+func KafkaProxyRouter(g *gin.RouterGroup, pc *c.KafkaProxyController) {
+	g.POST(env.KafkaProxyRecordsPath, c.AuthorizeOwner(env.KafkaProxyProduceTask, env.Write), v.CheckProduceKafkaRecords, pc.Produce)
+	g.GET(env.KafkaProxyTopicKeysPath, c.AuthorizeOwner(env.KafkaProxyConsumeTask, env.Read), pc.TopicKeys)
+	g.POST(env.KafkaProxyConsumersPath, c.AuthorizeOwner(env.KafkaProxyConsumeTask, env.Read), v.CheckCreateKafkaConsumer, pc.CreateConsumer)
+	g.GET(env.KafkaProxyConsumerRecordsPath, c.AuthorizeOwner(env.KafkaProxyConsumeTask, env.Read), v.CheckPollKafkaConsumer, pc.Poll)
+	g.POST(env.KafkaProxyConsumerOffsetsPath, c.AuthorizeOwner(env.KafkaProxyConsumeTask, env.Read), v.CheckCommitKafkaOffsets, pc.Commit)
+	g.DELETE(env.KafkaProxyConsumerPath, c.AuthorizeOwner(env.KafkaProxyConsumeTask, env.Read), pc.DeleteConsumer)
+}
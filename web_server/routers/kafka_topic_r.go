@@ -0,0 +1,18 @@
+package routers
+
+import (
+	c "web_server/controllers"
+	env "web_server/environments/processors"
+	v "web_server/validations"
+
+	"github.com/gin-gonic/gin"
+)
+
+// create, alter-config, delete ve rotate-key Bridge, describe Read task yetkisi ister.
+func KafkaTopicRouter(g *gin.RouterGroup, tc *c.KafkaTopicController) {
+	g.POST("", c.AuthorizeOwner(env.KafkaTopicCreateTask, env.Bridge), v.CheckCreateKafkaTopic, tc.CreateTopic)
+	g.GET(env.KafkaTopicPath, c.AuthorizeOwner(env.KafkaTopicDescribeTask, env.Read), tc.DescribeTopic)
+	g.PATCH(env.KafkaTopicConfigPath, c.AuthorizeOwner(env.KafkaTopicAlterConfigTask, env.Bridge), v.CheckAlterKafkaTopicConfig, tc.AlterTopicConfig)
+	g.DELETE(env.KafkaTopicPath, c.AuthorizeOwner(env.KafkaTopicDeleteTask, env.Bridge), tc.DeleteTopic)
+	g.POST(env.KafkaTopicKeysPath, c.AuthorizeOwner(env.KafkaTopicRotateKeyTask, env.Bridge), tc.RotateTopicKey)
+}
@@ -1,11 +1,13 @@
 package routers
 
 import (
+	"web_server/environments/processors"
+
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
-func MainRouter(router *gin.Engine) {
+func MainRouter(router *gin.Engine, kafkaTopicController *c.KafkaTopicController, kafkaProxyController *c.KafkaProxyController, envController *c.EnvController) {
 	env := c.GetWebServerEnv()
 	config := cors.DefaultConfig()
 	config.AllowOrigins = env.AllowOrigins
@@ -16,4 +18,7 @@ func MainRouter(router *gin.Engine) {
 
 	router.Use(cors.New(config))
 	DataQueriesRouter(router.Group(e.DataQueriesBasePath))
+	KafkaTopicRouter(router.Group(processors.KafkaTopicsBasePath), kafkaTopicController)
+	KafkaProxyRouter(router.Group(processors.KafkaProxyBasePath), kafkaProxyController)
+	EnvRouter(router.Group(processors.EnvBasePath), envController)
 }
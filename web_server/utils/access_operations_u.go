@@ -56,3 +56,23 @@ func CheckAuthn(ownerAuthn map[string]e.TaskData, referenceAuthn map[string]uint
 	}
 	return nil
 }
+
+// owner PermInfos içerisinde reference yetkiler aranır, yetki bulunursa status bilgisi kontrol edilir.
+func CheckPermInfos(ownerPerms map[string]e.PermissionData, referencePerms map[string]uint8) error {
+	for permKey, permType := range referencePerms {
+		permInfo, ok := ownerPerms[permKey]
+		if !ok || permInfo.PermType&permType == 0 {
+			return env.GetFuncError(env.InvalidTaskAuthn, nil, permKey)
+		}
+
+		if err := CheckDataStatusInfos(&e.CheckDataStatusInfosInput{
+			Status:      permInfo.StatusInfos.Status,
+			ActiveAt:    permInfo.StatusInfos.ActiveAt,
+			ExpiresAt:   permInfo.StatusInfos.ExpiresAt,
+			Description: permInfo.StatusInfos.Description,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
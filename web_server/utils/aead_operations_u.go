@@ -0,0 +1,45 @@
+package utils
+
+import (
+	cryptoRand "crypto/rand"
+	env "web_server/environments/processors"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// xchacha20-poly1305 için rastgele data key üretilir.
+func GenerateDataKey() ([]byte, error) {
+	dataKey := make([]byte, chacha20poly1305.KeySize)
+	if _, err := cryptoRand.Read(dataKey); err != nil {
+		return nil, env.GetFuncError(env.UnexpectedError, err)
+	}
+	return dataKey, nil
+}
+
+// data, data key ile xchacha20-poly1305 kullanılarak şifrelenir. Her çağrıda rastgele 24 byte nonce üretilir.
+func EncryptXChaCha20Poly1305(dataKey []byte, plaintext []byte, additionalData []byte) ([]byte, []byte, error) {
+	aead, err := chacha20poly1305.NewX(dataKey)
+	if err != nil {
+		return nil, nil, env.GetFuncError(env.InvalidSealedData, nil)
+	}
+
+	nonce := make([]byte, chacha20poly1305.NonceSizeX)
+	if _, err := cryptoRand.Read(nonce); err != nil {
+		return nil, nil, env.GetFuncError(env.UnexpectedError, err)
+	}
+	return nonce, aead.Seal(nil, nonce, plaintext, additionalData), nil
+}
+
+// şifreli data ve additional data doğrulanarak açılır.
+func DecryptXChaCha20Poly1305(dataKey []byte, nonce []byte, ciphertext []byte, additionalData []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.NewX(dataKey)
+	if err != nil || len(nonce) != chacha20poly1305.NonceSizeX {
+		return nil, env.GetFuncError(env.InvalidSealedData, nil)
+	}
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, env.GetFuncError(env.InvalidSealedData, nil)
+	}
+	return plaintext, nil
+}
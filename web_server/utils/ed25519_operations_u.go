@@ -2,6 +2,8 @@ package utils
 
 import (
 	"crypto/ed25519"
+	"os"
+	a "web_server/domain/abstractions"
 	e "web_server/domain/entities"
 	env "web_server/environments/processors"
 )
@@ -13,3 +15,75 @@ func VerifySignED25519(input e.VerifySignED25519Input) error {
 	}
 	return nil
 }
+
+// Ed25519Signer, key file üzerinden yüklenen ed25519 private key ile raw data imzalar.
+type Ed25519Signer struct {
+	signedBy      string
+	privKey       ed25519.PrivateKey
+	x25519PubKey  []byte
+	x25519PrivKey []byte
+}
+
+// derleme zamanı signer interface check
+var _ a.ISigner = (*Ed25519Signer)(nil)
+var _ a.ISealedKeyOpener = (*Ed25519Signer)(nil)
+
+// signer ile birlikte mühürlenmiş data açmak için kullanılan x25519 key çifti türetilir.
+func newEd25519Signer(privKey ed25519.PrivateKey, signedBy string) (*Ed25519Signer, error) {
+	x25519PrivKey, err := Ed25519PrivKeyToX25519(privKey)
+	if err != nil {
+		return nil, err
+	}
+	x25519PubKey, err := Ed25519PubKeyToX25519(privKey.Public().(ed25519.PublicKey))
+	if err != nil {
+		return nil, err
+	}
+	return &Ed25519Signer{signedBy: signedBy, privKey: privKey, x25519PubKey: x25519PubKey, x25519PrivKey: x25519PrivKey}, nil
+}
+
+/*
+- key file içerisinde 32 byte seed ya da 64 byte private key barınır.
+- signedBy imza bilgisine yazılır, doğrulama tarafında hangi pub key kullanılacağını belirtir.
+*/
+func NewEd25519SignerFromFile(keyFilePath string, signedBy string) (*Ed25519Signer, error) {
+	keyData, err := os.ReadFile(keyFilePath)
+	if err != nil {
+		switch {
+		case os.IsNotExist(err):
+			return nil, env.GetFuncError(env.FileNotFound, nil, keyFilePath)
+		case os.IsPermission(err):
+			return nil, env.GetFuncError(env.PermissionDenied, nil, keyFilePath)
+		default:
+			return nil, env.GetFuncError(env.UnexpectedError, err)
+		}
+	}
+
+	switch len(keyData) {
+	case ed25519.SeedSize:
+		return newEd25519Signer(ed25519.NewKeyFromSeed(keyData), signedBy)
+	case ed25519.PrivateKeySize:
+		return newEd25519Signer(ed25519.PrivateKey(keyData), signedBy)
+	default:
+		return nil, env.GetFuncError(env.InvalidSignerKey, nil, keyFilePath)
+	}
+}
+
+func (s *Ed25519Signer) IFSign(data []byte) (e.SignatureData, error) {
+	return e.SignatureData{
+		SignedBy:  s.signedBy,
+		Signature: ed25519.Sign(s.privKey, data),
+	}, nil
+}
+
+func (s *Ed25519Signer) IFPubKey() []byte {
+	return s.privKey.Public().(ed25519.PublicKey)
+}
+
+func (s *Ed25519Signer) IFX25519PubKey() []byte {
+	return s.x25519PubKey
+}
+
+// SealToX25519 ile signer x25519 pub key üzerine mühürlenmiş data açılır.
+func (s *Ed25519Signer) IFOpenSealed(sealed []byte) ([]byte, error) {
+	return OpenX25519Sealed(s.x25519PubKey, s.x25519PrivKey, sealed)
+}
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"maps"
+	"strconv"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// secret ciphertext başka bir env map, key ya da key version altına taşınamaz.
+func envSecretAdditionalData(envMapKey string, key string, keyVersion uint32) []byte {
+	return []byte(envMapKey + "/" + key + "/" + strconv.FormatUint(uint64(keyVersion), 10))
+}
+
+// value canonical cbor formatında data key ile şifrelenir ve EnvData.Value olarak kullanılacak cbor(EnvSecretData) döner.
+func SealEnvSecret(dataKey []byte, keyVersion uint32, envMapKey string, key string, value any) ([]byte, error) {
+	plaintext, err := MarshalCanonicalCBOR(value)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(plaintext)
+
+	nonce, ciphertext, err := EncryptXChaCha20Poly1305(dataKey, plaintext, envSecretAdditionalData(envMapKey, key, keyVersion))
+	if err != nil {
+		return nil, err
+	}
+	return MarshalCanonicalCBOR(e.EnvSecretData{KeyVersion: keyVersion, Nonce: nonce, Ciphertext: ciphertext})
+}
+
+// secret kind olmayan env data kabul edilmez.
+func DecodeEnvSecret(key string, envData e.EnvData[[]byte]) (e.EnvSecretData, error) {
+	if envData.Kind != env.EnvValueKindSecret {
+		return e.EnvSecretData{}, env.GetFuncError(env.EnvSecretAccessDenied, nil, key)
+	}
+	secret := e.EnvSecretData{}
+	if err := cbor.Unmarshal(envData.Value, &secret); err != nil {
+		return e.EnvSecretData{}, env.GetFuncError(env.InvalidMapValue, err, key)
+	}
+	return secret, nil
+}
+
+// secret data key ile açılır, plaintext cbor formatındadır. Çağıran kullandıktan sonra plaintext temizlemelidir.
+func OpenEnvSecret(dataKey []byte, envMapKey string, key string, secret e.EnvSecretData) ([]byte, error) {
+	return DecryptXChaCha20Poly1305(dataKey, secret.Nonce, secret.Ciphertext, envSecretAdditionalData(envMapKey, key, secret.KeyVersion))
+}
+
+/*
+- env map içerisindeki secret values sadece key version bilgisi kalacak şekilde redact edilir. Plain values değişmez.
+- secret bulunmayan env map kopyalanmadan döner. Dışa aktarım ve api responses için kullanılır.
+*/
+func RedactEnvSecrets(envMap e.EnvMapData[string, e.EnvData[[]byte]]) (e.EnvMapData[string, e.EnvData[[]byte]], error) {
+	redacted := envMap
+	cloned := false
+	for key, envData := range envMap.EnvInfos {
+		if envData.Kind != env.EnvValueKindSecret {
+			continue
+		}
+		if !cloned {
+			redacted.EnvInfos = maps.Clone(envMap.EnvInfos)
+			cloned = true
+		}
+
+		secret := e.EnvSecretData{}
+		if err := cbor.Unmarshal(envData.Value, &secret); err != nil {
+			return envMap, env.GetFuncError(env.InvalidMapValue, err, key)
+		}
+		redactedValue, err := MarshalCanonicalCBOR(e.EnvSecretData{KeyVersion: secret.KeyVersion})
+		if err != nil {
+			return envMap, err
+		}
+		envData.Value = redactedValue
+		redacted.EnvInfos[key] = envData
+	}
+	return redacted, nil
+}
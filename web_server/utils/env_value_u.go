@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"reflect"
+	"sync"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// çözülen env value cache anahtarı. Aynı key farklı türlere çözülebilir.
+type envValueCacheKey struct {
+	envMapKey string
+	key       string
+	valueType reflect.Type
+}
+
+// env map revision değişmediği sürece çözülen değer tekrar kullanılır.
+type envValueCacheData struct {
+	revision uint64
+	value    any
+}
+
+var envValueCache sync.Map // map[envValueCacheKey]envValueCacheData
+
+/*
+- env map içerisindeki EnvData[[]byte] değeri status kontrolünden sonra cbor olarak T türüne çevrilir.
+- status zamana bağlı olduğu için her okumada kontrol edilir, sadece çözülen değer cache edilir.
+- cache env map revision ile tutulur, env map güncellendiğinde değer tekrar çözülür.
+- map, slice ve pointer içeren türler çağıranlar arasında paylaşılmaması için cache edilmez.
+- secret kind values bu fonksiyon ile okunamaz.
+*/
+func GetEnvValue[T any](envMapKey string, key string) (T, error) {
+	var value T
+	envData, revision, err := env.GetEnvWithRevision[string, e.EnvData[[]byte]](envMapKey, key)
+	if err != nil {
+		return value, err
+	}
+
+	if err := CheckDataStatusInfos(&e.CheckDataStatusInfosInput{
+		Status:      envData.StatusInfo.Status,
+		ActiveAt:    envData.StatusInfo.ActiveAt,
+		ExpiresAt:   envData.StatusInfo.ExpiresAt,
+		Description: envData.StatusInfo.Description,
+	}); err != nil {
+		return value, env.GetFuncError(env.InvalidMapValue, err, key)
+	}
+
+	//secret values şifreli olarak tutulur, sadece secret accessor ile açılabilir.
+	if envData.Kind == env.EnvValueKindSecret {
+		return value, env.GetFuncError(env.EnvSecretAccessDenied, nil, key)
+	}
+
+	valueType := reflect.TypeOf((*T)(nil)).Elem()
+	cacheable := isImmutableType(valueType)
+	cacheKey := envValueCacheKey{envMapKey: envMapKey, key: key, valueType: valueType}
+	if cacheable {
+		if cached, ok := envValueCache.Load(cacheKey); ok && cached.(envValueCacheData).revision == revision {
+			return cached.(envValueCacheData).value.(T), nil
+		}
+	}
+
+	if err := cbor.Unmarshal(envData.Value, &value); err != nil {
+		return value, env.GetFuncError(env.InvalidMapValue, err, key)
+	}
+	if cacheable {
+		envValueCache.Store(cacheKey, envValueCacheData{revision: revision, value: value})
+	}
+	return value, nil
+}
+
+// açılış sırasında zorunlu env değerleri için kullanılır, hata durumunda panic oluşturur.
+func MustGetEnvValue[T any](envMapKey string, key string) T {
+	value, err := GetEnvValue[T](envMapKey, key)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// env map ya da key tanımlı değilse defaultValue döner. Status ve decode hataları döner.
+func GetEnvValueOr[T any](envMapKey string, key string, defaultValue T) (T, error) {
+	value, err := GetEnvValue[T](envMapKey, key)
+	if env.IsFuncError(err, env.EnvKeyNotFound) || env.IsFuncError(err, env.EnvMapKeyNotFound) {
+		return defaultValue, nil
+	}
+	return value, err
+}
+
+// tür map, slice, pointer, interface, chan ya da func içermiyorsa paylaşılabilir kabul edilir.
+func isImmutableType(valueType reflect.Type) bool {
+	switch valueType.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Pointer, reflect.Interface, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return false
+	case reflect.Array:
+		return isImmutableType(valueType.Elem())
+	case reflect.Struct:
+		for i := 0; i < valueType.NumField(); i++ {
+			if !isImmutableType(valueType.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	default:
+		return true
+	}
+}
@@ -0,0 +1,150 @@
+package utils
+
+import (
+	cryptoRand "crypto/rand"
+	"os"
+	"path/filepath"
+	a "web_server/domain/abstractions"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// data önce aynı dizindeki geçici file yazılır ve rename edilir. Yarım kalan yazma işlemi hedef file bozmaz.
+func WriteFileAtomic(filePath string, data []byte, perm os.FileMode) error {
+	tmpFile, err := os.CreateTemp(filepath.Dir(filePath), "."+filepath.Base(filePath)+".tmp-*")
+	if err != nil {
+		return env.GetFuncError(env.UnexpectedError, err)
+	}
+	tmpPath := tmpFile.Name()
+	//herhangi bir hata durumunda geçici file temizlenir.
+	defer os.Remove(tmpPath)
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return env.GetFuncError(env.UnexpectedError, err)
+	}
+
+	if err := tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		return env.GetFuncError(env.UnexpectedError, err)
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		return env.GetFuncError(env.UnexpectedError, err)
+	}
+
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		return env.GetFuncError(env.UnexpectedError, err)
+	}
+
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		return env.GetFuncError(env.UnexpectedError, err)
+	}
+	return nil
+}
+
+// file içeriği rastgele byte ile ezilir, diske yazdırılır ve sonrasında silinir.
+func SecureRemoveFile(filePath string) error {
+	file, err := os.OpenFile(filePath, os.O_WRONLY, 0)
+	if err != nil {
+		switch {
+		case os.IsNotExist(err):
+			return env.GetFuncError(env.FileNotFound, nil, filePath)
+		case os.IsPermission(err):
+			return env.GetFuncError(env.PermissionDenied, nil, filePath)
+		default:
+			return env.GetFuncError(env.UnexpectedError, err)
+		}
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return env.GetFuncError(env.UnexpectedError, err)
+	}
+
+	buf := make([]byte, 32*1024)
+	for remaining := info.Size(); remaining > 0; {
+		chunk := buf
+		if remaining < int64(len(chunk)) {
+			chunk = buf[:remaining]
+		}
+
+		if _, err := cryptoRand.Read(chunk); err != nil {
+			file.Close()
+			return env.GetFuncError(env.UnexpectedError, err)
+		}
+
+		n, err := file.Write(chunk)
+		if err != nil {
+			file.Close()
+			return env.GetFuncError(env.UnexpectedError, err)
+		}
+		remaining -= int64(n)
+	}
+
+	//ezilen içerik silinmeden önce diske yazdırılır.
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return env.GetFuncError(env.UnexpectedError, err)
+	}
+
+	if err := file.Close(); err != nil {
+		return env.GetFuncError(env.UnexpectedError, err)
+	}
+
+	if err := os.Remove(filePath); err != nil {
+		return env.GetFuncError(env.UnexpectedError, err)
+	}
+	return nil
+}
+
+/*
+- data imzalanır, önce file sonra imza bilgisi <file>.sig olarak yazılır.
+- imza raw file içeriği üzerinden üretilir, file üzerindeki herhangi bir değişiklik imzayı geçersiz kılar.
+*/
+func WriteSignedFile(filePath string, data []byte, perm os.FileMode, signer a.ISigner) error {
+	signatureInfos, err := signer.IFSign(data)
+	if err != nil {
+		return err
+	}
+
+	encodedSignature, err := cbor.Marshal(signatureInfos)
+	if err != nil {
+		return env.GetFuncError(env.UnexpectedError, err)
+	}
+
+	if err := WriteFileAtomic(filePath, data, perm); err != nil {
+		return err
+	}
+	return WriteFileAtomic(filePath+env.Sig, encodedSignature, perm)
+}
+
+// file ve yanındaki <file>.sig okunarak imza verilen pub key ile doğrulanır.
+func VerifySignedFile(filePath string, pubKey []byte) ([]byte, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, env.GetFuncError(env.FileNotFound, nil, filePath)
+	}
+
+	encodedSignature, err := os.ReadFile(filePath + env.Sig)
+	if err != nil {
+		return nil, env.GetFuncError(env.FileNotFound, nil, filePath+env.Sig)
+	}
+
+	signatureInfos := e.SignatureData{}
+	if err := cbor.Unmarshal(encodedSignature, &signatureInfos); err != nil {
+		return nil, env.GetFuncError(env.InvalidSignatureComponents, nil)
+	}
+
+	if err := VerifySignED25519(e.VerifySignED25519Input{
+		PublicKey: pubKey,
+		Signed:    signatureInfos.Signature,
+		Data:      data,
+	}); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
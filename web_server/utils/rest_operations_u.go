@@ -0,0 +1,116 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"mime"
+	"slices"
+	"strconv"
+	"strings"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// Content-Type bilgisine göre request body json ya da cbor olarak okunur. Content-Type boş ise json kabul edilir.
+func DecodeRequestBody(contentType string, body []byte, data any) error {
+	mediaType := env.ContentTypeJSON
+	if contentType != "" {
+		parsedType, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			return env.GetFuncError(env.InvalidRequestBody, err)
+		}
+		mediaType = parsedType
+	}
+
+	switch mediaType {
+	case env.ContentTypeJSON:
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(data); err != nil {
+			return env.GetFuncError(env.InvalidRequestBody, err)
+		}
+	case env.ContentTypeCBOR:
+		if err := cbor.Unmarshal(body, data); err != nil {
+			return env.GetFuncError(env.InvalidRequestBody, err)
+		}
+	default:
+		return env.GetFuncError(env.InvalidRequestBody, errors.New("unsupported content type "+mediaType))
+	}
+	return nil
+}
+
+// Accept header application/cbor içeriyorsa cbor, diğer durumlarda json döner.
+func EncodeResponseBody(accept string, data any) (string, []byte, error) {
+	if strings.Contains(accept, env.ContentTypeCBOR) {
+		encodedData, err := cbor.Marshal(data)
+		if err != nil {
+			return "", nil, env.GetFuncError(env.UnexpectedError, err)
+		}
+		return env.ContentTypeCBOR, encodedData, nil
+	}
+
+	encodedData, err := json.Marshal(data)
+	if err != nil {
+		return "", nil, env.GetFuncError(env.UnexpectedError, err)
+	}
+	return env.ContentTypeJSON, encodedData, nil
+}
+
+/*
+- Accept-Language header içerisinden en yüksek q değerine sahip desteklenen locale seçilir. Ex: tr-TR,tr;q=0.9,en;q=0.8
+- bölge bilgisi içeren tag desteklenmiyorsa dil bilgisi ile eşleştirilir (tr-TR => tr). Eşleşme yoksa varsayılan locale döner.
+*/
+func ResponseLocale(acceptLanguage string) string {
+	supported := env.FuncMessageLocales()
+	locale, bestQuality := env.DefaultLocale, 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if qValue, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsedQuality, err := strconv.ParseFloat(qValue, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsedQuality
+		}
+		if quality <= bestQuality {
+			continue
+		}
+
+		language, _, _ := strings.Cut(tag, "-")
+		for _, candidate := range []string{tag, language} {
+			if slices.Contains(supported, candidate) {
+				locale, bestQuality = candidate, quality
+				break
+			}
+		}
+	}
+	return locale
+}
+
+// owner access header base64(cbor(WhitelistAccessData)) formatındadır.
+func DecodeOwnerAccessHeader(header string) (e.WhitelistAccessData, error) {
+	accessData := e.WhitelistAccessData{}
+	if header == "" {
+		return accessData, env.GetFuncError(env.MissingOwnerAccess, nil)
+	}
+
+	encodedData, err := base64.StdEncoding.DecodeString(header)
+	if err != nil {
+		return accessData, env.GetFuncError(env.MissingOwnerAccess, nil)
+	}
+
+	if err := cbor.Unmarshal(encodedData, &accessData); err != nil || accessData.AccessKeyInfos.WhitelistKey == "" {
+		return accessData, env.GetFuncError(env.MissingOwnerAccess, nil)
+	}
+	return accessData, nil
+}
@@ -0,0 +1,43 @@
+package utils
+
+import (
+	cryptoRand "crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// kriptografik rastgele byte üretilir.
+func GenerateRandomBytes(length int) ([]byte, error) {
+	data := make([]byte, length)
+	if _, err := cryptoRand.Read(data); err != nil {
+		return nil, env.GetFuncError(env.UnexpectedError, err)
+	}
+	return data, nil
+}
+
+/*
+- rastgele parola ve salt üretilir, SCRAM-SHA-512 salted password (RFC 5802 Hi fonksiyonu => PBKDF2-HMAC-SHA512) hesaplanır.
+- broker sadece salt ve salted password bilgisini alır, parola sadece owner için mühürlenir.
+*/
+func GenerateScramSHA512Credential(iterations int32) (password []byte, credential e.KafkaScramSecretData, err error) {
+	randomPassword, err := GenerateRandomBytes(env.KafkaScramPasswordLength)
+	if err != nil {
+		return nil, e.KafkaScramSecretData{}, err
+	}
+	password = []byte(base64.RawURLEncoding.EncodeToString(randomPassword))
+
+	salt, err := GenerateRandomBytes(env.KafkaScramSaltLength)
+	if err != nil {
+		return nil, e.KafkaScramSecretData{}, err
+	}
+
+	return password, e.KafkaScramSecretData{
+		Iterations:     iterations,
+		Salt:           salt,
+		SaltedPassword: pbkdf2.Key(password, salt, int(iterations), sha512.Size, sha512.New),
+	}, nil
+}
@@ -191,6 +191,19 @@ func StringCIDv1Compare(referenceCID []byte, externalCID string) error {
 	return nil
 }
 
+// binary cid bilgisi string (multibase) formatına çevrilir.
+func CIDv1BytesToString(cidBytes []byte) (string, error) {
+	if err := IsValidCID(cidBytes); err != nil {
+		return "", err
+	}
+
+	parsedCID, err := cid.Cast(cidBytes)
+	if err != nil {
+		return "", env.GetFuncError(env.InvalidCID, err)
+	}
+	return parsedCID.String(), nil
+}
+
 func GenerateHashFromKey(data []byte, hashType uint8) ([]byte, error) {
 	switch hashType {
 	case env.HashTypeSHA3_256:
@@ -282,3 +295,14 @@ func GenerateRandStringKeyRandInput() (minLength, maxLength, specialCharCount in
 }
 
 func GenerateAccessToken()
+
+// map keys sıralanarak her seferinde aynı byte dizisini üreten cbor encoder. Hash ve cid üretiminde kullanılır.
+var canonicalEncMode, _ = cbor.CoreDetEncOptions().EncMode()
+
+func MarshalCanonicalCBOR(data any) ([]byte, error) {
+	encodedData, err := canonicalEncMode.Marshal(data)
+	if err != nil {
+		return nil, env.GetFuncError(env.UnexpectedError, err)
+	}
+	return encodedData, nil
+}
@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	cryptoRand "crypto/rand"
+	"crypto/sha512"
+	"math/big"
+	env "web_server/environments/processors"
+
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// curve25519 asal sayısı: 2^255 - 19
+var curve25519P = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+
+/*
+ed25519 pub key (edwards y koordinatı) x25519 pub key (montgomery u koordinatı) formatına çevrilir.
+u = (1 + y) / (1 - y) mod p
+*/
+func Ed25519PubKeyToX25519(pubKey []byte) ([]byte, error) {
+	if len(pubKey) != ed25519.PublicKeySize {
+		return nil, env.GetFuncError(env.InvalidX25519Key, nil, len(pubKey))
+	}
+
+	//little-endian y koordinatı, en üst bit x işaret biti olduğu için temizlenir.
+	yBytes := make([]byte, ed25519.PublicKeySize)
+	for i := range pubKey {
+		yBytes[ed25519.PublicKeySize-1-i] = pubKey[i]
+	}
+	yBytes[0] &= 0x7f
+	y := new(big.Int).SetBytes(yBytes)
+
+	one := big.NewInt(1)
+	denominator := new(big.Int).Sub(one, y)
+	denominator.Mod(denominator, curve25519P)
+	if denominator.Sign() == 0 {
+		return nil, env.GetFuncError(env.InvalidX25519Key, nil, len(pubKey))
+	}
+
+	numerator := new(big.Int).Add(one, y)
+	inverse := new(big.Int).ModInverse(denominator, curve25519P)
+	if inverse == nil {
+		return nil, env.GetFuncError(env.InvalidX25519Key, nil, len(pubKey))
+	}
+	u := numerator.Mul(numerator, inverse)
+	u.Mod(u, curve25519P)
+
+	uBytes := u.FillBytes(make([]byte, curve25519.PointSize))
+	x25519PubKey := make([]byte, curve25519.PointSize)
+	for i := range uBytes {
+		x25519PubKey[curve25519.PointSize-1-i] = uBytes[i]
+	}
+	return x25519PubKey, nil
+}
+
+// ed25519 private key seed bilgisinden x25519 private key üretilir (RFC 8032 ile aynı clamp işlemi).
+func Ed25519PrivKeyToX25519(privKey ed25519.PrivateKey) ([]byte, error) {
+	if len(privKey) != ed25519.PrivateKeySize {
+		return nil, env.GetFuncError(env.InvalidX25519Key, nil, len(privKey))
+	}
+
+	digest := sha512.Sum512(privKey.Seed())
+	x25519PrivKey := make([]byte, curve25519.ScalarSize)
+	copy(x25519PrivKey, digest[:curve25519.ScalarSize])
+	x25519PrivKey[0] &= 248
+	x25519PrivKey[31] &= 127
+	x25519PrivKey[31] |= 64
+	return x25519PrivKey, nil
+}
+
+// data sadece alıcının x25519 private key ile açılabilecek şekilde mühürlenir (anonymous sealed box).
+func SealToX25519(recipientPubKey []byte, data []byte) ([]byte, error) {
+	if len(recipientPubKey) != curve25519.PointSize {
+		return nil, env.GetFuncError(env.InvalidX25519Key, nil, len(recipientPubKey))
+	}
+
+	var pubKey [32]byte
+	copy(pubKey[:], recipientPubKey)
+	sealed, err := box.SealAnonymous(nil, data, &pubKey, cryptoRand.Reader)
+	if err != nil {
+		return nil, env.GetFuncError(env.UnexpectedError, err)
+	}
+	return sealed, nil
+}
+
+// SealToX25519 ile mühürlenen data alıcının x25519 key çifti ile açılır.
+func OpenX25519Sealed(recipientPubKey, recipientPrivKey []byte, sealed []byte) ([]byte, error) {
+	if len(recipientPubKey) != curve25519.PointSize || len(recipientPrivKey) != curve25519.ScalarSize {
+		return nil, env.GetFuncError(env.InvalidX25519Key, nil, len(recipientPrivKey))
+	}
+
+	var pubKey, privKey [32]byte
+	copy(pubKey[:], recipientPubKey)
+	copy(privKey[:], recipientPrivKey)
+	data, ok := box.OpenAnonymous(nil, sealed, &pubKey, &privKey)
+	if !ok {
+		return nil, env.GetFuncError(env.InvalidSealedData, nil)
+	}
+	return data, nil
+}
@@ -1,10 +1,11 @@
 package validations
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
-	"sync"
 	e "web_server/domain/entities"
 	env "web_server/environments/processors"
 	u "web_server/utils"
@@ -16,16 +17,37 @@ type ValidateEnvInput struct {
 	ParentPath []string
 }
 
+/*
+- Errors path ve code sırasına göre sıralıdır, ErrorMap tam field path => path üzerindeki hatalar.
+- bulunan hata sayısı maxValidationViolations değerini aşarsa fazlası atılır ve Truncated true olur, Total bütün hataların sayısıdır.
+*/
 type ValidateEnvOutput struct {
-	IsValid  bool
-	Errors   []error
-	ErrorMap map[string][]error
+	IsValid   bool
+	Errors    []error
+	ErrorMap  map[string][]error
+	Paths     []string //Errors ile aynı sırada hata path bilgileri
+	Total     int
+	Truncated bool
+	dropped   int //Merge ile eklenen truncated sonuçlarda tutulmayan hata sayısı
 }
 
+// rapor içerisinde tutulacak en fazla hata sayısı
+const maxValidationViolations = 100
+
 var (
 	allowedValueKinds = map[reflect.Kind]bool{
 		reflect.String:  true,
 		reflect.Int:     true,
+		reflect.Int8:    true,
+		reflect.Int16:   true,
+		reflect.Int32:   true,
+		reflect.Int64:   true,
+		reflect.Uint:    true,
+		reflect.Uint8:   true,
+		reflect.Uint16:  true,
+		reflect.Uint32:  true,
+		reflect.Uint64:  true,
+		reflect.Float32: true,
 		reflect.Float64: true,
 		reflect.Bool:    true,
 		reflect.Map:     true,
@@ -50,34 +72,25 @@ func ValidateInternalEnvMapData[K comparable, V any](data e.EnvMapData[K, V]) Va
 	ctx := &ValidationContext{
 		Path: []string{"EnvMapData"},
 	}
-	return validateEnvMapData(reflect.ValueOf(data), ctx)
+	result := newValidateEnvOutput()
+	validateEnvMapData(reflect.ValueOf(data), ctx, &result)
+	return result.finalize()
 }
 
 // Core Validation Logic
 func ValidateEnvDataWithContext(data interface{}, ctx *ValidationContext) ValidateEnvOutput {
 	val := reflect.ValueOf(data)
-	result := ValidateEnvOutput{
-		ErrorMap: make(map[string][]error),
-	}
+	result := newValidateEnvOutput()
 
-	if val.Kind() == reflect.Struct {
-		if hasField(val, "Value") { // Check for EnvData
-			result = validateEnvDataStruct(val, ctx)
-		} else if hasField(val, "EnvInfos") { // Check for EnvMapData
-			result = validateEnvMapData(val, ctx)
-		} else {
-			if err := deepValidate(val, ctx); err != nil {
-				result.appendError(err, ctx)
-			}
-		}
-	} else {
-		if err := deepValidate(val, ctx); err != nil {
-			result.appendError(err, ctx)
-		}
+	switch {
+	case val.Kind() == reflect.Struct && hasField(val, "Value"): // Check for EnvData
+		validateEnvDataStruct(val, ctx, &result)
+	case val.Kind() == reflect.Struct && hasField(val, "EnvInfos"): // Check for EnvMapData
+		validateEnvMapData(val, ctx, &result)
+	default:
+		deepValidate(val, ctx, &result)
 	}
-
-	result.IsValid = len(result.Errors) == 0
-	return result
+	return result.finalize()
 }
 
 // Helper to check if a struct has a specific field
@@ -89,48 +102,32 @@ func hasField(val reflect.Value, fieldName string) bool {
 	return ok
 }
 
-// ... (keep the rest of the functions as before, but update any references to envMapDataType)
+// map keys yazdırılan değerlerine göre sıralanır, doğrulama sırası her çalıştırmada aynıdır.
+func sortedMapKeys(val reflect.Value) []reflect.Value {
+	keys := val.MapKeys()
+	sort.SliceStable(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+	return keys
+}
 
-func validateEnvInfosMap(val reflect.Value, ctx *ValidationContext) error {
+func validateEnvInfosMap(val reflect.Value, ctx *ValidationContext, result *ValidateEnvOutput) {
 	if val.Kind() != reflect.Map {
-		return env.GetFuncError(env.InvalidMapType, nil, ctx.Path)
+		result.appendError(env.GetFuncError(env.InvalidMapType, nil, formatPath(ctx.Path)), ctx)
+		return
 	}
 
-	var wg sync.WaitGroup
-	errChan := make(chan error, val.Len())
-	mapKeys := val.MapKeys()
-
-	for _, key := range mapKeys {
-		wg.Add(1)
-		go func(k reflect.Value) {
-			defer wg.Done()
+	for _, key := range sortedMapKeys(val) {
+		keyCtx := withContext(ctx, fmt.Sprintf("[%v]", key.Interface()))
+		mapVal := val.MapIndex(key)
 
-			keyCtx := withContext(ctx, fmt.Sprintf("[%v]", k.Interface()))
-			mapVal := val.MapIndex(k)
-
-			// Check if map value is EnvData by structure
-			if !isEnvData(mapVal) {
-				errChan <- env.GetFuncError(env.InvalidMapValueType, nil, keyCtx.Path)
-				return
-			}
-
-			if err := deepValidate(mapVal, keyCtx); err != nil {
-				errChan <- err
-			}
-		}(key)
-	}
-
-	go func() {
-		wg.Wait()
-		close(errChan)
-	}()
-
-	for err := range errChan {
-		if err != nil {
-			return err
+		// Check if map value is EnvData by structure
+		if !isEnvData(mapVal) {
+			result.appendError(env.GetFuncError(env.InvalidMapValueType, nil, formatPath(keyCtx.Path)), keyCtx)
+			continue
 		}
+		validateEnvDataStruct(mapVal, keyCtx, result)
 	}
-	return nil
 }
 
 // Check if a value is EnvData by field presence
@@ -138,127 +135,93 @@ func isEnvData(val reflect.Value) bool {
 	return hasField(val, "Value") && hasField(val, "StatusInfo")
 }
 
-func validateEnvDataStruct(val reflect.Value, ctx *ValidationContext) ValidateEnvOutput {
-	result := ValidateEnvOutput{ErrorMap: make(map[string][]error)}
-
+func validateEnvDataStruct(val reflect.Value, ctx *ValidationContext, result *ValidateEnvOutput) {
 	// Validate Value field
-	valueField := val.FieldByName("Value")
-	valueCtx := withContext(ctx, "Value")
-	if err := validateValueField(valueField, valueCtx); err != nil {
-		result.appendError(err, valueCtx)
-	}
+	validateValueField(val.FieldByName("Value"), withContext(ctx, "Value"), result)
 
 	// Validate StatusInfo
-	statusCtx := withContext(ctx, "StatusInfo")
-	if err := validateStatusInfo(val, statusCtx); err != nil {
-		result.appendError(err, statusCtx)
-	}
-
-	result.IsValid = len(result.Errors) == 0
-	return result
+	validateStatusInfo(val, "StatusInfo", withContext(ctx, "StatusInfo"), result)
 }
 
-func validateEnvMapData(val reflect.Value, ctx *ValidationContext) ValidateEnvOutput {
-	result := ValidateEnvOutput{ErrorMap: make(map[string][]error)}
+func validateEnvMapData(val reflect.Value, ctx *ValidationContext, result *ValidateEnvOutput) {
+	validateEnvInfosMap(val.FieldByName("EnvInfos"), withContext(ctx, "EnvInfos"), result)
 
-	envInfos := val.FieldByName("EnvInfos")
-	envInfosCtx := withContext(ctx, "EnvInfos")
-	if err := validateEnvInfosMap(envInfos, envInfosCtx); err != nil {
-		result.appendError(err, envInfosCtx)
-	}
-
-	statusCtx := withContext(ctx, "StatusInfos")
-	if err := validateStatusInfo(val, statusCtx); err != nil {
-		result.appendError(err, statusCtx)
-	}
-
-	result.IsValid = len(result.Errors) == 0
-	return result
+	validateStatusInfo(val, "StatusInfos", withContext(ctx, "StatusInfos"), result)
 }
 
 // Field-specific Validation
-func validateValueField(val reflect.Value, ctx *ValidationContext) error {
+func validateValueField(val reflect.Value, ctx *ValidationContext, result *ValidateEnvOutput) {
+	if val.IsValid() && val.Kind() == reflect.Interface {
+		val = val.Elem()
+	}
 	if !val.IsValid() || (val.Kind() == reflect.Ptr && val.IsNil()) {
-		return env.GetFuncError(env.NilValueNotAllowed, nil, ctx.Path)
+		result.appendError(env.GetFuncError(env.NilValueNotAllowed, nil, formatPath(ctx.Path)), ctx)
+		return
 	}
 
 	if !allowedValueKinds[val.Kind()] {
-		return env.GetFuncError(env.UnSupportedDataType, nil, ctx.Path)
+		result.appendError(env.GetFuncError(env.UnSupportedDataType, nil, formatPath(ctx.Path), val.Kind().String()), ctx)
+		return
 	}
 
-	return deepValidate(val, ctx)
+	deepValidate(val, ctx, result)
 }
 
-func validateStatusInfo(val reflect.Value, ctx *ValidationContext) error {
-	statusField := val.FieldByName("StatusInfo")
+func validateStatusInfo(val reflect.Value, fieldName string, ctx *ValidationContext, result *ValidateEnvOutput) {
+	statusField := val.FieldByName(fieldName)
 	if !statusField.IsValid() {
-		return env.GetFuncError(env.MissingStatusInfo, nil, ctx.Path)
+		result.appendError(env.GetFuncError(env.MissingStatusInfo, nil, formatPath(ctx.Path)), ctx)
+		return
 	}
-	return deepValidate(statusField, ctx)
+	deepValidate(statusField, ctx, result)
 }
 
 // Enhanced Deep Validation
-func deepValidate(val reflect.Value, ctx *ValidationContext) error {
-	if val.Kind() == reflect.Ptr {
+func deepValidate(val reflect.Value, ctx *ValidationContext, result *ValidateEnvOutput) {
+	//nil input ve boş interface değerleri geçersiz reflect.Value olarak gelir.
+	if !val.IsValid() {
+		result.appendError(env.GetFuncError(env.NilValueNotAllowed, nil, formatPath(ctx.Path)), ctx)
+		return
+	}
+	if val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
 		if val.IsNil() {
-			return env.GetFuncError(env.NilValueNotAllowed, nil, ctx.Path)
+			result.appendError(env.GetFuncError(env.NilValueNotAllowed, nil, formatPath(ctx.Path)), ctx)
+			return
 		}
 		val = val.Elem()
 	}
 
 	switch val.Kind() {
 	case reflect.Map:
-		return validateGenericMap(val, ctx)
+		validateGenericMap(val, ctx, result)
 	case reflect.Slice, reflect.Array:
-		return validateSlice(val, ctx)
+		//[]byte tek değer olarak kabul edilir, elemanları ayrı ayrı doğrulanmaz.
+		if val.Type().Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		validateSlice(val, ctx, result)
 	case reflect.Struct:
 		if isEnvData(val) { // Yapısal kontrol kullanılıyor
-			if validationResult := validateEnvDataStruct(val, ctx); len(validationResult.Errors) > 0 {
-				return validationResult.Errors[0]
-			}
-			return nil
+			validateEnvDataStruct(val, ctx, result)
+			return
 		}
-		return validateStruct(val, ctx)
+		validateStruct(val, ctx, result)
 	default:
 		if !allowedValueKinds[val.Kind()] {
-			return env.GetFuncError(env.UnSupportedDataType, nil, ctx.Path)
+			result.appendError(env.GetFuncError(env.UnSupportedDataType, nil, formatPath(ctx.Path), val.Kind().String()), ctx)
 		}
 	}
-	return nil
 }
 
-// Concurrent Map Validation
-func validateGenericMap(val reflect.Value, ctx *ValidationContext) error {
+func validateGenericMap(val reflect.Value, ctx *ValidationContext, result *ValidateEnvOutput) {
 	if val.Type().Key().Kind() == reflect.Uintptr {
-		return env.GetFuncError(env.AllFieldsRequiredWithInvalidKey, nil, ctx.Path)
-	}
-
-	var wg sync.WaitGroup
-	errChan := make(chan error, val.Len())
-
-	for _, key := range val.MapKeys() {
-		wg.Add(1)
-		go func(k reflect.Value) {
-			defer wg.Done()
-
-			newCtx := withContext(ctx, fmt.Sprintf("[%v]", k.Interface()))
-			mapVal := val.MapIndex(k)
-
-			if err := deepValidate(mapVal, newCtx); err != nil {
-				errChan <- env.GetFuncError(env.InvalidMapValue, err, newCtx.Path)
-			}
-		}(key)
+		result.appendError(env.GetFuncError(env.AllFieldsRequiredWithInvalidKey, nil, formatPath(ctx.Path)), ctx)
+		return
 	}
 
-	go func() {
-		wg.Wait()
-		close(errChan)
-	}()
-
-	for err := range errChan {
-		return err
+	for _, key := range sortedMapKeys(val) {
+		deepValidate(val.MapIndex(key), withContext(ctx, fmt.Sprintf("[%v]", key.Interface())), result)
 	}
-	return nil
 }
 
 // Helper Functions
@@ -271,39 +234,143 @@ func withContext(ctx *ValidationContext, fieldName string) *ValidationContext {
 	return newCtx
 }
 
+// path parçaları birleştirilir, index ve map key parçaları önceki parçaya eklenir. Ex: EnvMapData.EnvInfos[key].Value
+func formatPath(path []string) string {
+	var builder strings.Builder
+	for i, part := range path {
+		if i > 0 && !strings.HasPrefix(part, "[") {
+			builder.WriteString(".")
+		}
+		builder.WriteString(part)
+	}
+	return builder.String()
+}
+
+func newValidateEnvOutput() ValidateEnvOutput {
+	return ValidateEnvOutput{ErrorMap: make(map[string][]error)}
+}
+
 func (o *ValidateEnvOutput) appendError(err error, ctx *ValidationContext) {
-	path := strings.Join(ctx.Path, ".")
+	path := formatPath(ctx.Path)
 	o.Errors = append(o.Errors, err)
+	o.Paths = append(o.Paths, path)
 	o.ErrorMap[path] = append(o.ErrorMap[path], err)
 }
 
-func validateSlice(val reflect.Value, ctx *ValidationContext) error {
-	for i := 0; i < val.Len(); i++ {
-		element := val.Index(i)
-		indexCtx := withContext(ctx, fmt.Sprintf("[%d]", i))
-
-		if err := deepValidate(element, indexCtx); err != nil {
-			return env.GetFuncError(env.InvalidSliceElement, err, indexCtx.Path)
+// hatalar path, code ve mesaj sırasına göre sıralanır ve maxValidationViolations ile sınırlandırılır.
+func (o ValidateEnvOutput) finalize() ValidateEnvOutput {
+	indexes := make([]int, len(o.Errors))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		left, right := indexes[i], indexes[j]
+		if o.Paths[left] != o.Paths[right] {
+			return o.Paths[left] < o.Paths[right]
 		}
+		leftCode, rightCode := funcErrorCode(o.Errors[left]), funcErrorCode(o.Errors[right])
+		if leftCode != rightCode {
+			return leftCode < rightCode
+		}
+		return o.Errors[left].Error() < o.Errors[right].Error()
+	})
+
+	result := newValidateEnvOutput()
+	result.Total = len(o.Errors) + o.dropped
+	result.IsValid = result.Total == 0
+	result.Truncated = o.dropped > 0
+	for _, index := range indexes {
+		if len(result.Errors) == maxValidationViolations {
+			result.Truncated = true
+			break
+		}
+		result.Errors = append(result.Errors, o.Errors[index])
+		result.Paths = append(result.Paths, o.Paths[index])
+		result.ErrorMap[o.Paths[index]] = append(result.ErrorMap[o.Paths[index]], o.Errors[index])
 	}
-	return nil
+	return result
+}
+
+func funcErrorCode(err error) int {
+	var funcErr *env.FuncError
+	if errors.As(err, &funcErr) {
+		return funcErr.Code
+	}
+	return env.UnexpectedError
+}
+
+/*
+- doğrulama sonucu imzalama araçları ve api tarafından kullanılabilecek rapor formatına çevrilir.
+- mesajlar locale ile üretilir.
+*/
+func (o ValidateEnvOutput) Report(locale string) e.ValidationReportData {
+	report := e.ValidationReportData{
+		Valid:      o.IsValid,
+		Total:      o.Total,
+		Truncated:  o.Truncated,
+		Violations: make([]e.ValidationViolationData, 0, len(o.Errors)),
+	}
+	for i, err := range o.Errors {
+		response := env.FuncErrorResponse(err, locale)
+		report.Violations = append(report.Violations, e.ValidationViolationData{
+			Path:     o.Paths[i],
+			Code:     response.Code,
+			Name:     response.Name,
+			Severity: response.Severity,
+			Message:  response.Error,
+		})
+	}
+	return report
+}
+
+// dry run gibi farklı kontrolleri tek rapor üzerinde toplayan çağıranlar için boş sonuç oluşturulur.
+func NewValidateEnvOutput() ValidateEnvOutput {
+	return newValidateEnvOutput()
+}
+
+// hata path bilgisi ile eklenir. Ex: AppendError(err, "EnvMapData", "[key]") => EnvMapData[key]
+func (o *ValidateEnvOutput) AppendError(err error, path ...string) {
+	o.appendError(err, &ValidationContext{Path: path})
+}
+
+// başka doğrulama sonucunun hataları eklenir. Sonuç truncated ise tutulmayan hatalar Total içerisinde sayılır.
+func (o *ValidateEnvOutput) Merge(other ValidateEnvOutput) {
+	for i, err := range other.Errors {
+		o.appendError(err, &ValidationContext{Path: []string{other.Paths[i]}})
+	}
+	o.dropped += other.Total - len(other.Errors)
+}
+
+// eklenen hatalar sıralanır ve sınırlandırılır. Report ve Err öncesi çağrılır.
+func (o ValidateEnvOutput) Finalize() ValidateEnvOutput {
+	return o.finalize()
 }
 
-func validateStruct(val reflect.Value, ctx *ValidationContext) error {
+// geçersiz sonuç ilk hatayı sarmalayan tek bir hataya çevrilir. Sonuç geçerli ise nil döner.
+func (o ValidateEnvOutput) Err() error {
+	if o.IsValid {
+		return nil
+	}
+	return env.GetFuncError(env.EnvValidationFailed, o.Errors[0], o.Total, o.Paths[0])
+}
+
+func validateSlice(val reflect.Value, ctx *ValidationContext, result *ValidateEnvOutput) {
+	for i := 0; i < val.Len(); i++ {
+		deepValidate(val.Index(i), withContext(ctx, fmt.Sprintf("[%d]", i)), result)
+	}
+}
+
+func validateStruct(val reflect.Value, ctx *ValidationContext, result *ValidateEnvOutput) {
 	for i := 0; i < val.NumField(); i++ {
 		field := val.Field(i)
 		fieldType := val.Type().Field(i)
-		fieldCtx := withContext(ctx, fieldType.Name)
 
 		if !field.CanInterface() || fieldType.Tag.Get("cbor") == "-" {
 			continue
 		}
 
-		if err := deepValidate(field, fieldCtx); err != nil {
-			return err
-		}
+		deepValidate(field, withContext(ctx, fieldType.Name), result)
 	}
-	return nil
 }
 
 // Bütün dilleri desteklemek adına byte odaklı çalışma
@@ -319,10 +386,10 @@ func ValidateExternalEnvData[V any](data e.EnvData[V]) error {
 	return nil
 }
 
-func ValidateExternalEnvMapData[K comparable, V any](data e.EnvMapData[K, e.EnvData[V]]) error {
-	if data.EnvInfos == nil {
-		return env.GetFuncError(env.InvalidMapValue, nil, nil)
-	}
+// external env map içerisindeki bütün hatalar toplanır. Ex: EnvMapData.EnvInfos[key].Value
+func ValidateExternalEnvMapDataReport[K comparable, V any](data e.EnvMapData[K, e.EnvData[V]]) ValidateEnvOutput {
+	ctx := &ValidationContext{Path: []string{"EnvMapData"}}
+	result := newValidateEnvOutput()
 
 	//alınan new env data status bilgisi kontrol edilir.
 	if err := u.CheckNewDataStatusInfos(&e.CheckDataStatusInfosInput{
@@ -331,21 +398,34 @@ func ValidateExternalEnvMapData[K comparable, V any](data e.EnvMapData[K, e.EnvD
 		ExpiresAt:   data.StatusInfos.ExpiresAt,
 		Description: data.StatusInfos.Description,
 	}); err != nil {
-		return err
+		result.appendError(err, withContext(ctx, "StatusInfos"))
+	}
+
+	envInfosCtx := withContext(ctx, "EnvInfos")
+	if data.EnvInfos == nil {
+		result.appendError(env.GetFuncError(env.InvalidMapValue, nil, formatPath(envInfosCtx.Path)), envInfosCtx)
+		return result.finalize()
 	}
 
 	for key, envData := range data.EnvInfos {
+		keyCtx := withContext(envInfosCtx, fmt.Sprintf("[%v]", key))
+
 		// Eğer `K` string ise boş olamaz
 		var zeroK K
 		if key == zeroK {
-			return env.GetFuncError(env.InvalidMapValueType, nil, key)
+			result.appendError(env.GetFuncError(env.InvalidMapValueType, nil, key), keyCtx)
+			continue
 		}
 
 		// EnvData doğrulaması
 		if err := ValidateExternalEnvData(envData); err != nil {
-			return env.GetFuncError(env.InvalidMapValue, err, key)
+			result.appendError(err, withContext(keyCtx, "Value"))
 		}
 	}
 
-	return nil
+	return result.finalize()
+}
+
+func ValidateExternalEnvMapData[K comparable, V any](data e.EnvMapData[K, e.EnvData[V]]) error {
+	return ValidateExternalEnvMapDataReport(data).Err()
 }
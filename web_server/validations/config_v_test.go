@@ -0,0 +1,54 @@
+package validations
+
+import (
+	"testing"
+	env "web_server/environments/processors"
+)
+
+func TestValidateEnvDataWithContextNilInput(t *testing.T) {
+	output := ValidateEnvDataWithContext(nil, &ValidationContext{Path: []string{"X"}})
+	if output.IsValid {
+		t.Fatal("nil input valid, want violation")
+	}
+	if !env.IsFuncError(output.Errors[0], env.NilValueNotAllowed) || output.Paths[0] != "X" {
+		t.Fatalf("violation = %v at %q, want NilValueNotAllowed at X", output.Errors[0], output.Paths[0])
+	}
+}
+
+func TestValidateEnvDataWithContextUnsupportedKind(t *testing.T) {
+	tests := []struct {
+		name string
+		data any
+	}{
+		{name: "chan", data: make(chan int)},
+		{name: "func", data: func() {}},
+		{name: "map value", data: map[string]any{"key": make(chan int)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := ValidateEnvDataWithContext(tt.data, &ValidationContext{Path: []string{"X"}})
+			if output.IsValid {
+				t.Fatal("unsupported kind valid, want violation")
+			}
+			if !env.IsFuncError(output.Errors[0], env.UnSupportedDataType) {
+				t.Fatalf("violation = %v, want UnSupportedDataType", output.Errors[0])
+			}
+		})
+	}
+}
+
+// struct içerisindeki desteklenmeyen field path ile raporlanır, unexported fields doğrulanmaz.
+func TestValidateEnvDataWithContextStructField(t *testing.T) {
+	type withFields struct {
+		Name    string
+		Channel chan int
+		inner   chan int
+	}
+	output := ValidateEnvDataWithContext(withFields{Name: "name", Channel: make(chan int), inner: make(chan int)}, &ValidationContext{Path: []string{"X"}})
+	if output.Total != 1 {
+		t.Fatalf("violations = %v at %v, want single violation", output.Errors, output.Paths)
+	}
+	if !env.IsFuncError(output.Errors[0], env.UnSupportedDataType) || output.Paths[0] != formatPath([]string{"X", "Channel"}) {
+		t.Fatalf("violation = %v at %q, want UnSupportedDataType at X.Channel", output.Errors[0], output.Paths[0])
+	}
+}
@@ -0,0 +1,311 @@
+package validations
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+var envSchemaTypes = []string{
+	env.EnvSchemaTypeString,
+	env.EnvSchemaTypeInt,
+	env.EnvSchemaTypeBool,
+	env.EnvSchemaTypeDuration,
+	env.EnvSchemaTypeURL,
+	env.EnvSchemaTypeHostPort,
+	env.EnvSchemaTypeEnum,
+	env.EnvSchemaTypeList,
+	env.EnvSchemaTypeMap,
+	env.EnvSchemaTypeSecret,
+}
+
+// schema tanımı doğrulanır. Bilinmeyen tür, derlenemeyen pattern, boş enum, Items içermeyen list ve iç içe secret kabul edilmez.
+func ValidateEnvSchema(schema e.EnvSchemaData) ValidateEnvOutput {
+	result := newValidateEnvOutput()
+	ctx := &ValidationContext{Path: []string{"EnvSchemaData", "Fields"}}
+	for _, key := range sortedKeys(schema.Fields) {
+		validateEnvSchemaField(schema.Fields[key], false, withContext(ctx, "["+key+"]"), &result)
+	}
+	return result.finalize()
+}
+
+func validateEnvSchemaField(field e.EnvSchemaFieldData, nested bool, ctx *ValidationContext, result *ValidateEnvOutput) {
+	invalid := func(reason string) {
+		result.appendError(env.GetFuncError(env.InvalidEnvSchema, nil, formatPath(ctx.Path), reason), ctx)
+	}
+
+	if !slices.Contains(envSchemaTypes, field.Type) {
+		invalid("unknown type " + field.Type)
+		return
+	}
+	//secret value bütün olarak şifrelendiği için list ya da map elemanı secret olamaz.
+	if nested && field.Type == env.EnvSchemaTypeSecret {
+		invalid("secret type only allowed for top level keys")
+		return
+	}
+	if field.Min != nil && field.Max != nil && *field.Min > *field.Max {
+		invalid("min greater than max")
+	}
+	if field.Pattern != "" {
+		if _, err := regexp.Compile(field.Pattern); err != nil {
+			invalid("invalid pattern " + field.Pattern)
+		}
+	}
+
+	switch field.Type {
+	case env.EnvSchemaTypeEnum:
+		if len(field.Enum) == 0 {
+			invalid("enum values required")
+		}
+	case env.EnvSchemaTypeList:
+		if field.Items == nil {
+			invalid("list items required")
+			return
+		}
+		validateEnvSchemaField(*field.Items, true, withContext(ctx, "Items"), result)
+	case env.EnvSchemaTypeMap:
+		for _, key := range sortedKeys(field.Fields) {
+			validateEnvSchemaField(field.Fields[key], true, withContext(withContext(ctx, "Fields"), "["+key+"]"), result)
+		}
+		if field.Values != nil {
+			validateEnvSchemaField(*field.Values, true, withContext(ctx, "Values"), result)
+		}
+	}
+}
+
+/*
+- env map values cbor olarak çözülür ve schema ile doğrulanır. Bütün hatalar path bilgisi ile toplanır. Ex: EnvMapData.EnvInfos[key].Value[host]
+- schema üzerinde tanımlı olmayan keys AllowUnknown false ise hata kabul edilir.
+- secret tanımlı keys sadece secret kind values kabul eder, secret values diğer türler için kabul edilmez.
+*/
+func ValidateEnvMapSchema(schema e.EnvSchemaData, envMap e.EnvMapData[string, e.EnvData[[]byte]]) ValidateEnvOutput {
+	result := newValidateEnvOutput()
+	ctx := &ValidationContext{Path: []string{"EnvMapData", "EnvInfos"}}
+
+	for _, key := range sortedKeys(schema.Fields) {
+		field := schema.Fields[key]
+		keyCtx := withContext(ctx, "["+key+"]")
+		envData, ok := envMap.EnvInfos[key]
+		if !ok {
+			if field.Required {
+				result.appendError(env.GetFuncError(env.EnvSchemaViolation, nil, formatPath(keyCtx.Path), "required key missing"), keyCtx)
+			}
+			continue
+		}
+
+		valueCtx := withContext(keyCtx, "Value")
+
+		//secret values şifreli olduğu için sadece kind kontrol edilir, plaintext doğrulanmaz ve hata mesajlarına eklenmez.
+		isSecret := envData.Kind == env.EnvValueKindSecret
+		if isSecret != (field.Type == env.EnvSchemaTypeSecret) {
+			reason := "secret value not allowed"
+			if !isSecret {
+				reason = "value must be secret"
+			}
+			result.appendError(env.GetFuncError(env.EnvSchemaViolation, nil, formatPath(valueCtx.Path), reason), valueCtx)
+			continue
+		}
+		if isSecret {
+			continue
+		}
+
+		var value any
+		if err := cbor.Unmarshal(envData.Value, &value); err != nil {
+			result.appendError(env.GetFuncError(env.EnvSchemaViolation, err, formatPath(valueCtx.Path), "value is not cbor"), valueCtx)
+			continue
+		}
+		validateEnvSchemaValue(field, value, valueCtx, &result)
+	}
+
+	if !schema.AllowUnknown {
+		for _, key := range sortedKeys(envMap.EnvInfos) {
+			if _, ok := schema.Fields[key]; !ok {
+				keyCtx := withContext(ctx, "["+key+"]")
+				result.appendError(env.GetFuncError(env.EnvSchemaViolation, nil, formatPath(keyCtx.Path), "key not declared in schema"), keyCtx)
+			}
+		}
+	}
+	return result.finalize()
+}
+
+func validateEnvSchemaValue(field e.EnvSchemaFieldData, value any, ctx *ValidationContext, result *ValidateEnvOutput) {
+	violation := func(reason string) {
+		result.appendError(env.GetFuncError(env.EnvSchemaViolation, nil, formatPath(ctx.Path), reason), ctx)
+	}
+	checkRange := func(value int64, unit string) {
+		if field.Min != nil && value < *field.Min {
+			violation(fmt.Sprintf("%s %d less than min %d", unit, value, *field.Min))
+		}
+		if field.Max != nil && value > *field.Max {
+			violation(fmt.Sprintf("%s %d greater than max %d", unit, value, *field.Max))
+		}
+	}
+
+	switch field.Type {
+	case env.EnvSchemaTypeString:
+		text, ok := value.(string)
+		if !ok {
+			violation(fmt.Sprintf("expected string, got %T", value))
+			return
+		}
+		checkRange(int64(len(text)), "length")
+		if field.Pattern != "" {
+			if pattern, err := regexp.Compile(field.Pattern); err != nil || !pattern.MatchString(text) {
+				violation("does not match pattern " + field.Pattern)
+			}
+		}
+	case env.EnvSchemaTypeInt:
+		number, ok := schemaInt(value)
+		if !ok {
+			violation(fmt.Sprintf("expected int, got %T", value))
+			return
+		}
+		checkRange(number, "value")
+	case env.EnvSchemaTypeBool:
+		if _, ok := value.(bool); !ok {
+			violation(fmt.Sprintf("expected bool, got %T", value))
+		}
+	case env.EnvSchemaTypeDuration:
+		text, ok := value.(string)
+		if !ok {
+			violation(fmt.Sprintf("expected duration string, got %T", value))
+			return
+		}
+		duration, err := time.ParseDuration(text)
+		if err != nil {
+			violation("invalid duration " + text)
+			return
+		}
+		checkRange(duration.Milliseconds(), "duration ms")
+	case env.EnvSchemaTypeURL:
+		text, ok := value.(string)
+		if !ok {
+			violation(fmt.Sprintf("expected url string, got %T", value))
+			return
+		}
+		parsedURL, err := url.Parse(text)
+		if err != nil || parsedURL.Scheme == "" || parsedURL.Host == "" {
+			violation("invalid url " + text)
+			return
+		}
+		if len(field.Schemes) > 0 && !slices.Contains(field.Schemes, parsedURL.Scheme) {
+			violation("url scheme not allowed " + parsedURL.Scheme)
+		}
+	case env.EnvSchemaTypeHostPort:
+		text, ok := value.(string)
+		if !ok {
+			violation(fmt.Sprintf("expected host:port string, got %T", value))
+			return
+		}
+		host, port, err := net.SplitHostPort(text)
+		if err != nil || host == "" {
+			violation("invalid host:port " + text)
+			return
+		}
+		if portNumber, err := strconv.Atoi(port); err != nil || portNumber < 1 || portNumber > 65535 {
+			violation("invalid port " + port)
+		}
+	case env.EnvSchemaTypeEnum:
+		text, ok := value.(string)
+		if !ok || !slices.Contains(field.Enum, text) {
+			violation(fmt.Sprintf("value %v not in enum %v", value, field.Enum))
+		}
+	case env.EnvSchemaTypeList:
+		items, ok := value.([]any)
+		if !ok {
+			violation(fmt.Sprintf("expected list, got %T", value))
+			return
+		}
+		checkRange(int64(len(items)), "length")
+		if field.Items == nil {
+			return
+		}
+		for i, item := range items {
+			validateEnvSchemaValue(*field.Items, item, withContext(ctx, fmt.Sprintf("[%d]", i)), result)
+		}
+	case env.EnvSchemaTypeMap:
+		entries, ok := schemaMap(value)
+		if !ok {
+			violation(fmt.Sprintf("expected map with string keys, got %T", value))
+			return
+		}
+		checkRange(int64(len(entries)), "length")
+		for _, key := range sortedKeys(field.Fields) {
+			keyCtx := withContext(ctx, "["+key+"]")
+			entry, ok := entries[key]
+			if !ok {
+				if field.Fields[key].Required {
+					result.appendError(env.GetFuncError(env.EnvSchemaViolation, nil, formatPath(keyCtx.Path), "required key missing"), keyCtx)
+				}
+				continue
+			}
+			validateEnvSchemaValue(field.Fields[key], entry, keyCtx, result)
+		}
+		for _, key := range sortedKeys(entries) {
+			if _, ok := field.Fields[key]; ok {
+				continue
+			}
+			keyCtx := withContext(ctx, "["+key+"]")
+			if field.Values == nil {
+				result.appendError(env.GetFuncError(env.EnvSchemaViolation, nil, formatPath(keyCtx.Path), "key not declared in schema"), keyCtx)
+				continue
+			}
+			validateEnvSchemaValue(*field.Values, entries[key], keyCtx, result)
+		}
+	default:
+		violation("unknown schema type " + field.Type)
+	}
+}
+
+// cbor integer değerleri uint64 ya da int64 olarak çözülür.
+func schemaInt(value any) (int64, bool) {
+	switch number := value.(type) {
+	case int64:
+		return number, true
+	case uint64:
+		if number > 1<<63-1 {
+			return 0, false
+		}
+		return int64(number), true
+	default:
+		return 0, false
+	}
+}
+
+// cbor map değerleri map[any]any olarak çözülür, sadece string keys kabul edilir.
+func schemaMap(value any) (map[string]any, bool) {
+	switch entries := value.(type) {
+	case map[string]any:
+		return entries, true
+	case map[any]any:
+		converted := make(map[string]any, len(entries))
+		for key, entry := range entries {
+			stringKey, ok := key.(string)
+			if !ok {
+				return nil, false
+			}
+			converted[stringKey] = entry
+		}
+		return converted, true
+	default:
+		return nil, false
+	}
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
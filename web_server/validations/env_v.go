@@ -0,0 +1,59 @@
+package validations
+
+import (
+	"errors"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// dry run için file zorunludur. Env map için field, access data için whitelist key zorunludur.
+func CheckEnvDryRun(c *gin.Context) {
+	input, ok := bindRequestBody[e.EnvDryRunInput](c)
+	if !ok {
+		return
+	}
+
+	var err error
+	switch {
+	case len(input.File) == 0:
+		err = errors.New("file required")
+	case input.Kind == env.EnvDryRunKindEnvMap && input.Field == "":
+		err = errors.New("field required")
+	case input.Kind == env.EnvDryRunKindAccessData && input.WhitelistKey == "":
+		err = errors.New("whitelist key required")
+	}
+	if err != nil {
+		abortWithError(c, env.GetFuncError(env.InvalidRequestBody, err))
+		return
+	}
+
+	c.Set(env.CtxRequestInput, input)
+	c.Next()
+}
+
+// diff için field zorunludur. Karşılaştırılacak candidate file ya da to revision bulunmak zorundadır.
+func CheckEnvMapDiff(c *gin.Context) {
+	input, ok := bindRequestBody[e.EnvMapDiffInput](c)
+	if !ok {
+		return
+	}
+
+	var err error
+	switch {
+	case input.Field == "":
+		err = errors.New("field required")
+	case len(input.File) == 0 && input.ToRevision == 0:
+		err = errors.New("file or to-revision required")
+	case len(input.File) > 0 && input.ToRevision != 0:
+		err = errors.New("file and to-revision cannot be used together")
+	}
+	if err != nil {
+		abortWithError(c, env.GetFuncError(env.InvalidRequestBody, err))
+		return
+	}
+
+	c.Set(env.CtxRequestInput, input)
+	c.Next()
+}
@@ -0,0 +1,114 @@
+package validations
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+
+	"github.com/gin-gonic/gin"
+)
+
+/*
+- topic ismi path üzerinden alınır, batch en az bir record içermek zorundadır.
+- envelope ve encryption headers sistem tarafından yazılır. Reserved prefix ile başlayan client headers kabul edilmez.
+*/
+func CheckProduceKafkaRecords(c *gin.Context) {
+	input, ok := bindRequestBody[e.ProduceKafkaRecordsInput](c)
+	if !ok {
+		return
+	}
+	if len(input.Records) == 0 {
+		abortWithError(c, env.GetFuncError(env.InvalidRequestBody, errors.New("at least one record required")))
+		return
+	}
+	for _, record := range input.Records {
+		for _, header := range record.Headers {
+			if strings.HasPrefix(strings.ToLower(header.Key), env.KafkaReservedHeaderPrefix) {
+				abortWithError(c, env.GetFuncError(env.InvalidRequestBody, errors.New("reserved header "+header.Key)))
+				return
+			}
+		}
+	}
+	input.Topic = c.Param(env.KafkaTopicParam)
+	c.Set(env.CtxRequestInput, input)
+	c.Next()
+}
+
+// group ve en az bir topic zorunludur. OffsetReset boş ise latest kabul edilir.
+func CheckCreateKafkaConsumer(c *gin.Context) {
+	input, ok := bindRequestBody[e.CreateKafkaConsumerInput](c)
+	if !ok {
+		return
+	}
+	if input.Group == "" || len(input.Topics) == 0 || slices.Contains(input.Topics, "") {
+		abortWithError(c, env.GetFuncError(env.InvalidRequestBody, errors.New("group and topics required")))
+		return
+	}
+
+	switch input.OffsetReset {
+	case "":
+		input.OffsetReset = env.KafkaOffsetResetLatest
+	case env.KafkaOffsetResetEarliest, env.KafkaOffsetResetLatest:
+	default:
+		abortWithError(c, env.GetFuncError(env.InvalidRequestBody, errors.New("invalid offset-reset "+input.OffsetReset)))
+		return
+	}
+
+	slices.Sort(input.Topics)
+	input.Topics = slices.Compact(input.Topics)
+	c.Set(env.CtxRequestInput, input)
+	c.Next()
+}
+
+// poll ayarları query üzerinden alınır. Ex: ?max-records=100&timeout-ms=1000
+func CheckPollKafkaConsumer(c *gin.Context) {
+	input := e.PollKafkaConsumerInput{
+		InstanceID: c.Param(env.KafkaConsumerInstanceParam),
+		TimeoutMs:  env.KafkaProxyDefaultPollTimeoutMs,
+	}
+
+	if rawMaxRecords := c.Query(env.MaxRecordsQuery); rawMaxRecords != "" {
+		maxRecords, err := strconv.Atoi(rawMaxRecords)
+		if err != nil || maxRecords < 1 {
+			abortWithError(c, env.GetFuncError(env.InvalidRequestBody, errors.New("invalid "+env.MaxRecordsQuery)))
+			return
+		}
+		input.MaxRecords = maxRecords
+	}
+
+	if rawTimeoutMs := c.Query(env.TimeoutMsQuery); rawTimeoutMs != "" {
+		timeoutMs, err := strconv.ParseInt(rawTimeoutMs, 10, 64)
+		if err != nil || timeoutMs < 0 || timeoutMs > env.KafkaProxyMaxPollTimeoutMs {
+			abortWithError(c, env.GetFuncError(env.InvalidRequestBody, errors.New("invalid "+env.TimeoutMsQuery)))
+			return
+		}
+		input.TimeoutMs = timeoutMs
+	}
+
+	c.Set(env.CtxRequestInput, input)
+	c.Next()
+}
+
+// body boş ise son poll ile alınan bütün records commit edilir.
+func CheckCommitKafkaOffsets(c *gin.Context) {
+	input := e.CommitKafkaOffsetsInput{}
+	if c.Request.ContentLength != 0 {
+		var ok bool
+		if input, ok = bindRequestBody[e.CommitKafkaOffsetsInput](c); !ok {
+			return
+		}
+	}
+
+	for _, offset := range input.Offsets {
+		if offset.Topic == "" || offset.Partition < 0 || offset.Offset < 0 {
+			abortWithError(c, env.GetFuncError(env.InvalidRequestBody, errors.New("invalid offset")))
+			return
+		}
+	}
+	input.InstanceID = c.Param(env.KafkaConsumerInstanceParam)
+	c.Set(env.CtxRequestInput, input)
+	c.Next()
+}
@@ -0,0 +1,66 @@
+package validations
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	e "web_server/domain/entities"
+	env "web_server/environments/processors"
+	u "web_server/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// request body en fazla 1MB olabilir.
+const maxRequestBodySize = 1 << 20
+
+// hata cevabı Accept header bilgisine göre yazılır ve istek sonlandırılır.
+func abortWithError(c *gin.Context, err error) {
+	locale := u.ResponseLocale(c.GetHeader(env.AcceptLanguageHeader))
+	contentType, body, encodeErr := u.EncodeResponseBody(c.GetHeader("Accept"), env.FuncErrorResponse(err, locale))
+	if encodeErr != nil {
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	c.Data(env.FuncErrorHTTPStatus(err), contentType, body)
+	c.Abort()
+}
+
+// request body Content-Type bilgisine göre T türüne çevrilir.
+func bindRequestBody[T any](c *gin.Context) (T, bool) {
+	var input T
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxRequestBodySize+1))
+	if err == nil && len(body) > maxRequestBodySize {
+		err = errors.New("request body exceeds 1MB")
+	}
+	if err != nil {
+		abortWithError(c, env.GetFuncError(env.InvalidRequestBody, err))
+		return input, false
+	}
+
+	if err := u.DecodeRequestBody(c.GetHeader("Content-Type"), body, &input); err != nil {
+		abortWithError(c, err)
+		return input, false
+	}
+	return input, true
+}
+
+func CheckCreateKafkaTopic(c *gin.Context) {
+	input, ok := bindRequestBody[e.CreateKafkaTopicInput](c)
+	if !ok {
+		return
+	}
+	c.Set(env.CtxRequestInput, input)
+	c.Next()
+}
+
+// topic ismi path üzerinden alınır, body içerisinde topic ismi bulunamaz.
+func CheckAlterKafkaTopicConfig(c *gin.Context) {
+	input, ok := bindRequestBody[e.AlterKafkaTopicConfigInput](c)
+	if !ok {
+		return
+	}
+	input.Name = c.Param(env.KafkaTopicParam)
+	c.Set(env.CtxRequestInput, input)
+	c.Next()
+}